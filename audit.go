@@ -0,0 +1,167 @@
+package sqlx
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-courier/sqlx/v2/builder"
+)
+
+// AuditOperation operation of change written into history table
+type AuditOperation string
+
+const (
+	AuditOperationCreate AuditOperation = "CREATE"
+	AuditOperationUpdate AuditOperation = "UPDATE"
+	AuditOperationDelete AuditOperation = "DELETE"
+	// AuditOperationUpsert row may be created or updated
+	AuditOperationUpsert AuditOperation = "UPSERT"
+)
+
+type contextKeyAuditActor struct{}
+
+// ContextWithAuditActor sets actor of changes,
+// which will be written into history tables by db.WithContext(ctx)
+func ContextWithAuditActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, contextKeyAuditActor{}, actor)
+}
+
+func AuditActorFromContext(ctx context.Context) string {
+	if actor, ok := ctx.Value(contextKeyAuditActor{}).(string); ok {
+		return actor
+	}
+	return ""
+}
+
+// WriteHistory copies rows of model matched condition into history table of model,
+// with operation, actor from context of db and now.
+// model should implement builder.WithHistoryTable, and be registered by Database.Register
+func WriteHistory(db DBExecutor, model builder.Model, operation AuditOperation, condition builder.SqlCondition) error {
+	withHistoryTable, ok := model.(builder.WithHistoryTable)
+	if !ok {
+		return fmt.Errorf("model %s is not audited", model.TableName())
+	}
+
+	table := db.T(model)
+
+	history := db.D().Table(withHistoryTable.HistoryTableName())
+	if history == nil {
+		return fmt.Errorf("history table %s of %s is not registered", withHistoryTable.HistoryTableName(), table.Name)
+	}
+
+	cols := &builder.Columns{}
+	values := &builder.Columns{}
+
+	table.Columns.Range(func(col *builder.Column, idx int) {
+		// deprecated columns are dropped or renamed when migrating
+		if col.DeprecatedActions != nil {
+			return
+		}
+		cols.Add(history.F(col.FieldName))
+		values.Add(col)
+	})
+
+	cols.Add(
+		history.F(builder.HistoryFieldOperation),
+		history.F(builder.HistoryFieldActor),
+		history.F(builder.HistoryFieldOperatedAt),
+	)
+
+	_, err := db.ExecExpr(
+		builder.Insert().Into(history).Values(
+			cols,
+			builder.Select(
+				builder.Expr("?,?,?,?", values, string(operation), AuditActorFromContext(db.Context()), time.Now()),
+			).From(table, builder.Where(condition)),
+		),
+	)
+	return err
+}
+
+// WriteHistoryOfCreated writes history of the row created by model just now.
+//
+// the row will be matched by primary key, or unique index without auto increment field,
+// or auto increment value inserted by last INSERT when dialect is builder.LastInsertIDDialect
+func WriteHistoryOfCreated(db DBExecutor, model builder.Model) error {
+	condition, err := conditionOfCreated(db, model)
+	if err != nil {
+		return err
+	}
+	return WriteHistory(db, model, AuditOperationCreate, condition)
+}
+
+// WriteHistoryOfUpdated writes history of the row updated by fieldValues,
+// which matched by fields named by fieldNames of model before updating
+func WriteHistoryOfUpdated(db DBExecutor, model builder.Model, operation AuditOperation, fieldValues builder.FieldValues, fieldNames ...string) error {
+	table := db.T(model)
+
+	values := builder.FieldValuesFromStructBy(model, fieldNames)
+	for _, fieldName := range fieldNames {
+		// key fields may be changed by updating
+		if v, ok := fieldValues[fieldName]; ok {
+			values[fieldName] = v
+		}
+	}
+
+	return WriteHistory(db, model, operation, conditionOfFieldValues(table, values, fieldNames))
+}
+
+func conditionOfCreated(db DBExecutor, model builder.Model) (builder.SqlCondition, error) {
+	table := db.T(model)
+	autoIncrement := table.AutoIncrement()
+
+	fieldValues := builder.FieldValuesFromStructByNonZero(model)
+
+	usable := func(key *builder.Key) bool {
+		if key == nil || !key.IsUnique || len(key.Def.FieldNames) == 0 {
+			return false
+		}
+		for _, fieldName := range key.Def.FieldNames {
+			if autoIncrement != nil && fieldName == autoIncrement.FieldName {
+				// auto increment value is only known when set
+				if _, ok := fieldValues[fieldName]; !ok {
+					return false
+				}
+			}
+		}
+		return true
+	}
+
+	if primary := table.Keys.Key("primary"); usable(primary) {
+		return conditionOfFieldValues(table, builder.FieldValuesFromStructBy(model, primary.Def.FieldNames), primary.Def.FieldNames), nil
+	}
+
+	uniqueIndexes := make([]*builder.Key, 0)
+
+	table.Keys.Range(func(key *builder.Key, idx int) {
+		if !key.IsPrimary() && usable(key) {
+			uniqueIndexes = append(uniqueIndexes, key)
+		}
+	})
+
+	if len(uniqueIndexes) > 0 {
+		sort.Slice(uniqueIndexes, func(i, j int) bool {
+			return uniqueIndexes[i].Name < uniqueIndexes[j].Name
+		})
+		key := uniqueIndexes[0]
+		return conditionOfFieldValues(table, builder.FieldValuesFromStructBy(model, key.Def.FieldNames), key.Def.FieldNames), nil
+	}
+
+	if autoIncrement != nil {
+		if dialect, ok := db.Dialect().(builder.LastInsertIDDialect); ok {
+			return autoIncrement.Eq(dialect.LastInsertID(autoIncrement)), nil
+		}
+	}
+
+	return nil, fmt.Errorf("cannot match created row of %s, primary key or unique index is required", table.Name)
+}
+
+func conditionOfFieldValues(table *builder.Table, fieldValues builder.FieldValues, fieldNames []string) builder.SqlCondition {
+	conditions := make([]builder.SqlCondition, len(fieldNames))
+	for i, fieldName := range fieldNames {
+		conditions[i] = table.F(fieldName).Eq(fieldValues[fieldName])
+	}
+	return builder.And(conditions...)
+}
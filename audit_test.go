@@ -0,0 +1,119 @@
+package sqlx_test
+
+import (
+	"database/sql/driver"
+	"testing"
+
+	"github.com/go-courier/sqlx/v2"
+	"github.com/go-courier/sqlx/v2/builder"
+	"github.com/go-courier/sqlx/v2/migration"
+	"github.com/google/uuid"
+	. "github.com/onsi/gomega"
+)
+
+type AuditedUser struct {
+	User
+}
+
+func (user *AuditedUser) HistoryTableName() string {
+	return "t_user_history"
+}
+
+func TestWriteHistory(t *testing.T) {
+	dbTest := sqlx.NewDatabase("test_audit")
+
+	for _, connector := range []driver.Connector{
+		mysqlConnector,
+		postgresConnector,
+	} {
+		t.Run("", func(t *testing.T) {
+			d := dbTest.OpenDB(connector)
+
+			db := d.WithContext(sqlx.ContextWithAuditActor(d.Context(), "admin"))
+
+			userTable := dbTest.Register(&AuditedUser{})
+
+			historyTable := dbTest.Table("t_user_history")
+			NewWithT(t).Expect(historyTable).NotTo(BeNil())
+
+			err := migration.Migrate(db, nil)
+			NewWithT(t).Expect(err).To(BeNil())
+
+			user := AuditedUser{}
+			user.Name = uuid.New().String()
+			user.Gender = GenderMale
+
+			t.Run("create", func(t *testing.T) {
+				err := sqlx.NewTasks(db).With(
+					func(db sqlx.DBExecutor) error {
+						_, err := db.ExecExpr(sqlx.InsertToDB(db, &user, nil))
+						return err
+					},
+					func(db sqlx.DBExecutor) error {
+						return sqlx.WriteHistoryOfCreated(db, &user)
+					},
+				).Do()
+				NewWithT(t).Expect(err).To(BeNil())
+			})
+
+			t.Run("update", func(t *testing.T) {
+				fieldValues := builder.FieldValues{"Gender": GenderFemale}
+
+				err := sqlx.NewTasks(db).With(
+					func(db sqlx.DBExecutor) error {
+						_, err := db.ExecExpr(
+							builder.Update(userTable).
+								Set(userTable.AssignmentsByFieldValues(fieldValues)...).
+								Where(userTable.F("Name").Eq(user.Name)),
+						)
+						return err
+					},
+					func(db sqlx.DBExecutor) error {
+						return sqlx.WriteHistoryOfUpdated(db, &user, sqlx.AuditOperationUpdate, fieldValues, "Name")
+					},
+				).Do()
+				NewWithT(t).Expect(err).To(BeNil())
+			})
+
+			t.Run("delete", func(t *testing.T) {
+				condition := userTable.F("Name").Eq(user.Name)
+
+				err := sqlx.NewTasks(db).With(
+					func(db sqlx.DBExecutor) error {
+						return sqlx.WriteHistory(db, &user, sqlx.AuditOperationDelete, condition)
+					},
+					func(db sqlx.DBExecutor) error {
+						_, err := db.ExecExpr(builder.Delete().From(userTable, builder.Where(condition)))
+						return err
+					},
+				).Do()
+				NewWithT(t).Expect(err).To(BeNil())
+			})
+
+			t.Run("history", func(t *testing.T) {
+				operations := make([]string, 0)
+
+				err := db.QueryExprAndScan(
+					builder.Select(historyTable.F(builder.HistoryFieldOperation)).From(
+						historyTable,
+						builder.Where(
+							builder.And(
+								historyTable.F("Name").Eq(user.Name),
+								historyTable.F(builder.HistoryFieldActor).Eq("admin"),
+							),
+						),
+						builder.OrderBy(builder.AscOrder(historyTable.F(builder.HistoryFieldID))),
+					),
+					&operations,
+				)
+				NewWithT(t).Expect(err).To(BeNil())
+				NewWithT(t).Expect(operations).To(Equal([]string{"CREATE", "UPDATE", "DELETE"}))
+			})
+
+			db.(*sqlx.DB).Tables.Range(func(table *builder.Table, idx int) {
+				_, err := db.ExecExpr(db.Dialect().DropTable(table))
+				NewWithT(t).Expect(err).To(BeNil())
+			})
+		})
+	}
+}
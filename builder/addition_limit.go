@@ -32,6 +32,14 @@ func (l limit) Offset(offset int64) *limit {
 	return &l
 }
 
+func (l *limit) RowCount() int64 {
+	return l.rowCount
+}
+
+func (l *limit) OffsetCount() int64 {
+	return l.offsetCount
+}
+
 func (l *limit) IsNil() bool {
 	return l == nil || l.rowCount <= 0
 }
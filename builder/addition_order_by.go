@@ -33,6 +33,10 @@ type orderBy struct {
 	orders []*Order
 }
 
+func (o *orderBy) Orders() []*Order {
+	return o.orders
+}
+
 func (o *orderBy) IsNil() bool {
 	return o == nil || len(o.orders) == 0
 }
@@ -63,6 +67,15 @@ type Order struct {
 	typ    string
 }
 
+func (o *Order) Target() SqlExpr {
+	return o.target
+}
+
+// Type returns ASC or DESC, empty means default order
+func (o *Order) Type() string {
+	return o.typ
+}
+
 func (o *Order) IsNil() bool {
 	return o == nil || IsNilExpr(o.target)
 }
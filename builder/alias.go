@@ -32,8 +32,24 @@ func MultiMayAutoAlias(columns ...SqlExpr) *exMayAutoAlias {
 	}
 }
 
+// MultiMayAutoAliasWithTableName like MultiMayAutoAlias,
+// but column of table will be aliased as AutoAliasName, like t_user.f_id AS t_user__f_id,
+// so columns of joined tables could be scanned into nested structs.
+func MultiMayAutoAliasWithTableName(columns ...SqlExpr) *exMayAutoAlias {
+	return &exMayAutoAlias{
+		columns:       columns,
+		withTableName: true,
+	}
+}
+
+// AutoAliasName alias of column of table for scanning into field of model of table
+func AutoAliasName(tableName string, columnName string) string {
+	return tableName + "__" + columnName
+}
+
 type exMayAutoAlias struct {
-	columns []SqlExpr
+	columns       []SqlExpr
+	withTableName bool
 }
 
 func (alias *exMayAutoAlias) IsNil() bool {
@@ -52,6 +68,7 @@ func (alias *exMayAutoAlias) Ex(ctx context.Context) *Ex {
 	})
 
 	return e.Ex(ContextWithToggles(ctx, Toggles{
-		ToggleNeedAutoAlias: true,
+		ToggleNeedAutoAlias:          true,
+		ToggleAutoAliasWithTableName: alias.withTableName,
 	}))
 }
@@ -12,4 +12,14 @@ func TestAlias(t *testing.T) {
 	t.Run("alias", func(t *testing.T) {
 		gomega.NewWithT(t).Expect(Alias(Expr("f_id"), "id")).To(BeExpr("f_id AS id"))
 	})
+
+	t.Run("auto alias with table name", func(t *testing.T) {
+		tUser := T("t_user", Col("f_id").Field("ID").Type(uint64(0), ""))
+		tOrg := T("t_org", Col("f_id").Field("ID").Type(uint64(0), ""), Col("f_user_id").Field("UserID").Type(uint64(0), ""))
+
+		gomega.NewWithT(t).Expect(
+			Select(MultiMayAutoAliasWithTableName(&tUser.Columns, &tOrg.Columns)).
+				From(tUser, LeftJoin(tOrg).On(tOrg.Col("f_user_id").Eq(tUser.Col("f_id")))),
+		).To(BeExpr("SELECT t_user.f_id AS t_user__f_id, t_org.f_id AS t_org__f_id,t_org.f_user_id AS t_org__f_user_id FROM t_user\nLEFT JOIN t_org ON t_org.f_user_id = t_user.f_id"))
+	})
 }
@@ -12,6 +12,13 @@ func ColumnTypeFromTypeAndTag(typ typex.Type, nameAndFlags string) *ColumnType {
 	ct := &ColumnType{}
 	ct.Type = typex.Deref(typ)
 
+	if rv, ok := typex.TryNew(ct.Type); ok {
+		if nv, ok := rv.Interface().(NullableValue); ok {
+			ct.Type = typex.Deref(typex.FromRType(nv.UnderlyingType()))
+			ct.Null = true
+		}
+	}
+
 	if strings.Contains(nameAndFlags, ",") {
 		for _, flag := range strings.Split(nameAndFlags, ",")[1:] {
 			nameAndValue := strings.Split(flag, "=")
@@ -48,7 +55,14 @@ func ColumnTypeFromTypeAndTag(typ typex.Type, nameAndFlags string) *ColumnType {
 				if len(nameAndValue) == 1 {
 					panic(fmt.Errorf("missing default value"))
 				}
-				ct.Default = &nameAndValue[1]
+				switch strings.ToLower(nameAndValue[1]) {
+				case "uuid", "uuidv4":
+					ct.AutoUUID = "v4"
+				case "uuidv7":
+					ct.AutoUUID = "v7"
+				default:
+					ct.Default = &nameAndValue[1]
+				}
 			case "onupdate":
 				if len(nameAndValue) == 1 {
 					panic(fmt.Errorf("missing onupdate value"))
@@ -69,6 +83,7 @@ type ColumnType struct {
 	OnUpdate          *string
 	Null              bool
 	AutoIncrement     bool
+	AutoUUID          string
 	DeprecatedActions *DeprecatedActions
 	Comment           string
 	Description       []string
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	. "github.com/go-courier/sqlx/v2/builder"
+	"github.com/go-courier/sqlx/v2/datatypes"
 	"github.com/go-courier/x/ptr"
 	"github.com/go-courier/x/types"
 	"github.com/onsi/gomega"
@@ -36,6 +37,14 @@ func TestColumnTypeFromTypeAndTag(t *testing.T) {
 			Type:    types.FromRType(reflect.TypeOf("")),
 			Default: ptr.String(`'1'`),
 		},
+		`,default=uuid`: &ColumnType{
+			Type:     types.FromRType(reflect.TypeOf([16]byte{})),
+			AutoUUID: "v4",
+		},
+		`,default=uuidv7`: &ColumnType{
+			Type:     types.FromRType(reflect.TypeOf([16]byte{})),
+			AutoUUID: "v7",
+		},
 	}
 
 	for tagValue, ct := range cases {
@@ -43,4 +52,13 @@ func TestColumnTypeFromTypeAndTag(t *testing.T) {
 			gomega.NewWithT(t).Expect(ColumnTypeFromTypeAndTag(ct.Type, tagValue)).To(gomega.Equal(ct))
 		})
 	}
+
+	t.Run("Null", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(ColumnTypeFromTypeAndTag(types.FromRType(reflect.TypeOf(datatypes.Null[string]{})), ",size=10")).
+			To(gomega.Equal(&ColumnType{
+				Type:   types.FromRType(reflect.TypeOf("")),
+				Length: 10,
+				Null:   true,
+			}))
+	})
 }
@@ -37,6 +37,11 @@ func (c *Condition) Ex(ctx context.Context) *Ex {
 	return c.expr.Ex(ctx)
 }
 
+// Expr returns wrapped expr of condition
+func (c *Condition) Expr() SqlExpr {
+	return c.expr
+}
+
 func (c *Condition) IsNil() bool {
 	return c == nil || IsNilExpr(c.expr)
 }
@@ -99,6 +104,16 @@ type ComposedCondition struct {
 	conditions []SqlCondition
 }
 
+// Op returns operator of composed conditions, one of AND, OR and XOR
+func (c *ComposedCondition) Op() string {
+	return c.op
+}
+
+// Conditions returns composed conditions
+func (c *ComposedCondition) Conditions() []SqlCondition {
+	return c.conditions
+}
+
 func (c *ComposedCondition) And(cond SqlCondition) SqlCondition {
 	return And(c, cond)
 }
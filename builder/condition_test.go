@@ -1,10 +1,12 @@
 package builder_test
 
 import (
+	"context"
 	"testing"
 
 	. "github.com/go-courier/sqlx/v2/builder"
 	. "github.com/go-courier/sqlx/v2/builder/buidertestingutils"
+	"github.com/go-courier/sqlx/v2/datatypes"
 	"github.com/onsi/gomega"
 )
 
@@ -135,6 +137,31 @@ func TestConditions(t *testing.T) {
 			"e", "f",
 		))
 	})
+	t.Run("In of row values", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(
+			Cols("a", "b").In([]interface{}{1, "x"}, []interface{}{2, "y"}),
+		).To(BeExpr(
+			"(a,b) IN ((?,?),(?,?))",
+			1, "x", 2, "y",
+		))
+	})
+	t.Run("In of row values by OR", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(
+			Cols("a", "b").InByOr([]interface{}{1, "x"}, []interface{}{2, "y"}),
+		).To(BeExpr(
+			"((a = ?) AND (b = ?)) OR ((a = ?) AND (b = ?))",
+			1, "x", 2, "y",
+		))
+	})
+	t.Run("In of row values mismatched", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(func() {
+			Cols("a", "b").In([]interface{}{1, "x"}, []interface{}{2})
+		}).To(gomega.Panic())
+
+		gomega.NewWithT(t).Expect(func() {
+			Cols("a", "b").InByOr([]interface{}{1, "x", 3})
+		}).To(gomega.Panic())
+	})
 	t.Run("NotIn", func(t *testing.T) {
 		gomega.NewWithT(t).Expect(
 			Col("d").NotIn("e", "f"),
@@ -205,4 +232,58 @@ func TestConditions(t *testing.T) {
 			"d IS NOT NULL",
 		))
 	})
+	t.Run("Contains all", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(
+			Col("d").ContainsAll(datatypes.StringArray{"a", "b"}),
+		).To(BeExpr(
+			"d @> ?",
+			datatypes.StringArray{"a", "b"},
+		))
+	})
+	t.Run("Overlaps", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(
+			Col("d").Overlaps(datatypes.Int64Array{1, 2}),
+		).To(BeExpr(
+			"d && ?",
+			datatypes.Int64Array{1, 2},
+		))
+	})
+	t.Run("Any equal", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(
+			Col("d").AnyEq("a"),
+		).To(BeExpr(
+			"? = ANY(d)",
+			"a",
+		))
+	})
+	t.Run("ST_Contains", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(
+			Col("d").STContains(datatypes.Point{X: 1, Y: 2}),
+		).To(BeExpr(
+			"ST_Contains(d, ST_GeomFromText(?, 4326))",
+			datatypes.Point{X: 1, Y: 2},
+		))
+	})
+	t.Run("ST_Contains in mysql", func(t *testing.T) {
+		e := Col("d").STContains(datatypes.Point{X: 1, Y: 2}).Ex(ContextWithDriverName(context.Background(), "mysql"))
+
+		gomega.NewWithT(t).Expect(e.Query()).To(gomega.Equal("ST_Contains(d, ST_GeomFromText(?, 4326, 'axis-order=long-lat'))"))
+		gomega.NewWithT(t).Expect(e.Args()).To(gomega.Equal([]interface{}{datatypes.Point{X: 1, Y: 2}}))
+	})
+	t.Run("ST_Distance_Sphere", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(
+			Col("d").STDistanceSphereLte(datatypes.Point{X: 1, Y: 2}, 1000),
+		).To(BeExpr(
+			"ST_Distance_Sphere(d, ST_GeomFromText(?, 4326)) <= ?",
+			datatypes.Point{X: 1, Y: 2}, float64(1000),
+		))
+	})
+	t.Run("ST_DWithin", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(
+			Col("d").STDWithin(datatypes.Point{X: 1, Y: 2}, 1000),
+		).To(BeExpr(
+			"(d && ST_Buffer(ST_GeomFromText(?, 4326)::geography, ?)::geometry AND ST_DWithin(d::geography, ST_GeomFromText(?, 4326)::geography, ?))",
+			datatypes.Point{X: 1, Y: 2}, float64(1000), datatypes.Point{X: 1, Y: 2}, float64(1000),
+		))
+	})
 }
@@ -2,6 +2,8 @@ package builder
 
 import (
 	"context"
+	"database/sql/driver"
+	"fmt"
 	"reflect"
 	"strings"
 
@@ -50,6 +52,9 @@ func (c *Column) Ex(ctx context.Context) *Ex {
 	toggles := TogglesFromContext(ctx)
 	if c.Table != nil && (c.exactly || toggles.Is(ToggleMultiTable)) {
 		if toggles.Is(ToggleNeedAutoAlias) {
+			if toggles.Is(ToggleAutoAliasWithTableName) {
+				return Expr("?.? AS ?", c.Table, Expr(c.Name), Expr(AutoAliasName(c.Table.Name, c.Name))).Ex(ctx)
+			}
 			return Expr("?.? AS ?", c.Table, Expr(c.Name), Expr(c.Name)).Ex(ctx)
 		}
 		return Expr("?.?", c.Table, Expr(c.Name)).Ex(ctx)
@@ -105,12 +110,57 @@ func (c *Column) ValueBy(v interface{}) *Assignment {
 	return ColumnsAndValues(c, v)
 }
 
-func (c *Column) Incr(d int) SqlExpr {
-	return Expr("? + ?", c, d)
+// Incr d could be int or other number types like datatypes.Decimal
+func (c *Column) Incr(d interface{}) SqlExpr {
+	return Expr("? + ?", c, c.delta(d))
+}
+
+// Dec d could be int or other number types like datatypes.Decimal
+func (c *Column) Dec(d interface{}) SqlExpr {
+	return Expr("? - ?", c, c.delta(d))
 }
 
-func (c *Column) Dec(d int) SqlExpr {
-	return Expr("? - ?", c, d)
+// delta casts number in string, like value of datatypes.Decimal, to decimal with size of column,
+// avoid calculating as double in mysql.
+// size of the value is used when column without size, fractional digits of the value will be kept.
+func (c *Column) delta(d interface{}) interface{} {
+	if valuer, ok := d.(driver.Valuer); ok {
+		if v, err := valuer.Value(); err == nil {
+			if s, ok := v.(string); ok {
+				length, decimal := decimalSizeOf(s)
+				if c.ColumnType != nil && c.Length > 0 {
+					length, decimal = c.Length, c.Decimal
+				}
+				return Expr(fmt.Sprintf("CAST(? AS DECIMAL(%d,%d))", length, decimal), d)
+			}
+		}
+	}
+
+	return d
+}
+
+// decimalSizeOf precision and scale of number in string like -123.45, limited by max size of mysql decimal(65,30)
+func decimalSizeOf(s string) (length uint64, decimal uint64) {
+	s = strings.TrimLeft(s, "+-")
+
+	integer := s
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		integer, decimal = s[:i], uint64(len(s)-i-1)
+	}
+
+	if decimal > 30 {
+		decimal = 30
+	}
+
+	length = uint64(len(integer)) + decimal
+	if length == decimal {
+		length++
+	}
+	if length > 65 {
+		length = 65
+	}
+
+	return length, decimal
 }
 
 func (c *Column) Like(v string) SqlCondition {
@@ -224,3 +274,35 @@ func (c *Column) Lt(v interface{}) SqlCondition {
 func (c *Column) Lte(v interface{}) SqlCondition {
 	return AsCond(Expr("? <= ?", c, v))
 }
+
+// ContainsAll for array column, v should be array value like datatypes.StringArray
+func (c *Column) ContainsAll(v interface{}) SqlCondition {
+	return AsCond(Expr("? @> ?", c, v))
+}
+
+// Overlaps for array column, v should be array value like datatypes.StringArray
+func (c *Column) Overlaps(v interface{}) SqlCondition {
+	return AsCond(Expr("? && ?", c, v))
+}
+
+// AnyEq for array column, matched when any element equals v
+func (c *Column) AnyEq(v interface{}) SqlCondition {
+	return AsCond(Expr("? = ANY(?)", v, c))
+}
+
+// STContains for geometry column, matched when column contains g, g should be geometry value like datatypes.Point
+func (c *Column) STContains(g interface{}) SqlCondition {
+	return AsCond(Expr("ST_Contains(?, ?)", c, g))
+}
+
+// STDistanceSphereLte for geometry column in mysql, matched when spherical distance to g is less or equal than meters
+func (c *Column) STDistanceSphereLte(g interface{}, meters float64) SqlCondition {
+	return AsCond(Expr("ST_Distance_Sphere(?, ?) <= ?", c, g, meters))
+}
+
+// STDWithin for geometry column in postgres with PostGIS, matched when distance to g is within meters.
+// column compared with bounding box of buffer around g first, so gist index of the column could be used,
+// then distance checked exactly in geography.
+func (c *Column) STDWithin(g interface{}, meters float64) SqlCondition {
+	return AsCond(Expr("(? && ST_Buffer(?::geography, ?)::geometry AND ST_DWithin(?::geography, ?::geography, ?))", c, g, meters, c, g, meters))
+}
@@ -181,3 +181,82 @@ func (cols *Columns) Range(cb func(col *Column, idx int)) {
 		cb(cols.l[i], i)
 	}
 }
+
+// In returns condition of row values, like `(f_a,f_b) IN ((?,?),(?,?))`,
+// each of values should be listed in same order of columns, and panics when count of values not matched.
+func (cols *Columns) In(values ...[]interface{}) SqlCondition {
+	n := len(values)
+	if n == 0 || cols.IsNil() {
+		return nil
+	}
+
+	cols.mustMatchRowValues(values)
+
+	e := Expr("")
+	e.Grow(n*cols.Len() + 1)
+
+	e.WriteGroup(func(e *Ex) {
+		e.WriteExpr(cols)
+	})
+
+	e.WriteQuery(" IN ")
+
+	e.WriteGroup(func(e *Ex) {
+		for i := range values {
+			if i > 0 {
+				e.WriteQueryByte(',')
+			}
+
+			e.WriteGroup(func(e *Ex) {
+				for j := 0; j < cols.Len(); j++ {
+					e.WriteHolder(j)
+				}
+			})
+
+			e.AppendArgs(values[i]...)
+		}
+	})
+
+	return AsCond(e)
+}
+
+// InByOr same as In, but expanded as `((f_a = ?) AND (f_b = ?)) OR ((f_a = ?) AND (f_b = ?))`
+// for dialects without row value supported.
+func (cols *Columns) InByOr(values ...[]interface{}) SqlCondition {
+	if len(values) == 0 || cols.IsNil() {
+		return nil
+	}
+
+	cols.mustMatchRowValues(values)
+
+	conditions := make([]SqlCondition, len(values))
+
+	for i := range values {
+		eqs := make([]SqlCondition, 0, cols.Len())
+
+		cols.Range(func(col *Column, idx int) {
+			eqs = append(eqs, col.Eq(values[i][idx]))
+		})
+
+		conditions[i] = And(eqs...)
+	}
+
+	return Or(conditions...)
+}
+
+func (cols *Columns) mustMatchRowValues(values [][]interface{}) {
+	for i := range values {
+		if len(values[i]) != cols.Len() {
+			panic(fmt.Errorf("row value %d has %d values, but %d columns required", i, len(values[i]), cols.Len()))
+		}
+	}
+}
+
+// ColumnsIn returns condition of row values by Columns.In when dialect is RowValueDialect and supported,
+// otherwise by Columns.InByOr.
+func ColumnsIn(dialect Dialect, cols *Columns, values ...[]interface{}) SqlCondition {
+	if d, ok := dialect.(RowValueDialect); ok && d.SupportRowValue() {
+		return cols.In(values...)
+	}
+	return cols.InByOr(values...)
+}
@@ -0,0 +1,60 @@
+package builder
+
+import (
+	"time"
+)
+
+// WithHistoryTable declares table audited,
+// generated methods will copy changed rows into the history table, which is created by HistoryTable
+type WithHistoryTable interface {
+	HistoryTableName() string
+}
+
+// field names of the change in history table
+const (
+	HistoryFieldID         = "HistoryID"
+	HistoryFieldOperation  = "HistoryOperation"
+	HistoryFieldActor      = "HistoryActor"
+	HistoryFieldOperatedAt = "HistoryOperatedAt"
+)
+
+// HistoryTable creates history table of table.
+//
+// history table has same columns of table without auto increment, auto uuid, on update and relation,
+// and f_history_id as primary key, f_history_operation, f_history_actor and f_history_operated_at for the change.
+// primary key of table will be an index of history table, for querying changes of one row.
+func HistoryTable(table *Table, name string) *Table {
+	history := T(name)
+	history.Schema = table.Schema
+	history.Description = []string{"history of " + table.Name}
+
+	table.Columns.Range(func(col *Column, idx int) {
+		columnType := *col.ColumnType
+		// values are copied from table, and rows of history never reference other tables
+		columnType.AutoIncrement = false
+		columnType.AutoUUID = ""
+		columnType.OnUpdate = nil
+		columnType.Relation = nil
+
+		history.AddCol(&Column{
+			Name:       col.Name,
+			FieldName:  col.FieldName,
+			ColumnType: &columnType,
+		})
+	})
+
+	history.AddCol(Col("f_history_id").Field(HistoryFieldID).Type(uint64(0), ",autoincrement"))
+	history.AddCol(Col("f_history_operation").Field(HistoryFieldOperation).Type("", ",size=16"))
+	history.AddCol(Col("f_history_actor").Field(HistoryFieldActor).Type("", ",size=255,default=''"))
+	history.AddCol(Col("f_history_operated_at").Field(HistoryFieldOperatedAt).Type(time.Time{}, ""))
+
+	history.AddKey(PrimaryKey(history.MustFields(HistoryFieldID)))
+
+	if primary := table.Keys.Key("primary"); primary != nil && len(primary.Def.FieldNames) > 0 {
+		if cols, err := history.Fields(primary.Def.FieldNames...); err == nil {
+			history.AddKey(Index("i_history_key", cols))
+		}
+	}
+
+	return history
+}
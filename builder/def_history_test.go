@@ -0,0 +1,53 @@
+package builder_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-courier/sqlx/v2/connectors/postgresql"
+
+	. "github.com/go-courier/sqlx/v2/builder"
+	"github.com/onsi/gomega"
+)
+
+func TestHistoryTable(t *testing.T) {
+	tUser := T("t_user",
+		Col("f_id").Field("ID").Type(uint64(0), ",autoincrement"),
+		Col("f_name").Field("Name").Type("", ",size=128,default=''"),
+	)
+	tUser.AddKey(PrimaryKey(tUser.MustFields("ID")))
+	tUser.F("Name").Relation = []string{"t_name", "Name"}
+	tUser.AddKey(UniqueIndex("i_name", tUser.MustFields("Name")))
+
+	history := HistoryTable(tUser, "t_user_history")
+
+	t.Run("columns", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(history.Columns.FieldNames()).To(gomega.Equal([]string{
+			"ID", "Name",
+			HistoryFieldID, HistoryFieldOperation, HistoryFieldActor, HistoryFieldOperatedAt,
+		}))
+		gomega.NewWithT(t).Expect(history.AutoIncrement().FieldName).To(gomega.Equal(HistoryFieldID))
+		gomega.NewWithT(t).Expect(tUser.AutoIncrement().FieldName).To(gomega.Equal("ID"))
+		gomega.NewWithT(t).Expect(history.F("Name").Relation).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(tUser.F("Name").Relation).To(gomega.Equal([]string{"t_name", "Name"}))
+	})
+
+	t.Run("keys", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(history.Keys.Len()).To(gomega.Equal(2))
+		gomega.NewWithT(t).Expect(history.Key("primary").Def.FieldNames).To(gomega.Equal([]string{HistoryFieldID}))
+		gomega.NewWithT(t).Expect(history.Key("i_history_key").IsUnique).To(gomega.BeFalse())
+		gomega.NewWithT(t).Expect(history.Key("i_history_key").Def.FieldNames).To(gomega.Equal([]string{"ID"}))
+	})
+
+	t.Run("create", func(t *testing.T) {
+		exprList := (&postgresql.PostgreSQLConnector{}).CreateTableIsNotExists(history)
+
+		exprs := make([]string, len(exprList))
+		for i, expr := range exprList {
+			exprs[i] = expr.Ex(context.Background()).Query()
+		}
+
+		gomega.NewWithT(t).Expect(exprs[0]).To(gomega.ContainSubstring("f_id bigint NOT NULL"))
+		gomega.NewWithT(t).Expect(exprs[0]).To(gomega.ContainSubstring("f_history_id bigserial NOT NULL"))
+	})
+}
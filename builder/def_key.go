@@ -1,6 +1,7 @@
 package builder
 
 import (
+	"regexp"
 	"strings"
 )
 
@@ -82,6 +83,31 @@ func (e IndexDef) TableExpr(t *Table) *Ex {
 	return ex
 }
 
+var reFieldNameInExpr = regexp.MustCompile(`#(\w+)`)
+
+// colNamesOf names of columns of t, or columns referenced by expr like YEAR(#CreatedAt)
+func (e IndexDef) colNamesOf(t *Table) []string {
+	if len(e.ColNames) != 0 {
+		return e.ColNames
+	}
+
+	fieldNames := e.FieldNames
+	if e.Expr != "" {
+		fieldNames = nil
+		for _, matched := range reFieldNameInExpr.FindAllStringSubmatch(e.Expr, -1) {
+			fieldNames = append(fieldNames, matched[1])
+		}
+	}
+
+	colNames := make([]string, 0, len(fieldNames))
+	for _, fieldName := range fieldNames {
+		if col := t.F(fieldName); col != nil {
+			colNames = append(colNames, col.Name)
+		}
+	}
+	return colNames
+}
+
 type Key struct {
 	Table *Table
 
@@ -0,0 +1,196 @@
+package builder
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParsePartitionDef
+// RANGE CreatedAt
+// RANGE YEAR(#CreatedAt)
+func ParsePartitionDef(parts ...string) *PartitionDef {
+	if len(parts) == 0 {
+		return nil
+	}
+
+	p := PartitionDef{
+		Method: strings.ToUpper(parts[0]),
+	}
+
+	if len(parts) > 1 {
+		p.IndexDef = *ParseIndexDef(parts[1:]...)
+	}
+
+	return &p
+}
+
+// PartitionDef declares table partitioned by Method on partition key
+// when creating table, RANGE will be created with a catch-all partition,
+// which is MAXVALUE partition in mysql, or DEFAULT partition in postgres (for LIST too),
+// rows of new partition will be moved out from the catch-all partition when adding it.
+// primary key and unique indexes must include all columns of partition key.
+type PartitionDef struct {
+	// RANGE, LIST or HASH
+	Method string
+	IndexDef
+}
+
+func (p PartitionDef) ToDefs() []string {
+	return append([]string{p.Method}, p.IndexDef.ToDefs()...)
+}
+
+// IsPlainCols partition key is only columns without expr
+func (p PartitionDef) IsPlainCols() bool {
+	return p.Expr == ""
+}
+
+// CheckKeys primary key and unique indexes of partitioned table must include all columns of partition key,
+// which required both by mysql and postgres.
+func (p PartitionDef) CheckKeys(t *Table) (err error) {
+	partitionColNames := p.IndexDef.colNamesOf(t)
+
+	t.Keys.Range(func(key *Key, idx int) {
+		if err != nil || !key.IsUnique {
+			return
+		}
+
+		keyColNames := map[string]bool{}
+		for _, colName := range key.Def.colNamesOf(t) {
+			keyColNames[colName] = true
+		}
+
+		for _, colName := range partitionColNames {
+			if !keyColNames[colName] {
+				err = fmt.Errorf("unique key %s of partitioned table %s should include column %s of partition key %v", key.Name, t.Name, colName, partitionColNames)
+				return
+			}
+		}
+	})
+
+	return
+}
+
+// TableExpr partition key always wrapped by group, like (f_created_at) or (YEAR(f_created_at))
+func (p PartitionDef) TableExpr(t *Table) *Ex {
+	if p.IsPlainCols() {
+		return p.IndexDef.TableExpr(t)
+	}
+	ex := Expr("")
+	ex.WriteGroup(func(ex *Ex) {
+		ex.WriteExpr(p.IndexDef.TableExpr(t))
+	})
+	return ex
+}
+
+// Partition of partitioned table, bounds should be sql literals
+type Partition struct {
+	Name string
+	// lower bound (inclusive) of RANGE, mysql will ignore it
+	From string
+	// upper bound (exclusive) of RANGE
+	To string
+	// values of LIST
+	In []string
+}
+
+type PartitionPeriod string
+
+const (
+	PartitionPeriodDay   PartitionPeriod = "DAY"
+	PartitionPeriodMonth PartitionPeriod = "MONTH"
+	PartitionPeriodYear  PartitionPeriod = "YEAR"
+)
+
+func (p PartitionPeriod) layout() string {
+	switch p {
+	case PartitionPeriodDay:
+		return "20060102"
+	case PartitionPeriodYear:
+		return "2006"
+	}
+	return "200601"
+}
+
+// Truncate returns start of period which contains t
+func (p PartitionPeriod) Truncate(t time.Time) time.Time {
+	switch p {
+	case PartitionPeriodDay:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	case PartitionPeriodYear:
+		return time.Date(t.Year(), 1, 1, 0, 0, 0, 0, t.Location())
+	}
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
+
+// Next returns start of next period of the period which contains t
+func (p PartitionPeriod) Next(t time.Time) time.Time {
+	start := p.Truncate(t)
+
+	switch p {
+	case PartitionPeriodDay:
+		return start.AddDate(0, 0, 1)
+	case PartitionPeriodYear:
+		return start.AddDate(1, 0, 0)
+	}
+	return start.AddDate(0, 1, 0)
+}
+
+// PartitionName returns name of partition of t for the period which contains at, like t_event_202610
+func (p PartitionPeriod) PartitionName(t *Table, at time.Time) string {
+	return t.Name + "_" + p.Truncate(at).Format(p.layout())
+}
+
+// ParsePartitionName returns start of period of partition named by PartitionName
+func (p PartitionPeriod) ParsePartitionName(t *Table, name string, loc *time.Location) (time.Time, bool) {
+	if !strings.HasPrefix(name, t.Name+"_") {
+		return time.Time{}, false
+	}
+
+	period := name[len(t.Name)+1:]
+	if len(period) != len(p.layout()) {
+		return time.Time{}, false
+	}
+
+	start, err := time.ParseInLocation(p.layout(), period, loc)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return start, true
+}
+
+// PartitionBound formats time as sql literal of bound of range partition
+type PartitionBound func(t time.Time) string
+
+// PartitionBoundDatetime for partition key of datetime or timestamp
+func PartitionBoundDatetime(t time.Time) string {
+	return "'" + t.Format("2006-01-02 15:04:05") + "'"
+}
+
+// PartitionBoundUnix for partition key of unix seconds, like datatypes.Timestamp
+func PartitionBoundUnix(t time.Time) string {
+	return strconv.FormatInt(t.Unix(), 10)
+}
+
+// RangePartitions returns count partitions of periods from the period which contains from
+func RangePartitions(t *Table, period PartitionPeriod, from time.Time, count int, bound PartitionBound) []*Partition {
+	partitions := make([]*Partition, 0, count)
+
+	start := period.Truncate(from)
+
+	for i := 0; i < count; i++ {
+		end := period.Next(start)
+
+		partitions = append(partitions, &Partition{
+			Name: period.PartitionName(t, start),
+			From: bound(start),
+			To:   bound(end),
+		})
+
+		start = end
+	}
+
+	return partitions
+}
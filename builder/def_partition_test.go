@@ -0,0 +1,80 @@
+package builder_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/go-courier/sqlx/v2/builder"
+	"github.com/go-courier/sqlx/v2/builder/buidertestingutils"
+	"github.com/onsi/gomega"
+)
+
+func TestPartition(t *testing.T) {
+	tEvent := T("t_event",
+		Col("f_id").Field("ID").Type(uint64(0), ",autoincrement"),
+		Col("f_created_at").Field("CreatedAt").Type(time.Time{}, ""),
+	)
+
+	t.Run("partition key", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(ParsePartitionDef("range", "CreatedAt").TableExpr(tEvent)).
+			To(buidertestingutils.BeExpr("(f_created_at)"))
+		gomega.NewWithT(t).Expect(ParsePartitionDef("RANGE", "YEAR(#CreatedAt)").TableExpr(tEvent)).
+			To(buidertestingutils.BeExpr("(YEAR(f_created_at))"))
+	})
+
+	t.Run("check keys", func(t *testing.T) {
+		tEventWithKeys := T("t_event",
+			Col("f_id").Field("ID").Type(uint64(0), ""),
+			Col("f_created_at").Field("CreatedAt").Type(time.Time{}, ""),
+			PrimaryKey(Cols("f_id", "f_created_at")),
+		)
+
+		gomega.NewWithT(t).Expect(ParsePartitionDef("RANGE", "CreatedAt").CheckKeys(tEventWithKeys)).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(ParsePartitionDef("RANGE", "YEAR(#CreatedAt)").CheckKeys(tEventWithKeys)).To(gomega.BeNil())
+
+		tEventWithKeys.AddKey(UniqueIndex("i_id", Cols("f_id")))
+		gomega.NewWithT(t).Expect(ParsePartitionDef("RANGE", "CreatedAt").CheckKeys(tEventWithKeys)).NotTo(gomega.BeNil())
+
+		gomega.NewWithT(t).Expect(func() {
+			TableFromModel(&EventWithoutPartitionKeyInPrimary{})
+		}).To(gomega.Panic())
+	})
+
+	t.Run("range partitions", func(t *testing.T) {
+		partitions := RangePartitions(tEvent, PartitionPeriodMonth, time.Date(2026, 11, 15, 8, 0, 0, 0, time.UTC), 2, PartitionBoundDatetime)
+
+		gomega.NewWithT(t).Expect(partitions).To(gomega.Equal([]*Partition{
+			{Name: "t_event_202611", From: "'2026-11-01 00:00:00'", To: "'2026-12-01 00:00:00'"},
+			{Name: "t_event_202612", From: "'2026-12-01 00:00:00'", To: "'2027-01-01 00:00:00'"},
+		}))
+	})
+
+	t.Run("parse partition name", func(t *testing.T) {
+		start, ok := PartitionPeriodDay.ParsePartitionName(tEvent, "t_event_20261019", time.UTC)
+		gomega.NewWithT(t).Expect(ok).To(gomega.BeTrue())
+		gomega.NewWithT(t).Expect(start).To(gomega.Equal(time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)))
+
+		_, ok = PartitionPeriodDay.ParsePartitionName(tEvent, "t_event_default", time.UTC)
+		gomega.NewWithT(t).Expect(ok).To(gomega.BeFalse())
+
+		_, ok = PartitionPeriodDay.ParsePartitionName(tEvent, "t_event_202610", time.UTC)
+		gomega.NewWithT(t).Expect(ok).To(gomega.BeFalse())
+	})
+}
+
+type EventWithoutPartitionKeyInPrimary struct {
+	ID        uint64    `db:"f_id"`
+	CreatedAt time.Time `db:"f_created_at"`
+}
+
+func (EventWithoutPartitionKeyInPrimary) TableName() string {
+	return "t_event"
+}
+
+func (EventWithoutPartitionKeyInPrimary) PrimaryKey() []string {
+	return []string{"ID"}
+}
+
+func (EventWithoutPartitionKeyInPrimary) PartitionBy() []string {
+	return []string{"RANGE", "CreatedAt"}
+}
@@ -0,0 +1,159 @@
+package builder
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// StoredFunction stored function, managed by version of its definition
+type StoredFunction struct {
+	Schema string
+	Name   string
+	// args, returns and options,
+	// like `() RETURNS trigger LANGUAGE plpgsql` for postgres, or `(v INT) RETURNS INT DETERMINISTIC` for mysql
+	Def  string
+	Body string
+}
+
+func (fn StoredFunction) WithSchema(schema string) *StoredFunction {
+	fn.Schema = schema
+	return &fn
+}
+
+// Version hash of definition, which will be kept in database for checking changes
+func (fn *StoredFunction) Version() string {
+	return routineVersion(fn.Name, fn.Def, fn.Body)
+}
+
+// Trigger of table, managed by version of its definition
+type Trigger struct {
+	Name string
+	// timing and events, like BEFORE UPDATE
+	When string
+	// postgres should be `EXECUTE PROCEDURE fn()`, mysql could be statements
+	Body string
+}
+
+// Version hash of definition, which will be kept in database for checking changes
+func (trigger *Trigger) Version() string {
+	return routineVersion(trigger.Name, trigger.When, trigger.Body)
+}
+
+var routineVersionRegexp = regexp.MustCompile(`sqlx:[0-9a-f]{40}`)
+
+// ParseRoutineVersion picks version from comment or definition of function or trigger,
+// returns empty when it is not managed
+func ParseRoutineVersion(s string) string {
+	return routineVersionRegexp.FindString(s)
+}
+
+func routineVersion(parts ...string) string {
+	h := sha1.New()
+	for _, part := range parts {
+		_, _ = h.Write([]byte(part))
+		_, _ = h.Write([]byte{'\n'})
+	}
+	return "sqlx:" + hex.EncodeToString(h.Sum(nil))
+}
+
+// RoutinesDiff returns statements to sync functions and triggers of tables,
+// prevFunctions in schema and prevTriggers (by table name) are versions of managed ones in database.
+//
+// triggers removed, or calling functions changed, are dropped first,
+// includes ones of tables not in tables any more,
+// then functions are synced, and triggers are created after, as triggers may depend on functions.
+func RoutinesDiff(dialect RoutineDialect, schema string, functions []*StoredFunction, tables []*Table, prevFunctions map[string]string, prevTriggers map[string]map[string]string) (exprList []SqlExpr) {
+	functionNames := map[string]bool{}
+	changedFunctions := make([]*StoredFunction, 0)
+
+	for _, fn := range functions {
+		functionNames[fn.Name] = true
+
+		if prevFunctions[fn.Name] != fn.Version() {
+			changedFunctions = append(changedFunctions, fn)
+		}
+	}
+
+	callsChangedFunctions := func(trigger *Trigger) bool {
+		for _, fn := range changedFunctions {
+			if _, ok := prevFunctions[fn.Name]; ok && strings.Contains(trigger.Body, fn.Name+"(") {
+				return true
+			}
+		}
+		return false
+	}
+
+	tableNames := map[string]bool{}
+	createTriggers := make([]SqlExpr, 0)
+
+	for _, t := range tables {
+		tableNames[t.Name] = true
+
+		triggerNames := map[string]bool{}
+
+		for _, trigger := range t.Triggers {
+			triggerNames[trigger.Name] = true
+
+			prevVersion, existed := prevTriggers[t.Name][trigger.Name]
+
+			if existed && callsChangedFunctions(trigger) {
+				exprList = append(exprList, dialect.DropTrigger(t, trigger))
+				createTriggers = append(createTriggers, dialect.CreateOrReplaceTrigger(t, trigger)...)
+				continue
+			}
+
+			if prevVersion != trigger.Version() {
+				createTriggers = append(createTriggers, dialect.CreateOrReplaceTrigger(t, trigger)...)
+			}
+		}
+
+		for _, name := range sortedKeys(prevTriggers[t.Name]) {
+			if !triggerNames[name] {
+				exprList = append(exprList, dialect.DropTrigger(t, &Trigger{Name: name}))
+			}
+		}
+	}
+
+	prevTableNames := make([]string, 0, len(prevTriggers))
+	for tableName := range prevTriggers {
+		prevTableNames = append(prevTableNames, tableName)
+	}
+	sort.Strings(prevTableNames)
+
+	for _, tableName := range prevTableNames {
+		if tableNames[tableName] {
+			continue
+		}
+
+		t := T(tableName)
+		t.Schema = schema
+
+		for _, name := range sortedKeys(prevTriggers[tableName]) {
+			exprList = append(exprList, dialect.DropTrigger(t, &Trigger{Name: name}))
+		}
+	}
+
+	for _, fn := range changedFunctions {
+		exprList = append(exprList, dialect.CreateOrReplaceFunction(fn)...)
+	}
+
+	for _, name := range sortedKeys(prevFunctions) {
+		if !functionNames[name] {
+			exprList = append(exprList, dialect.DropFunction(&StoredFunction{Schema: schema, Name: name}))
+		}
+	}
+
+	return append(exprList, createTriggers...)
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
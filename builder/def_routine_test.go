@@ -0,0 +1,70 @@
+package builder_test
+
+import (
+	"testing"
+
+	"github.com/go-courier/sqlx/v2/connectors/postgresql"
+
+	. "github.com/go-courier/sqlx/v2/builder"
+	"github.com/go-courier/sqlx/v2/builder/buidertestingutils"
+	"github.com/onsi/gomega"
+)
+
+func TestRoutinesDiff(t *testing.T) {
+	c := &postgresql.PostgreSQLConnector{}
+
+	fn := &StoredFunction{Name: "fn_touch", Def: "() RETURNS trigger LANGUAGE plpgsql", Body: "BEGIN\n\tRETURN NEW;\nEND"}
+
+	tUser := T("t_user",
+		Col("f_id").Field("ID").Type(uint64(0), ",autoincrement"),
+	)
+	tUser.AddTrigger(&Trigger{Name: "t_user_touch", When: "BEFORE UPDATE", Body: "EXECUTE PROCEDURE fn_touch()"})
+
+	t.Run("version", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(ParseRoutineVersion("prefix " + fn.Version())).To(gomega.Equal(fn.Version()))
+		gomega.NewWithT(t).Expect(ParseRoutineVersion("created by dba")).To(gomega.Equal(""))
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(RoutinesDiff(c, "", []*StoredFunction{fn}, []*Table{tUser},
+			map[string]string{"fn_touch": fn.Version()},
+			map[string]map[string]string{"t_user": {"t_user_touch": tUser.Triggers[0].Version()}},
+		)).To(gomega.HaveLen(0))
+	})
+
+	t.Run("changed and removed", func(t *testing.T) {
+		exprList := RoutinesDiff(c, "", []*StoredFunction{fn}, []*Table{tUser},
+			map[string]string{"fn_touch": fn.Version(), "fn_removed": "sqlx:0000000000000000000000000000000000000000"},
+			map[string]map[string]string{"t_user": {"t_user_touch": "sqlx:0000000000000000000000000000000000000000", "t_user_removed": "sqlx:0000000000000000000000000000000000000000"}},
+		)
+
+		gomega.NewWithT(t).Expect(exprList).To(gomega.HaveLen(5))
+		gomega.NewWithT(t).Expect(exprList[0]).To(buidertestingutils.BeExpr("DROP TRIGGER IF EXISTS t_user_removed ON t_user;"))
+		gomega.NewWithT(t).Expect(exprList[1]).To(buidertestingutils.BeExpr("DROP FUNCTION IF EXISTS fn_removed;"))
+		gomega.NewWithT(t).Expect(exprList[2]).To(buidertestingutils.BeExpr("DROP TRIGGER IF EXISTS t_user_touch ON t_user;"))
+		gomega.NewWithT(t).Expect(exprList[3]).To(buidertestingutils.BeExpr("CREATE TRIGGER t_user_touch BEFORE UPDATE ON t_user FOR EACH ROW EXECUTE PROCEDURE fn_touch();"))
+	})
+
+	t.Run("function changed", func(t *testing.T) {
+		exprList := RoutinesDiff(c, "", []*StoredFunction{fn}, []*Table{tUser},
+			map[string]string{"fn_touch": "sqlx:0000000000000000000000000000000000000000"},
+			map[string]map[string]string{"t_user": {"t_user_touch": tUser.Triggers[0].Version()}},
+		)
+
+		gomega.NewWithT(t).Expect(exprList).To(gomega.HaveLen(7))
+		gomega.NewWithT(t).Expect(exprList[0]).To(buidertestingutils.BeExpr("DROP TRIGGER IF EXISTS t_user_touch ON t_user;"))
+		gomega.NewWithT(t).Expect(exprList[1]).To(buidertestingutils.BeExpr("DROP FUNCTION IF EXISTS fn_touch;"))
+		gomega.NewWithT(t).Expect(exprList[2]).To(buidertestingutils.BeExpr("CREATE FUNCTION fn_touch() RETURNS trigger LANGUAGE plpgsql AS $$\nBEGIN\n\tRETURN NEW;\nEND\n$$;"))
+		gomega.NewWithT(t).Expect(exprList[5]).To(buidertestingutils.BeExpr("CREATE TRIGGER t_user_touch BEFORE UPDATE ON t_user FOR EACH ROW EXECUTE PROCEDURE fn_touch();"))
+	})
+
+	t.Run("triggers of table removed", func(t *testing.T) {
+		exprList := RoutinesDiff(c, "", []*StoredFunction{fn}, nil,
+			map[string]string{"fn_touch": fn.Version()},
+			map[string]map[string]string{"t_user": {"t_user_touch": tUser.Triggers[0].Version()}},
+		)
+
+		gomega.NewWithT(t).Expect(exprList).To(gomega.HaveLen(1))
+		gomega.NewWithT(t).Expect(exprList[0]).To(buidertestingutils.BeExpr("DROP TRIGGER IF EXISTS t_user_touch ON t_user;"))
+	})
+}
@@ -41,6 +41,9 @@ type Table struct {
 	Schema    string
 	ModelName string
 	Model     Model
+	Partition *PartitionDef
+	View      *ViewDef
+	Triggers  []*Trigger
 
 	Columns
 	Keys
@@ -86,6 +89,17 @@ func (t *Table) AddCol(d *Column) {
 	t.Columns.Add(d.On(t))
 }
 
+// AddTrigger adds trigger, or replaces trigger with same name
+func (t *Table) AddTrigger(trigger *Trigger) {
+	for i := range t.Triggers {
+		if t.Triggers[i].Name == trigger.Name {
+			t.Triggers[i] = trigger
+			return
+		}
+	}
+	t.Triggers = append(t.Triggers, trigger)
+}
+
 func (t *Table) AddKey(key *Key) {
 	if key == nil {
 		return
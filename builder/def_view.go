@@ -0,0 +1,33 @@
+package builder
+
+// V view defined by query, which could be selected from like table.
+// columns could be defined by tableDefinitions, or ScanDefToTable with model.
+// query will be written in DDL, so args are not allowed.
+func V(viewName string, query SqlExpr, tableDefinitions ...TableDefinition) *Table {
+	t := T(viewName, tableDefinitions...)
+	t.View = &ViewDef{
+		Query: query,
+	}
+	return t
+}
+
+// MaterializedV materialized view defined by query, only for postgres,
+// mysql will treat it as normal view
+func MaterializedV(viewName string, query SqlExpr, tableDefinitions ...TableDefinition) *Table {
+	t := V(viewName, query, tableDefinitions...)
+	t.View.Materialized = true
+	return t
+}
+
+type ViewDef struct {
+	Query        SqlExpr
+	Materialized bool
+}
+
+func (t *Table) IsView() bool {
+	return t != nil && t.View != nil
+}
+
+func (t *Table) IsMaterializedView() bool {
+	return t.IsView() && t.View.Materialized
+}
@@ -0,0 +1,26 @@
+package builder
+
+import (
+	"context"
+
+	contextx "github.com/go-courier/x/context"
+)
+
+type contextKeyForDriverName struct {
+}
+
+// ContextWithDriverName sets driver name of dialect for resolving DialectValuerExpr,
+// sqlx.DB sets it when executing
+func ContextWithDriverName(ctx context.Context, driverName string) context.Context {
+	return contextx.WithValue(ctx, contextKeyForDriverName{}, driverName)
+}
+
+func DriverNameFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if driverName, ok := ctx.Value(contextKeyForDriverName{}).(string); ok {
+		return driverName
+	}
+	return ""
+}
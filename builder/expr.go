@@ -274,6 +274,12 @@ func preprocessArgs(args []interface{}) bool {
 
 	for i := range args {
 		switch arg := args[i].(type) {
+		case DialectValuerExpr:
+			// resolved when Ex, expr could be executed by dbs of different dialects
+			args[i] = ExprBy(func(ctx context.Context) *Ex {
+				return ExactlyExpr(arg.ValueExOf(DriverNameFromContext(ctx)), arg)
+			})
+			shouldResolve = true
 		case ValuerExpr:
 			args[i] = ExactlyExpr(arg.ValueEx(), arg)
 			shouldResolve = true
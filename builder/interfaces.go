@@ -1,5 +1,10 @@
 package builder
 
+import (
+	"database/sql/driver"
+	"reflect"
+)
+
 // replace ? as some query snippet
 //
 // examples:
@@ -9,10 +14,31 @@ type ValuerExpr interface {
 	ValueEx() string
 }
 
+// DialectValuerExpr same as ValuerExpr, but query snippet depends on driver name from DriverNameFromContext,
+// driver name will be empty when not executing by sqlx.DB
+type DialectValuerExpr interface {
+	ValueExOf(driverName string) string
+}
+
 type DataTypeDescriber interface {
 	DataType(driverName string) string
 }
 
+// NullableValue value which could be NULL, like datatypes.Null[T]
+// FieldValuesFromStructByNonZero never skips it as zero field, so NULL could be written explicitly.
+// column type will be resolved by UnderlyingType with null flag
+type NullableValue interface {
+	driver.Valuer
+	UnderlyingType() reflect.Type
+}
+
+// NativeEnum value stored as native enum type of database, like datatypes.NativeEnum[T]
+// postgres connector creates the type by NativeEnumTypeName before tables, and adds new values when migrating
+type NativeEnum interface {
+	NativeEnumTypeName() string
+	NativeEnumValues() []string
+}
+
 type Model interface {
 	TableName() string
 }
@@ -35,6 +61,12 @@ type WithIndexes interface {
 	Indexes() Indexes
 }
 
+// WithPartition declares table partitioned
+// returns partition method with field names or expr, like []string{"RANGE", "CreatedAt"}
+type WithPartition interface {
+	PartitionBy() []string
+}
+
 type WithComments interface {
 	Comments() map[string]string
 }
@@ -66,3 +98,35 @@ type Dialect interface {
 	DropIndex(key *Key) SqlExpr
 	DataType(columnType *ColumnType) SqlExpr
 }
+
+// ViewDialect dialect which could manage views
+type ViewDialect interface {
+	CreateOrReplaceView(v *Table) []SqlExpr
+	DropView(v *Table) SqlExpr
+	RefreshMaterializedView(v *Table, concurrently bool) SqlExpr
+}
+
+// RoutineDialect dialect which could manage functions and triggers,
+// version of function or trigger should be kept, for skipping unchanged ones when migrating
+type RoutineDialect interface {
+	CreateOrReplaceFunction(fn *StoredFunction) []SqlExpr
+	DropFunction(fn *StoredFunction) SqlExpr
+	CreateOrReplaceTrigger(t *Table, trigger *Trigger) []SqlExpr
+	DropTrigger(t *Table, trigger *Trigger) SqlExpr
+}
+
+// RowValueDialect dialect which could tell whether row value, like `(f_a,f_b) IN ((?,?))`, is supported
+type RowValueDialect interface {
+	SupportRowValue() bool
+}
+
+// LastInsertIDDialect dialect which could refer the auto increment value inserted by the last INSERT of current session
+type LastInsertIDDialect interface {
+	LastInsertID(col *Column) SqlExpr
+}
+
+// PartitionDialect dialect which could add or drop partitions of partitioned table
+type PartitionDialect interface {
+	AddPartition(t *Table, p *Partition) SqlExpr
+	DropPartition(t *Table, p *Partition) SqlExpr
+}
@@ -34,6 +34,11 @@ func (s StmtSelect) From(table *Table, additions ...Addition) *StmtSelect {
 	return &s
 }
 
+// Table which selected from
+func (s *StmtSelect) Table() *Table {
+	return s.table
+}
+
 func (s *StmtSelect) Ex(ctx context.Context) *Ex {
 	multiTable := false
 
@@ -83,6 +88,20 @@ func (s *StmtSelect) Ex(ctx context.Context) *Ex {
 	return e.Ex(ctx)
 }
 
-func ForUpdate() *OtherAddition {
-	return AsAddition(Expr("FOR UPDATE"))
+// modifiers of ForUpdate, supported by mysql 8.0+ and postgres 9.5+
+const (
+	// SkipLocked skips rows locked by others instead of waiting, like polling queue by multiple workers
+	SkipLocked = "SKIP LOCKED"
+	// NoWait fails instead of waiting when rows locked by others
+	NoWait = "NOWAIT"
+)
+
+// ForUpdate locks selected rows, with modifiers like SkipLocked or NoWait
+func ForUpdate(modifiers ...string) *OtherAddition {
+	e := Expr("FOR UPDATE")
+	for i := range modifiers {
+		e.WriteQueryByte(' ')
+		e.WriteQuery(modifiers[i])
+	}
+	return AsAddition(e)
 }
@@ -64,6 +64,24 @@ WHERE f_a = ?`, 1))
 SELECT * FROM T
 WHERE f_a = ?
 FOR UPDATE
+`,
+			1,
+		))
+	})
+	t.Run("select for update skip locked", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(
+			Select(nil).From(
+				table,
+				Where(Col("F_a").Eq(1)),
+				ForUpdate(SkipLocked),
+				Limit(10),
+			),
+		).To(BeExpr(
+			`
+SELECT * FROM T
+WHERE f_a = ?
+LIMIT 10
+FOR UPDATE SKIP LOCKED
 `,
 			1,
 		))
@@ -5,6 +5,7 @@ import (
 
 	. "github.com/go-courier/sqlx/v2/builder"
 	. "github.com/go-courier/sqlx/v2/builder/buidertestingutils"
+	"github.com/go-courier/sqlx/v2/datatypes"
 	"github.com/onsi/gomega"
 )
 
@@ -27,4 +28,19 @@ UPDATE T SET f_a = ?, f_b = ?
 WHERE f_a = ?
 /* Comment */`, 1, 2, 1))
 	})
+	t.Run("update with incr", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(
+			Update(table).
+				Set(
+					ColumnsAndValues(Col("F_a"), Col("F_a").Incr(1)),
+					ColumnsAndValues(Col("F_b"), Col("F_b").Dec(datatypes.MustParseDecimal("0.25"))),
+					ColumnsAndValues(Col("F_c"), Col("F_c").Type(datatypes.Decimal{}, ",size=20,decimal=4").Incr(datatypes.MustParseDecimal("0.25"))),
+				).
+				Where(
+					Col("F_a").Eq(1),
+				),
+		).To(BeExpr(`
+UPDATE T SET f_a = f_a + ?, f_b = f_b - CAST(? AS DECIMAL(3,2)), f_c = f_c + CAST(? AS DECIMAL(20,4))
+WHERE f_a = ?`, 1, datatypes.MustParseDecimal("0.25"), datatypes.MustParseDecimal("0.25"), 1))
+	})
 }
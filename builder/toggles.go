@@ -7,9 +7,10 @@ import (
 )
 
 var (
-	ToggleMultiTable    = "MultiTable"
-	ToggleNeedAutoAlias = "NeedAlias"
-	ToggleUseValues     = "UseValues"
+	ToggleMultiTable             = "MultiTable"
+	ToggleNeedAutoAlias          = "NeedAlias"
+	ToggleAutoAliasWithTableName = "AutoAliasWithTableName"
+	ToggleUseValues              = "UseValues"
 )
 
 type Toggles map[string]bool
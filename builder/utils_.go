@@ -173,13 +173,22 @@ func FieldValuesFromStructByNonZero(structValue interface{}, excludes ...string)
 	rv := reflect.Indirect(reflect.ValueOf(structValue))
 	fieldMap := ToMap(excludes)
 	ForEachStructFieldValue(context.Background(), rv, func(sf *StructFieldValue) {
-		if !reflectx.IsEmptyValue(sf.Value) || (fieldMap != nil && fieldMap[sf.Field.FieldName]) {
+		if !reflectx.IsEmptyValue(sf.Value) || isNullableValue(sf.Value) || (fieldMap != nil && fieldMap[sf.Field.FieldName]) {
 			fieldValues[sf.Field.FieldName] = sf.Value.Interface()
 		}
 	})
 	return
 }
 
+// nil pointer of NullableValue means leave unchanged
+func isNullableValue(rv reflect.Value) bool {
+	if rv.Kind() == reflect.Ptr {
+		return false
+	}
+	_, ok := rv.Interface().(NullableValue)
+	return ok
+}
+
 func TableFromModel(model Model) *Table {
 	tpe := reflect.TypeOf(model)
 	if tpe.Kind() != reflect.Ptr {
@@ -192,6 +201,7 @@ func TableFromModel(model Model) *Table {
 
 	table := T(model.TableName())
 	table.Model = model
+	table.ModelName = tpe.Name()
 
 	ScanDefToTable(table, model)
 
@@ -215,6 +225,10 @@ func ScanDefToTable(table *Table, i interface{}) {
 		table.Description = desc
 	}
 
+	if withPartition, ok := i.(WithPartition); ok {
+		table.Partition = ParsePartitionDef(withPartition.PartitionBy()...)
+	}
+
 	if withComments, ok := i.(WithComments); ok {
 		for fieldName, comment := range withComments.Comments() {
 			field := table.F(fieldName)
@@ -274,6 +288,11 @@ func ScanDefToTable(table *Table, i interface{}) {
 			})
 		}
 	}
+	if table.Partition != nil {
+		if err := table.Partition.CheckKeys(table); err != nil {
+			panic(err)
+		}
+	}
 }
 
 func ResolveIndexNameAndMethod(n string) (name string, method string) {
@@ -5,6 +5,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/go-courier/sqlx/v2/datatypes"
 	"github.com/onsi/gomega"
 )
 
@@ -42,6 +43,24 @@ func TestValueMap(t *testing.T) {
 			}))
 	})
 
+	t.Run("#FieldValuesFromStructByNonZero with Null", func(t *testing.T) {
+		type Patch struct {
+			Name     datatypes.Null[string]  `db:"f_name"`
+			Nickname *datatypes.Null[string] `db:"f_nickname"`
+			Username *datatypes.Null[string] `db:"f_username"`
+		}
+
+		patch := Patch{
+			Nickname: &datatypes.Null[string]{},
+		}
+
+		gomega.NewWithT(t).Expect(FieldValuesFromStructByNonZero(patch)).
+			To(gomega.Equal(FieldValues{
+				"Name":     datatypes.Null[string]{},
+				"Nickname": &datatypes.Null[string]{},
+			}))
+	})
+
 	t.Run("#GetColumnName", func(t *testing.T) {
 		gomega.NewWithT(t).Expect(GetColumnName("Text", "")).To(gomega.Equal("f_text"))
 		gomega.NewWithT(t).Expect(GetColumnName("Text", ",size=256")).To(gomega.Equal("f_text"))
@@ -0,0 +1,213 @@
+// Package cli implements sqlx command-line tool.
+//
+// Commands migrate, er and introspect work on registered databases.
+// Databases could be registered in two ways:
+//
+// 1. build own binary with build tag, database packages call RegisterDatabase in init()
+//
+//	//go:build sqlx_databases
+//
+//	package main
+//
+//	import (
+//		_ "github.com/xxx/app/database"
+//		"github.com/go-courier/sqlx/v2/cmd/sqlx/cli"
+//	)
+//
+//	func main() { cli.Main() }
+//
+// 2. load plugin by -plugin, plugin could call RegisterDatabase in init() or export `var Database *sqlx.Database`
+//
+//	go build -buildmode=plugin -o database.so ./database
+//	sqlx migrate plan -plugin=./database.so -driver=mysql -host="root@tcp(0.0.0.0:3306)"
+package cli
+
+import (
+	"database/sql/driver"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"plugin"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/go-courier/sqlx/v2"
+	"github.com/go-courier/sqlx/v2/builder"
+	"github.com/go-courier/sqlx/v2/mysqlconnector"
+	"github.com/go-courier/sqlx/v2/postgresqlconnector"
+)
+
+var databases = sync.Map{}
+
+// RegisterDatabase register database for commands migrate, er and introspect
+func RegisterDatabase(d *sqlx.Database) {
+	databases.Store(d.Name, d)
+}
+
+func Main() {
+	if err := Run(os.Args[1:], os.Stdout); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return
+		}
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+type command struct {
+	name  string
+	usage string
+	run   func(args []string, stdout io.Writer) error
+}
+
+var commands = []command{
+	{name: "gen", usage: "generate sql funcs of structs in package", run: runGen},
+	{name: "migrate", usage: "plan or apply migration of registered database", run: runMigrate},
+	{name: "er", usage: "dump ER of registered database as json or diagram", run: runER},
+	{name: "introspect", usage: "print schema of tables of registered database in connecting database", run: runIntrospect},
+}
+
+func Run(args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return usage(stdout)
+	}
+
+	for _, c := range commands {
+		if c.name == args[0] {
+			return c.run(args[1:], stdout)
+		}
+	}
+
+	_ = usage(stdout)
+	return fmt.Errorf("unknown command %s", args[0])
+}
+
+func usage(w io.Writer) error {
+	_, _ = fmt.Fprintln(w, "Usage: sqlx <command> [flags]")
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, "Commands:")
+	for _, c := range commands {
+		_, _ = fmt.Fprintf(w, "  %-12s%s\n", c.name, c.usage)
+	}
+	return nil
+}
+
+type databaseFlags struct {
+	plugin   string
+	database string
+}
+
+func (f *databaseFlags) bind(fs *flag.FlagSet) {
+	fs.StringVar(&f.plugin, "plugin", "", "plugin (.so) to load databases")
+	fs.StringVar(&f.database, "database", "", "name of registered database, could be empty when only one registered")
+}
+
+func (f *databaseFlags) loadDatabase() (*sqlx.Database, error) {
+	if f.plugin != "" {
+		if err := loadPlugin(f.plugin); err != nil {
+			return nil, err
+		}
+	}
+
+	names := make([]string, 0)
+	databases.Range(func(key, value interface{}) bool {
+		names = append(names, key.(string))
+		return true
+	})
+	sort.Strings(names)
+
+	if f.database == "" {
+		switch len(names) {
+		case 0:
+			return nil, fmt.Errorf("no database registered, use -plugin or build with database packages")
+		case 1:
+			f.database = names[0]
+		default:
+			return nil, fmt.Errorf("multiple databases registered, use -database to pick one of %s", strings.Join(names, ", "))
+		}
+	}
+
+	if d, ok := databases.Load(f.database); ok {
+		return d.(*sqlx.Database), nil
+	}
+
+	return nil, fmt.Errorf("database %s is not registered", f.database)
+}
+
+// loadPlugin registers the database exported as Database by the plugin
+func loadPlugin(path string) error {
+	p, err := plugin.Open(path)
+	if err != nil {
+		return err
+	}
+	sym, err := p.Lookup("Database")
+	if err != nil {
+		return fmt.Errorf("plugin %s: %w", path, err)
+	}
+	if err := registerDatabaseSymbol(sym); err != nil {
+		return fmt.Errorf("plugin %s: %w", path, err)
+	}
+	return nil
+}
+
+func registerDatabaseSymbol(sym plugin.Symbol) error {
+	switch d := sym.(type) {
+	case **sqlx.Database:
+		if *d == nil {
+			return fmt.Errorf("symbol Database is nil")
+		}
+		RegisterDatabase(*d)
+	case *sqlx.Database:
+		RegisterDatabase(d)
+	default:
+		return fmt.Errorf("symbol Database should be *sqlx.Database, but got %T", sym)
+	}
+	return nil
+}
+
+type connectorFlags struct {
+	driver     string
+	host       string
+	extra      string
+	extensions string
+}
+
+func (f *connectorFlags) bind(fs *flag.FlagSet) {
+	fs.StringVar(&f.driver, "driver", "mysql", "mysql or postgres")
+	fs.StringVar(&f.host, "host", "", `host of dsn, like root@tcp(0.0.0.0:3306) for mysql or postgres://postgres@0.0.0.0:5432 for postgres`)
+	fs.StringVar(&f.extra, "extra", "", "extra params of dsn, like sslmode=disable")
+	fs.StringVar(&f.extensions, "extensions", "", "postgres extensions, separated by comma")
+}
+
+type connector interface {
+	driver.Connector
+	builder.Dialect
+}
+
+func (f *connectorFlags) connector() (connector, error) {
+	switch f.driver {
+	case "mysql":
+		return &mysqlconnector.MysqlConnector{Host: f.host, Extra: f.extra}, nil
+	case "postgres", "postgresql":
+		c := &postgresqlconnector.PostgreSQLConnector{Host: f.host, Extra: f.extra}
+		if f.extensions != "" {
+			c.Extensions = strings.Split(f.extensions, ",")
+		}
+		return c, nil
+	}
+	return nil, fmt.Errorf("unsupported driver %s", f.driver)
+}
+
+func (f *connectorFlags) openDB(d *sqlx.Database) (*sqlx.DB, error) {
+	if f.host == "" {
+		return nil, fmt.Errorf("missing -host")
+	}
+	c, err := f.connector()
+	if err != nil {
+		return nil, err
+	}
+	return d.OpenDB(c), nil
+}
@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-courier/sqlx/v2"
+	"github.com/go-courier/sqlx/v2/er"
+	"github.com/onsi/gomega"
+)
+
+type User struct {
+	ID   uint64 `db:"f_id,autoincrement"`
+	Name string `db:"f_name,size=255,default=''"`
+}
+
+func (User) TableName() string {
+	return "t_user"
+}
+
+func (User) PrimaryKey() []string {
+	return []string{"ID"}
+}
+
+func TestRun(t *testing.T) {
+	d := sqlx.NewDatabase("cli_test")
+	d.Register(&User{})
+
+	RegisterDatabase(d)
+
+	t.Run("usage", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+		err := Run(nil, buf)
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(buf.String()).To(gomega.ContainSubstring("introspect"))
+	})
+
+	t.Run("database symbol of plugin", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(registerDatabaseSymbol(&d)).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(registerDatabaseSymbol(d)).To(gomega.BeNil())
+
+		var nilDatabase *sqlx.Database
+		gomega.NewWithT(t).Expect(registerDatabaseSymbol(&nilDatabase)).NotTo(gomega.BeNil())
+		gomega.NewWithT(t).Expect(registerDatabaseSymbol(&User{})).NotTo(gomega.BeNil())
+	})
+
+	t.Run("unknown command", func(t *testing.T) {
+		err := Run([]string{"unknown"}, bytes.NewBuffer(nil))
+		gomega.NewWithT(t).Expect(err).NotTo(gomega.BeNil())
+	})
+
+	t.Run("er", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+		err := Run([]string{"er", "-driver=postgres"}, buf)
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+
+		erd := &er.ERDatabase{}
+		err = json.Unmarshal(buf.Bytes(), erd)
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(erd.Name).To(gomega.Equal("cli_test"))
+		gomega.NewWithT(t).Expect(erd.Tables["t_user"].Cols["f_id"].DataType).To(gomega.Equal("bigserial NOT NULL"))
+	})
+
+	t.Run("er as mermaid", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+		err := Run([]string{"er", "-driver=postgres", "-format=mermaid"}, buf)
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(buf.String()).To(gomega.ContainSubstring("bigserial f_id PK"))
+	})
+
+	t.Run("er as data dictionary", func(t *testing.T) {
+		dir := t.TempDir()
+		err := Run([]string{"er", "-driver=mysql,postgres", "-format=markdown", "-out=" + dir}, bytes.NewBuffer(nil))
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+
+		page, err := os.ReadFile(filepath.Join(dir, "t_user.md"))
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(string(page)).To(gomega.ContainSubstring("| Column | mysql | postgres |"))
+	})
+
+	t.Run("migrate without host", func(t *testing.T) {
+		err := Run([]string{"migrate", "plan", "-database=cli_test"}, bytes.NewBuffer(nil))
+		gomega.NewWithT(t).Expect(err).NotTo(gomega.BeNil())
+	})
+
+	t.Run("migrate with unknown database", func(t *testing.T) {
+		err := Run([]string{"migrate", "plan", "-database=unknown", "-host=root@tcp(0.0.0.0:3306)"}, bytes.NewBuffer(nil))
+		gomega.NewWithT(t).Expect(err).To(gomega.MatchError("database unknown is not registered"))
+	})
+}
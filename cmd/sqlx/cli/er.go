@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/go-courier/sqlx/v2/builder"
+	"github.com/go-courier/sqlx/v2/er"
+)
+
+func runER(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("er", flag.ContinueOnError)
+	fs.SetOutput(stdout)
+
+	df := &databaseFlags{}
+	df.bind(fs)
+	cf := &connectorFlags{}
+	cf.bind(fs)
+
+	format := fs.String("format", "json", "output format, json, mermaid, plantuml, dot, or markdown and html for data dictionary")
+	out := fs.String("out", "", "output dir of data dictionary pages")
+
+	fs.Usage = func() {
+		_, _ = fmt.Fprintln(stdout, "Usage: sqlx er [flags]")
+		_, _ = fmt.Fprintln(stdout, "data types of columns are resolved by -driver, no connection needed")
+		_, _ = fmt.Fprintln(stdout, "data dictionary could list data types of multiple drivers, like -driver=mysql,postgres")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	d, err := df.loadDatabase()
+	if err != nil {
+		return err
+	}
+
+	if *format == "markdown" || *format == "html" {
+		if *out == "" {
+			return fmt.Errorf("missing -out")
+		}
+
+		dialects := make([]builder.Dialect, 0)
+		for _, driver := range strings.Split(cf.driver, ",") {
+			c, err := (&connectorFlags{driver: driver}).connector()
+			if err != nil {
+				return err
+			}
+			dialects = append(dialects, c)
+		}
+
+		return er.DataDictionaryFromDB(d, dialects...).Output(*out, *format)
+	}
+
+	dialect, err := cf.connector()
+	if err != nil {
+		return err
+	}
+
+	erd := er.DatabaseERFromDB(d, dialect)
+
+	if *format == "json" {
+		e := json.NewEncoder(stdout)
+		e.SetIndent("", "  ")
+		return e.Encode(erd)
+	}
+
+	render, ok := er.Renderers[*format]
+	if !ok {
+		return fmt.Errorf("unsupported format %s", *format)
+	}
+
+	return render(stdout, erd)
+}
@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"go/types"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+
+	"github.com/go-courier/packagesx"
+	"github.com/go-courier/sqlx/v2/generator"
+)
+
+func runGen(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("gen", flag.ContinueOnError)
+	fs.SetOutput(stdout)
+
+	pkgPath := fs.String("pkg", ".", "package to scan")
+
+	c := generator.Config{}
+
+	fs.StringVar(&c.Database, "database", "", "variable name of database, like DBTest")
+	fs.StringVar(&c.TableName, "table-name", "", "table name, only works when generating for one struct")
+	fs.BoolVar(&c.WithComments, "with-comments", true, "generate comments")
+	fs.BoolVar(&c.WithTableName, "with-table-name", true, "generate TableName()")
+	fs.BoolVar(&c.WithTableInterfaces, "with-table-interfaces", true, "generate table interfaces like PrimaryKey()")
+	fs.BoolVar(&c.WithMethods, "with-methods", true, "generate methods like Create()")
+	fs.BoolVar(&c.WithRepository, "with-repository", false, "generate repository interface with db-backed implementation and in-memory fake, requires -with-methods")
+	fs.StringVar(&c.FieldPrimaryKey, "field-primary-key", "", "field name of primary key")
+	fs.StringVar(&c.FieldKeyDeletedAt, "field-deleted-at", "", "field name of soft delete, default DeletedAt")
+	fs.StringVar(&c.FieldKeyCreatedAt, "field-created-at", "", "field name of created at, default CreatedAt")
+	fs.StringVar(&c.FieldKeyUpdatedAt, "field-updated-at", "", "field name of updated at, default UpdatedAt")
+
+	fs.Usage = func() {
+		_, _ = fmt.Fprintln(stdout, "Usage: sqlx gen [flags] [StructName...]")
+		_, _ = fmt.Fprintln(stdout, "all structs with db tags in package will be generated when no StructName")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	pkg, err := packagesx.Load(*pkgPath)
+	if err != nil {
+		return err
+	}
+
+	structNames := fs.Args()
+	if len(structNames) == 0 {
+		structNames = structNamesWithDBTag(pkg)
+	}
+
+	if c.TableName != "" && len(structNames) != 1 {
+		return fmt.Errorf("-table-name only works when generating for one struct")
+	}
+
+	cwd, _ := os.Getwd()
+
+	for _, name := range structNames {
+		g := generator.NewSqlFuncGenerator(pkg)
+		g.Config = c
+		g.StructName = name
+
+		g.Scan()
+		g.Output(cwd)
+	}
+
+	return nil
+}
+
+func structNamesWithDBTag(pkg *packagesx.Package) []string {
+	names := make([]string, 0)
+
+	for _, obj := range pkg.TypesInfo.Defs {
+		typeName, ok := obj.(*types.TypeName)
+		if !ok || typeName.Parent() != pkg.Types.Scope() {
+			continue
+		}
+		s, ok := typeName.Type().Underlying().(*types.Struct)
+		if !ok {
+			continue
+		}
+		for i := 0; i < s.NumFields(); i++ {
+			if _, ok := reflect.StructTag(s.Tag(i)).Lookup("db"); ok {
+				names = append(names, typeName.Name())
+				break
+			}
+		}
+	}
+
+	sort.Strings(names)
+	return names
+}
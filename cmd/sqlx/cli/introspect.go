@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/go-courier/sqlx/v2"
+	"github.com/go-courier/sqlx/v2/builder"
+	"github.com/go-courier/sqlx/v2/generator"
+)
+
+func runIntrospect(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("introspect", flag.ContinueOnError)
+	fs.SetOutput(stdout)
+
+	df := &databaseFlags{}
+	df.bind(fs)
+	cf := &connectorFlags{}
+	cf.bind(fs)
+
+	name := fs.String("name", "", "name of database to introspect all tables, without registered database")
+	models := fs.String("models", "", "output dir of models generated from tables, instead of printing schema")
+
+	fs.Usage = func() {
+		_, _ = fmt.Fprintln(stdout, "Usage: sqlx introspect [flags]")
+		_, _ = fmt.Fprintln(stdout, "prints tables of registered database as they are in the connecting database")
+		_, _ = fmt.Fprintln(stdout, "or generates models of them with -models")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	d := sqlx.NewDatabase(*name)
+
+	if *name == "" {
+		registered, err := df.loadDatabase()
+		if err != nil {
+			return err
+		}
+		d = registered
+	}
+
+	db, err := cf.openDB(d)
+	if err != nil {
+		return err
+	}
+
+	introspector, ok := db.Dialect().(sqlx.SchemaIntrospector)
+	if !ok {
+		return fmt.Errorf("driver %s not support introspect", cf.driver)
+	}
+
+	liveDB, err := introspector.Introspect(db)
+	if err != nil {
+		return err
+	}
+
+	if liveDB == nil {
+		return fmt.Errorf("database %s not exists", d.Name)
+	}
+
+	if *models != "" {
+		g := generator.NewDatabaseModelGenerator(filepath.Base(*models), liveDB, db.Dialect())
+		g.Scan()
+		g.Output(*models)
+		return nil
+	}
+
+	return printSchema(stdout, liveDB, db.Dialect())
+}
+
+func printSchema(w io.Writer, d *sqlx.Database, dialect builder.Dialect) error {
+	for _, name := range d.Tables.TableNames() {
+		for _, expr := range dialect.CreateTableIsNotExists(d.Table(name)) {
+			if _, err := io.WriteString(w, builder.ResolveExpr(expr).Query()+"\n"); err != nil {
+				return err
+			}
+		}
+		if _, err := io.WriteString(w, "\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/go-courier/sqlx/v2/migration"
+)
+
+func runMigrate(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	fs.SetOutput(stdout)
+
+	df := &databaseFlags{}
+	df.bind(fs)
+	cf := &connectorFlags{}
+	cf.bind(fs)
+
+	fs.Usage = func() {
+		_, _ = fmt.Fprintln(stdout, "Usage: sqlx migrate plan|apply [flags]")
+		_, _ = fmt.Fprintln(stdout, "plan prints sql of migration without executing, apply executes it")
+		fs.PrintDefaults()
+	}
+
+	if len(args) == 0 {
+		fs.Usage()
+		return fmt.Errorf("missing plan or apply")
+	}
+
+	action := args[0]
+	if action != "plan" && action != "apply" {
+		fs.Usage()
+		return fmt.Errorf("unknown migrate action %s", action)
+	}
+
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	d, err := df.loadDatabase()
+	if err != nil {
+		return err
+	}
+
+	db, err := cf.openDB(d)
+	if err != nil {
+		return err
+	}
+
+	if action == "plan" {
+		return migration.Migrate(db, stdout)
+	}
+
+	return migration.Migrate(db, nil)
+}
@@ -0,0 +1,9 @@
+package main
+
+import (
+	"github.com/go-courier/sqlx/v2/cmd/sqlx/cli"
+)
+
+func main() {
+	cli.Main()
+}
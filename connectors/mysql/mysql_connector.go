@@ -21,6 +21,8 @@ import (
 var _ interface {
 	driver.Connector
 	builder.Dialect
+	builder.RowValueDialect
+	builder.LastInsertIDDialect
 } = (*MysqlConnector)(nil)
 
 type MysqlConnector struct {
@@ -43,6 +45,11 @@ func (c MysqlConnector) WithDBName(dbName string) driver.Connector {
 	return &c
 }
 
+// Introspect returns tables of db.D() in the connecting database
+func (c *MysqlConnector) Introspect(db sqlx.DBExecutor) (*sqlx.Database, error) {
+	return DBFromInformationSchema(db)
+}
+
 func (c *MysqlConnector) Migrate(ctx context.Context, db sqlx.DBExecutor) error {
 	output := migration.MigrationOutputFromContext(ctx)
 
@@ -50,7 +57,7 @@ func (c *MysqlConnector) Migrate(ctx context.Context, db sqlx.DBExecutor) error
 	d := db.D().WithSchema("")
 	dialect := db.Dialect()
 
-	prevDB, err := dbFromInformationSchema(db)
+	prevDB, err := DBFromInformationSchema(db)
 	if err != nil {
 		return err
 	}
@@ -102,6 +109,31 @@ func (c *MysqlConnector) Migrate(ctx context.Context, db sqlx.DBExecutor) error
 		}
 	}
 
+	prevFunctions, prevTriggers, err := routineVersionsFromInformationSchema(db)
+	if err != nil {
+		return err
+	}
+
+	tables := make([]*builder.Table, 0)
+	d.Tables.Range(func(table *builder.Table, idx int) {
+		tables = append(tables, table)
+	})
+
+	for _, expr := range builder.RoutinesDiff(c, "", d.Functions, tables, prevFunctions, prevTriggers) {
+		if err := exec(expr); err != nil {
+			return err
+		}
+	}
+
+	// definition of view could not be compared, so always replace
+	for _, name := range d.Views.TableNames() {
+		for _, expr := range c.CreateOrReplaceView(d.Views.Table(name)) {
+			if err := exec(expr); err != nil {
+				return err
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -293,6 +325,40 @@ func (c *MysqlConnector) CreateTableIsNotExists(table *builder.Table) (exprs []b
 		expr.WriteQuery(c.Charset)
 	}
 
+	if table.Partition != nil {
+		expr.WriteQuery(" PARTITION BY ")
+		expr.WriteQuery(table.Partition.Method)
+
+		if table.Partition.Method != "HASH" && table.Partition.IsPlainCols() {
+			expr.WriteQuery(" COLUMNS")
+		} else {
+			expr.WriteQueryByte(' ')
+		}
+
+		expr.WriteExpr(table.Partition.TableExpr(table))
+
+		// RANGE partitions must be defined when creating,
+		// so catch all by max partition, and AddPartition will split it.
+		if table.Partition.Method == "RANGE" {
+			expr.WriteQuery(" (PARTITION ")
+			expr.WriteQuery(maxPartitionName(table))
+			expr.WriteQuery(" VALUES LESS THAN ")
+			expr.WriteGroup(func(e *builder.Ex) {
+				n := 1
+				if table.Partition.IsPlainCols() {
+					n = len(table.Partition.FieldNames) + len(table.Partition.ColNames)
+				}
+				for i := 0; i < n; i++ {
+					if i > 0 {
+						e.WriteQueryByte(',')
+					}
+					e.WriteQuery("MAXVALUE")
+				}
+			})
+			expr.WriteQueryByte(')')
+		}
+	}
+
 	expr.WriteEnd()
 	exprs = append(exprs, expr)
 
@@ -305,6 +371,129 @@ func (c *MysqlConnector) CreateTableIsNotExists(table *builder.Table) (exprs []b
 	return
 }
 
+// CreateOrReplaceFunction drops and creates function,
+// body will be wrapped by BEGIN END with version in comment
+func (c *MysqlConnector) CreateOrReplaceFunction(fn *builder.StoredFunction) []builder.SqlExpr {
+	e := builder.Expr("CREATE FUNCTION ")
+	e.WriteQuery(fn.Name)
+	e.WriteQuery(fn.Def)
+	e.WriteQueryByte('\n')
+	e.WriteQuery(routineBody(fn.Version(), fn.Body))
+	e.WriteEnd()
+
+	return []builder.SqlExpr{c.DropFunction(fn), e}
+}
+
+func (c *MysqlConnector) DropFunction(fn *builder.StoredFunction) builder.SqlExpr {
+	e := builder.Expr("DROP FUNCTION IF EXISTS ")
+	e.WriteQuery(fn.Name)
+	e.WriteEnd()
+	return e
+}
+
+// CreateOrReplaceTrigger drops and creates trigger,
+// body will be wrapped by BEGIN END with version in comment
+func (c *MysqlConnector) CreateOrReplaceTrigger(t *builder.Table, trigger *builder.Trigger) []builder.SqlExpr {
+	e := builder.Expr("CREATE TRIGGER ")
+	e.WriteQuery(trigger.Name)
+	e.WriteQueryByte(' ')
+	e.WriteQuery(trigger.When)
+	e.WriteQuery(" ON ")
+	e.WriteQuery(t.Name)
+	e.WriteQuery(" FOR EACH ROW\n")
+	e.WriteQuery(routineBody(trigger.Version(), trigger.Body))
+	e.WriteEnd()
+
+	return []builder.SqlExpr{c.DropTrigger(t, trigger), e}
+}
+
+func (c *MysqlConnector) DropTrigger(t *builder.Table, trigger *builder.Trigger) builder.SqlExpr {
+	e := builder.Expr("DROP TRIGGER IF EXISTS ")
+	e.WriteQuery(trigger.Name)
+	e.WriteEnd()
+	return e
+}
+
+// routineBody comments in compound statement will be kept by mysql, so version could be read back
+func routineBody(version string, body string) string {
+	return "BEGIN\n/* " + version + " */\n" + strings.TrimSuffix(strings.TrimSpace(body), ";") + ";\nEND"
+}
+
+func (c *MysqlConnector) CreateOrReplaceView(v *builder.Table) []builder.SqlExpr {
+	e := builder.Expr("CREATE OR REPLACE VIEW ")
+	e.WriteQuery(v.Name)
+	e.WriteQuery(" AS ")
+	e.WriteExpr(v.View.Query)
+	e.WriteEnd()
+	return []builder.SqlExpr{e}
+}
+
+func (c *MysqlConnector) DropView(v *builder.Table) builder.SqlExpr {
+	e := builder.Expr("DROP VIEW IF EXISTS ")
+	e.WriteQuery(v.Name)
+	e.WriteEnd()
+	return e
+}
+
+// RefreshMaterializedView mysql not support materialized view, view always be fresh
+func (c *MysqlConnector) RefreshMaterializedView(v *builder.Table, concurrently bool) builder.SqlExpr {
+	return nil
+}
+
+// AddPartition splits max partition for RANGE, or adds partition for LIST
+func (c *MysqlConnector) AddPartition(t *builder.Table, p *builder.Partition) builder.SqlExpr {
+	e := builder.Expr("ALTER TABLE ")
+	e.WriteQuery(t.Name)
+
+	if len(p.In) > 0 {
+		e.WriteQuery(" ADD PARTITION (PARTITION ")
+		e.WriteQuery(p.Name)
+		e.WriteQuery(" VALUES IN (")
+		e.WriteQuery(strings.Join(p.In, ","))
+		e.WriteQuery("))")
+		e.WriteEnd()
+		return e
+	}
+
+	e.WriteQuery(" REORGANIZE PARTITION ")
+	e.WriteQuery(maxPartitionName(t))
+	e.WriteQuery(" INTO (PARTITION ")
+	e.WriteQuery(p.Name)
+	e.WriteQuery(" VALUES LESS THAN (")
+	e.WriteQuery(p.To)
+	e.WriteQuery("), PARTITION ")
+	e.WriteQuery(maxPartitionName(t))
+	e.WriteQuery(" VALUES LESS THAN (MAXVALUE))")
+	e.WriteEnd()
+	return e
+}
+
+func (c *MysqlConnector) DropPartition(t *builder.Table, p *builder.Partition) builder.SqlExpr {
+	e := builder.Expr("ALTER TABLE ")
+	e.WriteQuery(t.Name)
+	e.WriteQuery(" DROP PARTITION ")
+	e.WriteQuery(p.Name)
+	e.WriteEnd()
+	return e
+}
+
+func (c *MysqlConnector) SupportRowValue() bool {
+	return true
+}
+
+func (c *MysqlConnector) LastInsertID(col *builder.Column) builder.SqlExpr {
+	return builder.Expr("LAST_INSERT_ID()")
+}
+
+// PartitionNames returns names of partitions of table in the connecting database
+func (c *MysqlConnector) PartitionNames(db sqlx.DBExecutor, t *builder.Table) ([]string, error) {
+	return partitionNamesFromInformationSchema(db, t)
+}
+
+func maxPartitionName(t *builder.Table) string {
+	return t.Name + "_pmax"
+}
+
 func (c *MysqlConnector) DropTable(t *builder.Table) builder.SqlExpr {
 	e := builder.Expr("DROP TABLE IF EXISTS ")
 	e.WriteQuery(t.Name)
@@ -385,7 +574,12 @@ func (c *MysqlConnector) dbDataType(typ typex.Type, columnType *builder.ColumnTy
 
 	if rv, ok := typex.TryNew(typ); ok {
 		if dtd, ok := rv.Interface().(builder.DataTypeDescriber); ok {
-			return dtd.DataType(c.DriverName())
+			dataType := dtd.DataType(c.DriverName())
+			// decimal means decimal(10,0) in mysql, which will truncate fractional digits
+			if dataType == "decimal" && columnType.Length == 0 {
+				panic(fmt.Errorf("size of %s is required for decimal, like `db:\"f_amount,size=65,decimal=30\"`", typ))
+			}
+			return dataType
 		}
 	}
 
@@ -470,7 +664,7 @@ func autocompleteSize(dataType string, columnType *builder.ColumnType) string {
 			size = 255
 		}
 		return sizeModifier(size, columnType.Decimal)
-	case "float", "double", "decimal":
+	case "char", "binary", "float", "double", "decimal":
 		if columnType.Length > 0 {
 			return sizeModifier(columnType.Length, columnType.Decimal)
 		}
@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/go-courier/enumeration"
 	"github.com/go-courier/sqlx/v2/builder"
 	"github.com/go-courier/sqlx/v2/builder/buidertestingutils"
+	"github.com/go-courier/sqlx/v2/datatypes"
 	"github.com/onsi/gomega"
 )
 
@@ -91,6 +93,140 @@ func TestMysqlConnector(t *testing.T) {
 			c.DropColumn(table.Col("F_name")),
 		).To(buidertestingutils.BeExpr( /* language=MySQL */ "ALTER TABLE t DROP COLUMN f_name;"))
 	})
+	t.Run("GeometryDataType", func(t *testing.T) {
+		tableWithGeometry := builder.T("t",
+			builder.Col("f_location").Type(datatypes.Point{}, ""),
+		)
+
+		gomega.NewWithT(t).Expect(c.AddColumn(tableWithGeometry.Col("f_location"))).
+			To(buidertestingutils.BeExpr( /* language=MySQL */ "ALTER TABLE t ADD COLUMN f_location point SRID 4326 NOT NULL;"))
+
+		tableWithPolygon := builder.T("t",
+			builder.Col("f_area").Type(datatypes.Polygon{}, ""),
+		)
+
+		gomega.NewWithT(t).Expect(c.AddColumn(tableWithPolygon.Col("f_area"))).
+			To(buidertestingutils.BeExpr( /* language=MySQL */ "ALTER TABLE t ADD COLUMN f_area polygon SRID 4326 NOT NULL;"))
+	})
+	t.Run("DecimalDataType", func(t *testing.T) {
+		tableWithDecimal := builder.T("t",
+			builder.Col("f_amount").Type(datatypes.Decimal{}, ",size=20,decimal=4,default='0'"),
+		)
+
+		gomega.NewWithT(t).Expect(c.AddColumn(tableWithDecimal.Col("f_amount"))).
+			To(buidertestingutils.BeExpr( /* language=MySQL */ "ALTER TABLE t ADD COLUMN f_amount decimal(20,4) NOT NULL DEFAULT '0';"))
+
+		tableWithDecimalWithoutSize := builder.T("t",
+			builder.Col("f_amount").Type(datatypes.Decimal{}, ",default='0'"),
+		)
+
+		gomega.NewWithT(t).Expect(func() {
+			c.AddColumn(tableWithDecimalWithoutSize.Col("f_amount"))
+		}).To(gomega.Panic())
+	})
+	t.Run("Partition", func(t *testing.T) {
+		tableWithPartition := builder.T("t_event",
+			builder.Col("f_id").Field("ID").Type(uint64(0), ""),
+			builder.Col("f_created_at").Field("CreatedAt").Type(int64(0), ""),
+			builder.PrimaryKey(builder.Cols("f_id", "f_created_at")),
+		)
+		tableWithPartition.Partition = builder.ParsePartitionDef("RANGE", "CreatedAt")
+
+		gomega.NewWithT(t).Expect(c.CreateTableIsNotExists(tableWithPartition)[0]).
+			To(buidertestingutils.BeExpr( /* language=MySQL */ `CREATE TABLE IF NOT EXISTS t_event (
+	f_id bigint unsigned NOT NULL,
+	f_created_at bigint NOT NULL,
+	PRIMARY KEY (f_id,f_created_at)
+) ENGINE=InnoDB CHARSET=utf8mb4 PARTITION BY RANGE COLUMNS(f_created_at) (PARTITION t_event_pmax VALUES LESS THAN (MAXVALUE));`))
+
+		p := &builder.Partition{Name: "t_event_202610", From: "1790812800", To: "1793491200"}
+
+		gomega.NewWithT(t).Expect(c.AddPartition(tableWithPartition, p)).
+			To(buidertestingutils.BeExpr( /* language=MySQL */ "ALTER TABLE t_event REORGANIZE PARTITION t_event_pmax INTO (PARTITION t_event_202610 VALUES LESS THAN (1793491200), PARTITION t_event_pmax VALUES LESS THAN (MAXVALUE));"))
+		gomega.NewWithT(t).Expect(c.DropPartition(tableWithPartition, p)).
+			To(buidertestingutils.BeExpr( /* language=MySQL */ "ALTER TABLE t_event DROP PARTITION t_event_202610;"))
+	})
+
+	t.Run("View", func(t *testing.T) {
+		view := builder.V("v_user", builder.Select(table.MustCols("F_id", "f_name")).From(table))
+
+		gomega.NewWithT(t).Expect(c.CreateOrReplaceView(view)[0]).
+			To(buidertestingutils.BeExpr( /* language=MySQL */ "CREATE OR REPLACE VIEW v_user AS SELECT f_id,f_name FROM t;"))
+		gomega.NewWithT(t).Expect(c.DropView(view)).
+			To(buidertestingutils.BeExpr( /* language=MySQL */ "DROP VIEW IF EXISTS v_user;"))
+	})
+
+	t.Run("Routine", func(t *testing.T) {
+		fn := &builder.StoredFunction{Name: "fn_add_one", Def: "(v INT) RETURNS INT DETERMINISTIC", Body: "RETURN v + 1;"}
+
+		exprs := c.CreateOrReplaceFunction(fn)
+		gomega.NewWithT(t).Expect(exprs).To(gomega.HaveLen(2))
+		gomega.NewWithT(t).Expect(exprs[0]).To(buidertestingutils.BeExpr( /* language=MySQL */ "DROP FUNCTION IF EXISTS fn_add_one;"))
+		gomega.NewWithT(t).Expect(exprs[1]).To(buidertestingutils.BeExpr( /* language=MySQL */ "CREATE FUNCTION fn_add_one(v INT) RETURNS INT DETERMINISTIC\nBEGIN\n/* " + fn.Version() + " */\nRETURN v + 1;\nEND;"))
+
+		trigger := &builder.Trigger{Name: "t_before_insert", When: "BEFORE INSERT", Body: "SET NEW.f_created_at = UNIX_TIMESTAMP()"}
+
+		exprs = c.CreateOrReplaceTrigger(table, trigger)
+		gomega.NewWithT(t).Expect(exprs).To(gomega.HaveLen(2))
+		gomega.NewWithT(t).Expect(exprs[0]).To(buidertestingutils.BeExpr( /* language=MySQL */ "DROP TRIGGER IF EXISTS t_before_insert;"))
+		gomega.NewWithT(t).Expect(exprs[1]).To(buidertestingutils.BeExpr( /* language=MySQL */ "CREATE TRIGGER t_before_insert BEFORE INSERT ON t FOR EACH ROW\nBEGIN\n/* " + trigger.Version() + " */\nSET NEW.f_created_at = UNIX_TIMESTAMP();\nEND;"))
+	})
+
+	t.Run("NativeEnumDataType", func(t *testing.T) {
+		tableWithEnum := builder.T("t",
+			builder.Col("f_protocol").Type(datatypes.NativeEnum[Protocol]{}, ",default='HTTP'"),
+		)
+
+		gomega.NewWithT(t).Expect(c.AddColumn(tableWithEnum.Col("f_protocol"))).
+			To(buidertestingutils.BeExpr( /* language=MySQL */ "ALTER TABLE t ADD COLUMN f_protocol enum('UNKNOWN','HTTP','HTTPS') NOT NULL DEFAULT 'HTTP';"))
+
+		prevCol := colFromColumnSchema(&ColumnSchema{
+			COLUMN_NAME: "f_protocol",
+			DATA_TYPE:   "enum",
+			COLUMN_TYPE: "enum('HTTP')",
+			IS_NULLABLE: "NO",
+		})
+		prevCol.Default = tableWithEnum.Col("f_protocol").Default
+
+		prevTable := builder.T("t")
+		prevTable.AddCol(prevCol)
+
+		gomega.NewWithT(t).Expect(tableWithEnum.Diff(prevTable, c)).To(gomega.HaveLen(1))
+	})
+}
+
+type Protocol int
+
+const (
+	PROTOCOL_UNKNOWN Protocol = iota
+	PROTOCOL__HTTP
+	PROTOCOL__HTTPS
+)
+
+func (Protocol) TypeName() string {
+	return "example.Protocol"
+}
+
+func (v Protocol) Int() int {
+	return int(v)
+}
+
+func (v Protocol) String() string {
+	switch v {
+	case PROTOCOL__HTTP:
+		return "HTTP"
+	case PROTOCOL__HTTPS:
+		return "HTTPS"
+	}
+	return "UNKNOWN"
+}
+
+func (v Protocol) Label() string {
+	return v.String()
+}
+
+func (Protocol) ConstValues() []enumeration.IntStringerEnum {
+	return []enumeration.IntStringerEnum{PROTOCOL__HTTP, PROTOCOL__HTTPS}
 }
 
 type Point struct {
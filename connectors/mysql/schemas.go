@@ -2,6 +2,7 @@ package mysql
 
 import (
 	"database/sql"
+	"strconv"
 	"strings"
 
 	"github.com/go-courier/sqlx/v2"
@@ -16,12 +17,26 @@ func toInterfaces(list ...string) []interface{} {
 	return s
 }
 
-func dbFromInformationSchema(db sqlx.DBExecutor) (*sqlx.Database, error) {
+// DBFromInformationSchema read tables of db.D() from information schema,
+// all tables of the database will be read when no table registered
+func DBFromInformationSchema(db sqlx.DBExecutor) (*sqlx.Database, error) {
 	d := db.D()
 	tableNames := d.Tables.TableNames()
 
 	database := sqlx.NewDatabase(d.Name)
 
+	// views have columns too, only base tables should be read
+	if len(tableNames) == 0 {
+		names, err := baseTableNames(db, database.Name)
+		if err != nil {
+			return nil, err
+		}
+		if len(names) == 0 {
+			return database, nil
+		}
+		tableNames = names
+	}
+
 	tableColumnSchema := SchemaDatabase.T(&ColumnSchema{})
 	columnSchemaList := make([]ColumnSchema, 0)
 
@@ -41,6 +56,8 @@ func dbFromInformationSchema(db sqlx.DBExecutor) (*sqlx.Database, error) {
 		return nil, err
 	}
 
+	hasSpatialColumns := false
+
 	for i := range columnSchemaList {
 		columnSchema := columnSchemaList[i]
 		table := database.Table(columnSchema.TABLE_NAME)
@@ -49,6 +66,35 @@ func dbFromInformationSchema(db sqlx.DBExecutor) (*sqlx.Database, error) {
 			database.AddTable(table)
 		}
 		table.AddCol(colFromColumnSchema(&columnSchema))
+
+		if spatialDataTypes[columnSchema.DATA_TYPE] {
+			hasSpatialColumns = true
+		}
+	}
+
+	// SRID of spatial columns only kept in SRS_ID of mysql 8.0+, so only be read when spatial columns exist
+	if hasSpatialColumns {
+		spatialColumnSchemaList := make([]SpatialColumnSchema, 0)
+
+		err := db.QueryExprAndScan(
+			builder.Expr("SELECT TABLE_NAME, COLUMN_NAME, SRS_ID FROM INFORMATION_SCHEMA.COLUMNS WHERE ?",
+				builder.And(
+					tableColumnSchema.F("TABLE_SCHEMA").Eq(database.Name),
+					tableColumnSchema.F("TABLE_NAME").In(toInterfaces(tableNames...)...),
+					builder.AsCond(builder.Expr("SRS_ID IS NOT NULL")),
+				),
+			),
+			&spatialColumnSchemaList,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, spatialColumnSchema := range spatialColumnSchemaList {
+			if col := database.Table(spatialColumnSchema.TABLE_NAME).Col(spatialColumnSchema.COLUMN_NAME); col != nil {
+				col.DataType = col.DataType + " SRID " + strconv.FormatUint(spatialColumnSchema.SRS_ID, 10)
+			}
+		}
 	}
 
 	if tableColumnSchema.Columns.Len() != 0 {
@@ -80,6 +126,9 @@ func dbFromInformationSchema(db sqlx.DBExecutor) (*sqlx.Database, error) {
 
 		for _, indexSchema := range indexList {
 			table := database.Table(indexSchema.TABLE_NAME)
+			if table == nil {
+				continue
+			}
 
 			if key := table.Keys.Key(indexSchema.INDEX_NAME); key != nil {
 				key.Def.ColNames = append(key.Def.ColNames, indexSchema.COLUMN_NAME)
@@ -98,11 +147,138 @@ func dbFromInformationSchema(db sqlx.DBExecutor) (*sqlx.Database, error) {
 	return database, nil
 }
 
+func baseTableNames(db sqlx.DBExecutor, tableSchema string) ([]string, error) {
+	tableTableSchema := SchemaDatabase.T(&TableSchema{})
+	tableSchemaList := make([]TableSchema, 0)
+
+	err := db.QueryExprAndScan(
+		builder.Select(tableTableSchema.Columns.Clone()).
+			From(tableTableSchema,
+				builder.Where(
+					builder.And(
+						tableTableSchema.F("TABLE_SCHEMA").Eq(tableSchema),
+						tableTableSchema.F("TABLE_TYPE").Eq("BASE TABLE"),
+					),
+				),
+			),
+		&tableSchemaList,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	tableNames := make([]string, len(tableSchemaList))
+	for i := range tableSchemaList {
+		tableNames[i] = tableSchemaList[i].TABLE_NAME
+	}
+	return tableNames, nil
+}
+
 var SchemaDatabase = sqlx.NewDatabase("INFORMATION_SCHEMA")
 
 func init() {
+	SchemaDatabase.Register(&TableSchema{})
 	SchemaDatabase.Register(&ColumnSchema{})
 	SchemaDatabase.Register(&IndexSchema{})
+	SchemaDatabase.Register(&PartitionSchema{})
+	SchemaDatabase.Register(&RoutineSchema{})
+	SchemaDatabase.Register(&TriggerSchema{})
+}
+
+// routineVersionsFromInformationSchema versions of managed functions by name, and triggers by table name and name
+func routineVersionsFromInformationSchema(db sqlx.DBExecutor) (map[string]string, map[string]map[string]string, error) {
+	d := db.D()
+
+	tableRoutineSchema := SchemaDatabase.T(&RoutineSchema{})
+	routineSchemaList := make([]RoutineSchema, 0)
+
+	err := db.QueryExprAndScan(
+		builder.Select(tableRoutineSchema.Columns.Clone()).
+			From(tableRoutineSchema,
+				builder.Where(
+					builder.And(
+						tableRoutineSchema.F("ROUTINE_SCHEMA").Eq(d.Name),
+						tableRoutineSchema.F("ROUTINE_TYPE").Eq("FUNCTION"),
+					),
+				),
+			),
+		&routineSchemaList,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	functions := map[string]string{}
+
+	for _, routineSchema := range routineSchemaList {
+		if version := builder.ParseRoutineVersion(routineSchema.ROUTINE_DEFINITION.String); version != "" {
+			functions[routineSchema.ROUTINE_NAME] = version
+		}
+	}
+
+	triggers := map[string]map[string]string{}
+
+	if tableNames := d.Tables.TableNames(); len(tableNames) > 0 {
+		tableTriggerSchema := SchemaDatabase.T(&TriggerSchema{})
+		triggerSchemaList := make([]TriggerSchema, 0)
+
+		err := db.QueryExprAndScan(
+			builder.Select(tableTriggerSchema.Columns.Clone()).
+				From(tableTriggerSchema,
+					builder.Where(
+						builder.And(
+							tableTriggerSchema.F("TRIGGER_SCHEMA").Eq(d.Name),
+							tableTriggerSchema.F("EVENT_OBJECT_TABLE").In(toInterfaces(tableNames...)...),
+						),
+					),
+				),
+			&triggerSchemaList,
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, triggerSchema := range triggerSchemaList {
+			if version := builder.ParseRoutineVersion(triggerSchema.ACTION_STATEMENT); version != "" {
+				if triggers[triggerSchema.EVENT_OBJECT_TABLE] == nil {
+					triggers[triggerSchema.EVENT_OBJECT_TABLE] = map[string]string{}
+				}
+				triggers[triggerSchema.EVENT_OBJECT_TABLE][triggerSchema.TRIGGER_NAME] = version
+			}
+		}
+	}
+
+	return functions, triggers, nil
+}
+
+func partitionNamesFromInformationSchema(db sqlx.DBExecutor, t *builder.Table) ([]string, error) {
+	tablePartitionSchema := SchemaDatabase.T(&PartitionSchema{})
+	partitionSchemaList := make([]PartitionSchema, 0)
+
+	err := db.QueryExprAndScan(
+		builder.Select(tablePartitionSchema.Columns.Clone()).
+			From(tablePartitionSchema,
+				builder.Where(
+					builder.And(
+						tablePartitionSchema.F("TABLE_SCHEMA").Eq(db.D().Name),
+						tablePartitionSchema.F("TABLE_NAME").Eq(t.Name),
+						tablePartitionSchema.F("PARTITION_NAME").IsNotNull(),
+					),
+				),
+				builder.OrderBy(builder.AscOrder(tablePartitionSchema.F("PARTITION_ORDINAL_POSITION"))),
+			),
+		&partitionSchemaList,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(partitionSchemaList))
+	for _, partitionSchema := range partitionSchemaList {
+		names = append(names, partitionSchema.PARTITION_NAME.String)
+	}
+
+	return names, nil
 }
 
 func colFromColumnSchema(columnSchema *ColumnSchema) *builder.Column {
@@ -128,6 +304,11 @@ func colFromColumnSchema(columnSchema *ColumnSchema) *builder.Column {
 		dataType = dataType + " unsigned"
 	}
 
+	// values of enum only kept in column type, like enum('A','B')
+	if dataType == "enum" {
+		dataType = columnSchema.COLUMN_TYPE
+	}
+
 	col.DataType = dataType
 
 	// numeric type
@@ -142,6 +323,11 @@ func colFromColumnSchema(columnSchema *ColumnSchema) *builder.Column {
 		col.Null = true
 	}
 
+	if columnSchema.COLUMN_COMMENT != "" {
+		col.Comment = strings.Split(columnSchema.COLUMN_COMMENT, "\n")[0]
+		col.Description = strings.Split(columnSchema.COLUMN_COMMENT, "\n")
+	}
+
 	return col
 }
 
@@ -164,6 +350,28 @@ func normalizeDefaultValue(v string) string {
 	return quoteWith(v, '\'', false, false)
 }
 
+var spatialDataTypes = map[string]bool{
+	"geometry":           true,
+	"point":              true,
+	"linestring":         true,
+	"polygon":            true,
+	"multipoint":         true,
+	"multilinestring":    true,
+	"multipolygon":       true,
+	"geomcollection":     true,
+	"geometrycollection": true,
+}
+
+type TableSchema struct {
+	TABLE_SCHEMA string `db:"TABLE_SCHEMA"`
+	TABLE_NAME   string `db:"TABLE_NAME"`
+	TABLE_TYPE   string `db:"TABLE_TYPE"`
+}
+
+func (TableSchema) TableName() string {
+	return "INFORMATION_SCHEMA.TABLES"
+}
+
 type ColumnSchema struct {
 	TABLE_SCHEMA             string         `db:"TABLE_SCHEMA"`
 	TABLE_NAME               string         `db:"TABLE_NAME"`
@@ -176,12 +384,20 @@ type ColumnSchema struct {
 	CHARACTER_MAXIMUM_LENGTH uint64         `db:"CHARACTER_MAXIMUM_LENGTH"`
 	NUMERIC_PRECISION        uint64         `db:"NUMERIC_PRECISION"`
 	NUMERIC_SCALE            uint64         `db:"NUMERIC_SCALE"`
+	COLUMN_COMMENT           string         `db:"COLUMN_COMMENT"`
 }
 
 func (ColumnSchema) TableName() string {
 	return "INFORMATION_SCHEMA.COLUMNS"
 }
 
+// SpatialColumnSchema SRID of spatial column
+type SpatialColumnSchema struct {
+	TABLE_NAME  string `db:"TABLE_NAME"`
+	COLUMN_NAME string `db:"COLUMN_NAME"`
+	SRS_ID      uint64 `db:"SRS_ID"`
+}
+
 type IndexSchema struct {
 	TABLE_SCHEMA string `db:"TABLE_SCHEMA"`
 	TABLE_NAME   string `db:"TABLE_NAME"`
@@ -196,3 +412,36 @@ type IndexSchema struct {
 func (IndexSchema) TableName() string {
 	return "INFORMATION_SCHEMA.STATISTICS"
 }
+
+type PartitionSchema struct {
+	TABLE_SCHEMA               string         `db:"TABLE_SCHEMA"`
+	TABLE_NAME                 string         `db:"TABLE_NAME"`
+	PARTITION_NAME             sql.NullString `db:"PARTITION_NAME"`
+	PARTITION_ORDINAL_POSITION sql.NullInt64  `db:"PARTITION_ORDINAL_POSITION"`
+}
+
+func (PartitionSchema) TableName() string {
+	return "INFORMATION_SCHEMA.PARTITIONS"
+}
+
+type RoutineSchema struct {
+	ROUTINE_SCHEMA     string         `db:"ROUTINE_SCHEMA"`
+	ROUTINE_NAME       string         `db:"ROUTINE_NAME"`
+	ROUTINE_TYPE       string         `db:"ROUTINE_TYPE"`
+	ROUTINE_DEFINITION sql.NullString `db:"ROUTINE_DEFINITION"`
+}
+
+func (RoutineSchema) TableName() string {
+	return "INFORMATION_SCHEMA.ROUTINES"
+}
+
+type TriggerSchema struct {
+	TRIGGER_SCHEMA     string `db:"TRIGGER_SCHEMA"`
+	TRIGGER_NAME       string `db:"TRIGGER_NAME"`
+	EVENT_OBJECT_TABLE string `db:"EVENT_OBJECT_TABLE"`
+	ACTION_STATEMENT   string `db:"ACTION_STATEMENT"`
+}
+
+func (TriggerSchema) TableName() string {
+	return "INFORMATION_SCHEMA.TRIGGERS"
+}
@@ -1,6 +1,49 @@
 package mysql
 
-import _ "unsafe"
+import (
+	"strconv"
+	"unicode/utf8"
+)
 
-//go:linkname quoteWith strconv.quoteWith
-func quoteWith(s string, quote byte, ASCIIonly, graphicOnly bool) string
+// quoteWith same as strconv.quoteWith, which is not allowed to be linked by go:linkname in new go versions
+func quoteWith(s string, quote byte, ASCIIonly, graphicOnly bool) string {
+	buf := make([]byte, 0, 3*len(s)/2)
+	buf = append(buf, quote)
+
+	for width := 0; len(s) > 0; s = s[width:] {
+		r := rune(s[0])
+		width = 1
+		if r >= utf8.RuneSelf {
+			r, width = utf8.DecodeRuneInString(s)
+		}
+		if width == 1 && r == utf8.RuneError {
+			buf = append(buf, `\x`...)
+			buf = append(buf, "0123456789abcdef"[s[0]>>4])
+			buf = append(buf, "0123456789abcdef"[s[0]&0xF])
+			continue
+		}
+		if r == rune(quote) {
+			buf = append(buf, '\\', quote)
+			continue
+		}
+		if r == '"' || r == '\'' {
+			buf = append(buf, byte(r))
+			continue
+		}
+
+		var q string
+		switch {
+		case ASCIIonly:
+			q = strconv.QuoteRuneToASCII(r)
+		case graphicOnly:
+			q = strconv.QuoteRuneToGraphic(r)
+		default:
+			q = strconv.QuoteRune(r)
+		}
+		// trim quotes of rune literal
+		buf = append(buf, q[1:len(q)-1]...)
+	}
+
+	buf = append(buf, quote)
+	return string(buf)
+}
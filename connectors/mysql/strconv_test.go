@@ -0,0 +1,37 @@
+package mysql
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestQuoteWith(t *testing.T) {
+	values := []string{
+		"",
+		"abc",
+		`a"b`,
+		"a'b",
+		`a\b`,
+		"tab\tnew line\n",
+		"中文",
+		"☺ ",
+		"\xff\xfe",
+		"�",
+		"\x00\x7f",
+	}
+
+	t.Run("same as strconv", func(t *testing.T) {
+		for _, v := range values {
+			gomega.NewWithT(t).Expect(quoteWith(v, '"', false, false)).To(gomega.Equal(strconv.Quote(v)))
+			gomega.NewWithT(t).Expect(quoteWith(v, '"', true, false)).To(gomega.Equal(strconv.QuoteToASCII(v)))
+			gomega.NewWithT(t).Expect(quoteWith(v, '"', false, true)).To(gomega.Equal(strconv.QuoteToGraphic(v)))
+		}
+	})
+
+	t.Run("single quote", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(quoteWith(`it's "ok"`, '\'', false, false)).To(gomega.Equal(`'it\'s "ok"'`))
+		gomega.NewWithT(t).Expect(quoteWith("a\nb", '\'', false, false)).To(gomega.Equal(`'a\nb'`))
+	})
+}
@@ -3,10 +3,13 @@ package postgresql
 import (
 	"bytes"
 	"context"
+	"crypto/sha1"
 	"database/sql/driver"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -21,6 +24,8 @@ import (
 var _ interface {
 	driver.Connector
 	builder.Dialect
+	builder.RowValueDialect
+	builder.LastInsertIDDialect
 } = (*PostgreSQLConnector)(nil)
 
 type PostgreSQLConnector struct {
@@ -28,6 +33,135 @@ type PostgreSQLConnector struct {
 	DBName     string
 	Extra      string
 	Extensions []string
+
+	// name of database migrating, marked in comments of functions and views as owner
+	owner string
+}
+
+// ownedBy returns connector marking owner in comments of functions and views created by it
+func (c PostgreSQLConnector) ownedBy(owner string) *PostgreSQLConnector {
+	c.owner = owner
+	return &c
+}
+
+const ownerCommentPrefix = " database:"
+
+// ownedComment marks owner in comment, routines or views without owner will not be dropped when migrating
+func ownedComment(comment string, owner string) string {
+	if owner == "" {
+		return comment
+	}
+	return comment + ownerCommentPrefix + owner
+}
+
+func ownerOfComment(comment string) string {
+	if i := strings.LastIndex(comment, ownerCommentPrefix); i >= 0 {
+		return comment[i+len(ownerCommentPrefix):]
+	}
+	return ""
+}
+
+// nativeEnumTypesDiff creates missing native enum types in schema of d and adds new values of existed ones.
+// values removed from enum will be kept, postgres not support to drop value of enum type.
+func (c *PostgreSQLConnector) nativeEnumTypesDiff(d *sqlx.Database, prevEnumTypes map[string][]string) (exprs []builder.SqlExpr, err error) {
+	typeNames := make([]string, 0)
+	enumTypes := map[string][]string{}
+
+	d.Tables.Range(func(table *builder.Table, idx int) {
+		table.Columns.Range(func(col *builder.Column, idx int) {
+			if err != nil {
+				return
+			}
+			if nativeEnum, ok := nativeEnumOf(col.ColumnType); ok {
+				typeName := nativeEnum.NativeEnumTypeName()
+				values := nativeEnum.NativeEnumValues()
+
+				if prevValues, ok := enumTypes[typeName]; ok {
+					// types named by short type name, different enums with same name could not share one type
+					if strings.Join(prevValues, ",") != strings.Join(values, ",") {
+						err = fmt.Errorf("native enum type %s of %s.%s conflicts, values %v but %v declared by other column", typeName, table.Name, col.Name, values, prevValues)
+					}
+					return
+				}
+
+				typeNames = append(typeNames, typeName)
+				enumTypes[typeName] = values
+			}
+		})
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, typeName := range typeNames {
+		prevValues, ok := prevEnumTypes[typeName]
+		if !ok {
+			exprs = append(exprs, c.CreateEnumType(enumTypeName(d.Schema, typeName), enumTypes[typeName]))
+			continue
+		}
+
+		existed := map[string]bool{}
+		for _, v := range prevValues {
+			existed[v] = true
+		}
+
+		for _, v := range enumTypes[typeName] {
+			if !existed[v] {
+				exprs = append(exprs, c.AddEnumValue(enumTypeName(d.Schema, typeName), v))
+			}
+		}
+	}
+
+	return
+}
+
+func nativeEnumOf(columnType *builder.ColumnType) (builder.NativeEnum, bool) {
+	if columnType.DataType != "" {
+		return nil, false
+	}
+	if rv, ok := typex.TryNew(columnType.Type); ok {
+		if nativeEnum, ok := rv.Interface().(builder.NativeEnum); ok {
+			return nativeEnum, true
+		}
+	}
+	return nil, false
+}
+
+// enumTypeName qualifies native enum type by schema, as the type created in the schema of tables
+func enumTypeName(schema string, typeName string) string {
+	if schema == "" {
+		return typeName
+	}
+	return schema + "." + typeName
+}
+
+func (c *PostgreSQLConnector) CreateEnumType(typeName string, values []string) builder.SqlExpr {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = quoteLiteral(v)
+	}
+
+	e := builder.Expr("CREATE TYPE ")
+	e.WriteQuery(typeName)
+	e.WriteQuery(" AS ENUM (")
+	e.WriteQuery(strings.Join(quoted, ","))
+	e.WriteQuery(")")
+	e.WriteEnd()
+	return e
+}
+
+func (c *PostgreSQLConnector) AddEnumValue(typeName string, value string) builder.SqlExpr {
+	e := builder.Expr("ALTER TYPE ")
+	e.WriteQuery(typeName)
+	e.WriteQuery(" ADD VALUE IF NOT EXISTS ")
+	e.WriteQuery(quoteLiteral(value))
+	e.WriteEnd()
+	return e
+}
+
+func quoteLiteral(v string) string {
+	return "'" + strings.ReplaceAll(v, "'", "''") + "'"
 }
 
 func (c *PostgreSQLConnector) Connect(ctx context.Context) (driver.Conn, error) {
@@ -75,10 +209,15 @@ func (c PostgreSQLConnector) WithDBName(dbName string) driver.Connector {
 	return &c
 }
 
+// Introspect returns tables of db.D() in the connecting database
+func (c *PostgreSQLConnector) Introspect(db sqlx.DBExecutor) (*sqlx.Database, error) {
+	return DBFromInformationSchema(db)
+}
+
 func (c *PostgreSQLConnector) Migrate(ctx context.Context, db sqlx.DBExecutor) error {
 	output := migration.MigrationOutputFromContext(ctx)
 
-	prevDB, err := dbFromInformationSchema(db)
+	prevDB, err := DBFromInformationSchema(db)
 	if err != nil {
 		return err
 	}
@@ -101,6 +240,14 @@ func (c *PostgreSQLConnector) Migrate(ctx context.Context, db sqlx.DBExecutor) e
 		return err
 	}
 
+	prevEnumTypes := map[string][]string{}
+	prevViews := map[string]*ViewSchema{}
+	prevViewDependencies := map[string][]string{}
+	prevFunctions := map[string]string{}
+	prevTriggers := map[string]map[string]string{}
+
+	functions, tables := c.routinesWithOnUpdate(d)
+
 	if prevDB == nil {
 		prevDB = &sqlx.Database{
 			Name: d.Name,
@@ -108,6 +255,23 @@ func (c *PostgreSQLConnector) Migrate(ctx context.Context, db sqlx.DBExecutor) e
 		if err := exec(dialect.CreateDatabase(d.Name)); err != nil {
 			return err
 		}
+	} else {
+		prevEnumTypes, err = nativeEnumTypesFromDB(db, d.Schema)
+		if err != nil {
+			return err
+		}
+		prevViews, err = viewSchemasFromDB(db, d.Schema)
+		if err != nil {
+			return err
+		}
+		prevViewDependencies, err = viewDependenciesFromDB(db, d.Schema)
+		if err != nil {
+			return err
+		}
+		prevFunctions, prevTriggers, err = routineVersionsFromDB(db, d, functions)
+		if err != nil {
+			return err
+		}
 	}
 
 	if d.Schema != "" {
@@ -117,23 +281,60 @@ func (c *PostgreSQLConnector) Migrate(ctx context.Context, db sqlx.DBExecutor) e
 		prevDB = prevDB.WithSchema(d.Schema)
 	}
 
+	enumTypeExprs, err := c.nativeEnumTypesDiff(d, prevEnumTypes)
+	if err != nil {
+		return err
+	}
+
+	// native enum types should be ready before tables
+	for _, expr := range enumTypeExprs {
+		if err := exec(expr); err != nil {
+			return err
+		}
+	}
+
+	tableExprs := map[string][]builder.SqlExpr{}
+	alteredTables := map[string]bool{}
+
 	for _, name := range d.Tables.TableNames() {
 		table := d.Table(name)
 
 		prevTable := prevDB.Table(name)
 
 		if prevTable == nil {
-			for _, expr := range dialect.CreateTableIsNotExists(table) {
-				if err := exec(expr); err != nil {
-					return err
-				}
-			}
+			tableExprs[name] = dialect.CreateTableIsNotExists(table)
 			continue
 		}
 
-		exprList := table.Diff(prevTable, dialect)
+		tableExprs[name] = table.Diff(prevTable, dialect)
+		alteredTables[name] = len(tableExprs[name]) > 0
+	}
+
+	// views could not be replaced when columns changed, and block altering columns of tables they depend on,
+	// so they will be dropped before tables migrated, and created after
+	for _, prevView := range viewsToDrop(d, prevViews, prevViewDependencies, alteredTables) {
+		if err := exec(c.DropView(prevViewTable(prevView, d.Schema))); err != nil {
+			return err
+		}
+		delete(prevViews, prevView.VIEW_NAME)
+	}
+
+	for _, name := range d.Tables.TableNames() {
+		for _, expr := range tableExprs[name] {
+			if err := exec(expr); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, expr := range builder.RoutinesDiff(c.ownedBy(d.Name), d.Schema, functions, tables, prevFunctions, prevTriggers) {
+		if err := exec(expr); err != nil {
+			return err
+		}
+	}
 
-		for _, expr := range exprList {
+	for _, name := range d.Views.TableNames() {
+		for _, expr := range c.ownedBy(d.Name).viewDiff(d.Views.Table(name), prevViews[name]) {
 			if err := exec(expr); err != nil {
 				return err
 			}
@@ -267,7 +468,7 @@ func (c *PostgreSQLConnector) CreateTableIsNotExists(t *builder.Table) (exprs []
 
 			e.WriteExpr(col)
 			e.WriteQueryByte(' ')
-			e.WriteExpr(c.DataType(col.ColumnType))
+			e.WriteExpr(c.columnDataType(col))
 		})
 
 		t.Keys.Range(func(key *builder.Key, idx int) {
@@ -283,9 +484,27 @@ func (c *PostgreSQLConnector) CreateTableIsNotExists(t *builder.Table) (exprs []
 		expr.WriteQueryByte('\n')
 	})
 
+	if t.Partition != nil {
+		expr.WriteQuery(" PARTITION BY ")
+		expr.WriteQuery(t.Partition.Method)
+		expr.WriteQueryByte(' ')
+		expr.WriteExpr(t.Partition.TableExpr(t))
+	}
+
 	expr.WriteEnd()
 	exprs = append(exprs, expr)
 
+	// rows out of partitions will be kept in default partition
+	if t.Partition != nil && t.Partition.Method != "HASH" {
+		e := builder.Expr("CREATE TABLE IF NOT EXISTS ")
+		e.WriteExpr(partitionTable(t, defaultPartitionName(t)))
+		e.WriteQuery(" PARTITION OF ")
+		e.WriteExpr(t)
+		e.WriteQuery(" DEFAULT")
+		e.WriteEnd()
+		exprs = append(exprs, e)
+	}
+
 	t.Keys.Range(func(key *builder.Key, idx int) {
 		if !key.IsPrimary() {
 			exprs = append(exprs, c.AddIndex(key))
@@ -295,6 +514,390 @@ func (c *PostgreSQLConnector) CreateTableIsNotExists(t *builder.Table) (exprs []
 	return
 }
 
+// routinesWithOnUpdate emulates onupdate of columns by generated function and trigger of table
+func (c *PostgreSQLConnector) routinesWithOnUpdate(d *sqlx.Database) ([]*builder.StoredFunction, []*builder.Table) {
+	functions := append([]*builder.StoredFunction{}, d.Functions...)
+	tables := make([]*builder.Table, 0)
+
+	d.Tables.Range(func(table *builder.Table, idx int) {
+		body := bytes.NewBuffer(nil)
+
+		table.Columns.Range(func(col *builder.Column, idx int) {
+			if col.DeprecatedActions != nil || col.ColumnType.OnUpdate == nil {
+				return
+			}
+
+			_, _ = fmt.Fprintf(body, `	IF NEW IS DISTINCT FROM OLD AND NEW.%s IS NOT DISTINCT FROM OLD.%s THEN
+		NEW.%s = %s;
+	END IF;
+`, col.Name, col.Name, col.Name, *col.ColumnType.OnUpdate)
+		})
+
+		if body.Len() == 0 {
+			tables = append(tables, table)
+			return
+		}
+
+		fn := &builder.StoredFunction{
+			Schema: table.Schema,
+			Name:   table.Name + "_onupdate",
+			Def:    "() RETURNS trigger LANGUAGE plpgsql",
+			Body:   "BEGIN\n" + body.String() + "\tRETURN NEW;\nEND",
+		}
+
+		functions = append(functions, fn)
+
+		t := *table
+		t.Triggers = append([]*builder.Trigger{}, table.Triggers...)
+		t.AddTrigger(&builder.Trigger{
+			Name: table.Name + "_onupdate",
+			When: "BEFORE UPDATE",
+			Body: "EXECUTE PROCEDURE " + routineName(fn.Schema, fn.Name) + "()",
+		})
+
+		tables = append(tables, &t)
+	})
+
+	return functions, tables
+}
+
+// CreateOrReplaceFunction drops and creates function, and marks version in comment,
+// return type could not be changed by CREATE OR REPLACE FUNCTION
+func (c *PostgreSQLConnector) CreateOrReplaceFunction(fn *builder.StoredFunction) []builder.SqlExpr {
+	e := builder.Expr("CREATE FUNCTION ")
+	e.WriteQuery(routineName(fn.Schema, fn.Name))
+	e.WriteQuery(fn.Def)
+	e.WriteQuery(" AS $$\n")
+	e.WriteQuery(fn.Body)
+	e.WriteQuery("\n$$")
+	e.WriteEnd()
+
+	comment := builder.Expr("COMMENT ON FUNCTION ")
+	comment.WriteQuery(routineName(fn.Schema, fn.Name))
+	comment.WriteQuery(" IS ")
+	comment.WriteQuery(quoteLiteral(ownedComment(fn.Version(), c.owner)))
+	comment.WriteEnd()
+
+	return []builder.SqlExpr{c.DropFunction(fn), e, comment}
+}
+
+func (c *PostgreSQLConnector) DropFunction(fn *builder.StoredFunction) builder.SqlExpr {
+	e := builder.Expr("DROP FUNCTION IF EXISTS ")
+	e.WriteQuery(routineName(fn.Schema, fn.Name))
+	e.WriteEnd()
+	return e
+}
+
+// CreateOrReplaceTrigger drops and creates trigger, and marks version in comment
+func (c *PostgreSQLConnector) CreateOrReplaceTrigger(t *builder.Table, trigger *builder.Trigger) []builder.SqlExpr {
+	e := builder.Expr("CREATE TRIGGER ")
+	e.WriteQuery(trigger.Name)
+	e.WriteQueryByte(' ')
+	e.WriteQuery(trigger.When)
+	e.WriteQuery(" ON ")
+	e.WriteExpr(t)
+	e.WriteQuery(" FOR EACH ROW ")
+	e.WriteQuery(trigger.Body)
+	e.WriteEnd()
+
+	comment := builder.Expr("COMMENT ON TRIGGER ")
+	comment.WriteQuery(trigger.Name)
+	comment.WriteQuery(" ON ")
+	comment.WriteExpr(t)
+	comment.WriteQuery(" IS ")
+	comment.WriteQuery(quoteLiteral(trigger.Version()))
+	comment.WriteEnd()
+
+	return []builder.SqlExpr{c.DropTrigger(t, trigger), e, comment}
+}
+
+func (c *PostgreSQLConnector) DropTrigger(t *builder.Table, trigger *builder.Trigger) builder.SqlExpr {
+	e := builder.Expr("DROP TRIGGER IF EXISTS ")
+	e.WriteQuery(trigger.Name)
+	e.WriteQuery(" ON ")
+	e.WriteExpr(t)
+	e.WriteEnd()
+	return e
+}
+
+func routineName(schema string, name string) string {
+	if schema != "" {
+		return schema + "." + name
+	}
+	return name
+}
+
+// viewDiff drops and creates view when comment marked definition changed,
+// postgres could not replace view when columns renamed, removed or retyped
+func (c *PostgreSQLConnector) viewDiff(v *builder.Table, prevView *ViewSchema) (exprs []builder.SqlExpr) {
+	if prevView != nil {
+		if prevView.VIEW_COMMENT == viewComment(v, c.owner) {
+			return nil
+		}
+
+		exprs = append(exprs, c.DropView(prevViewTable(prevView, v.Schema)))
+	}
+
+	return append(exprs, c.CreateOrReplaceView(v)...)
+}
+
+// viewsToDrop returns views should be dropped before migrating tables, dependents first.
+// they are views owned by d which changed or not registered any more,
+// registered views depend on altered tables, and registered views depend on views dropped.
+func viewsToDrop(d *sqlx.Database, prevViews map[string]*ViewSchema, dependencies map[string][]string, alteredTables map[string]bool) []*ViewSchema {
+	names := make([]string, 0, len(prevViews))
+	for name := range prevViews {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	dropped := map[string]bool{}
+
+	for _, name := range names {
+		prevView := prevViews[name]
+
+		if v := d.Views.Table(name); v != nil {
+			if prevView.VIEW_COMMENT != viewComment(v, d.Name) {
+				dropped[name] = true
+				continue
+			}
+			for _, tableName := range dependencies[name] {
+				if alteredTables[tableName] {
+					dropped[name] = true
+				}
+			}
+			continue
+		}
+
+		// views of other databases sharing the schema should be kept
+		if strings.HasPrefix(prevView.VIEW_COMMENT, viewCommentPrefix) && ownerOfComment(prevView.VIEW_COMMENT) == d.Name {
+			dropped[name] = true
+		}
+	}
+
+	for changed := true; changed; {
+		changed = false
+		for _, name := range names {
+			if dropped[name] || d.Views.Table(name) == nil {
+				continue
+			}
+			for _, tableName := range dependencies[name] {
+				if dropped[tableName] {
+					dropped[name] = true
+					changed = true
+				}
+			}
+		}
+	}
+
+	list := make([]*ViewSchema, 0)
+	visited := map[string]bool{}
+
+	var visit func(name string)
+	visit = func(name string) {
+		if visited[name] {
+			return
+		}
+		visited[name] = true
+
+		for _, dependent := range names {
+			if !dropped[dependent] {
+				continue
+			}
+			for _, tableName := range dependencies[dependent] {
+				if tableName == name {
+					visit(dependent)
+				}
+			}
+		}
+
+		list = append(list, prevViews[name])
+	}
+
+	for _, name := range names {
+		if dropped[name] {
+			visit(name)
+		}
+	}
+
+	return list
+}
+
+func prevViewTable(prevView *ViewSchema, schema string) *builder.Table {
+	v := builder.V(prevView.VIEW_NAME, nil)
+	v.Schema = schema
+	v.View.Materialized = prevView.IsMaterialized()
+	return v
+}
+
+// CreateOrReplaceView replaces view, but materialized view will be dropped and created with its indexes.
+// definition of view will be marked in comment, for checking changes when migrating.
+func (c *PostgreSQLConnector) CreateOrReplaceView(v *builder.Table) (exprs []builder.SqlExpr) {
+	kind := "VIEW"
+
+	if v.IsMaterializedView() {
+		kind = "MATERIALIZED VIEW"
+		exprs = append(exprs, c.DropView(v))
+
+		e := builder.Expr("CREATE MATERIALIZED VIEW ")
+		e.WriteExpr(v)
+		e.WriteQuery(" AS ")
+		e.WriteExpr(v.View.Query)
+		e.WriteEnd()
+		exprs = append(exprs, e)
+
+		v.Keys.Range(func(key *builder.Key, idx int) {
+			if !key.IsPrimary() {
+				exprs = append(exprs, c.AddIndex(key))
+			}
+		})
+	} else {
+		e := builder.Expr("CREATE OR REPLACE VIEW ")
+		e.WriteExpr(v)
+		e.WriteQuery(" AS ")
+		e.WriteExpr(v.View.Query)
+		e.WriteEnd()
+		exprs = append(exprs, e)
+	}
+
+	e := builder.Expr("COMMENT ON " + kind + " ")
+	e.WriteExpr(v)
+	e.WriteQuery(" IS ")
+	e.WriteQuery(quoteLiteral(viewComment(v, c.owner)))
+	e.WriteEnd()
+
+	return append(exprs, e)
+}
+
+func (c *PostgreSQLConnector) DropView(v *builder.Table) builder.SqlExpr {
+	e := builder.Expr("DROP ")
+	if v.IsMaterializedView() {
+		e.WriteQuery("MATERIALIZED ")
+	}
+	e.WriteQuery("VIEW IF EXISTS ")
+	e.WriteExpr(v)
+	e.WriteEnd()
+	return e
+}
+
+func (c *PostgreSQLConnector) RefreshMaterializedView(v *builder.Table, concurrently bool) builder.SqlExpr {
+	e := builder.Expr("REFRESH MATERIALIZED VIEW ")
+	if concurrently {
+		e.WriteQuery("CONCURRENTLY ")
+	}
+	e.WriteExpr(v)
+	e.WriteEnd()
+	return e
+}
+
+const viewCommentPrefix = "sqlx:"
+
+// viewComment marks kind and definition of view, and owner
+func viewComment(v *builder.Table, owner string) string {
+	ex := builder.ResolveExpr(v.View.Query)
+
+	h := sha1.New()
+	_, _ = fmt.Fprintf(h, "%v\n%s\n%v", v.IsMaterializedView(), ex.Query(), ex.Args())
+
+	return ownedComment(viewCommentPrefix+hex.EncodeToString(h.Sum(nil)), owner)
+}
+
+// AddPartition creates partition table for RANGE or LIST.
+// partition could not be created when rows of its bounds are in default partition,
+// so default partition will be detached, rows of the partition moved out from it, then attached back.
+func (c *PostgreSQLConnector) AddPartition(t *builder.Table, p *builder.Partition) builder.SqlExpr {
+	partition := partitionTable(t, p.Name)
+	defaultPartition := partitionTable(t, defaultPartitionName(t))
+	partitionKey := t.Partition.TableExpr(t)
+
+	e := builder.Expr("ALTER TABLE ")
+	e.WriteExpr(t)
+	e.WriteQuery(" DETACH PARTITION ")
+	e.WriteExpr(defaultPartition)
+	e.WriteEnd()
+	e.WriteQueryByte('\n')
+
+	e.WriteQuery("CREATE TABLE IF NOT EXISTS ")
+	e.WriteExpr(partition)
+	e.WriteQuery(" PARTITION OF ")
+	e.WriteExpr(t)
+
+	if len(p.In) > 0 {
+		e.WriteQuery(" FOR VALUES IN (")
+		e.WriteQuery(strings.Join(p.In, ","))
+		e.WriteQueryByte(')')
+	} else {
+		e.WriteQuery(" FOR VALUES FROM (")
+		e.WriteQuery(p.From)
+		e.WriteQuery(") TO (")
+		e.WriteQuery(p.To)
+		e.WriteQueryByte(')')
+	}
+	e.WriteEnd()
+	e.WriteQueryByte('\n')
+
+	e.WriteQuery("WITH moved AS (DELETE FROM ")
+	e.WriteExpr(defaultPartition)
+	e.WriteQuery(" WHERE ")
+	if len(p.In) > 0 {
+		e.WriteExpr(partitionKey)
+		e.WriteQuery(" IN (")
+		e.WriteQuery(strings.Join(p.In, ","))
+		e.WriteQueryByte(')')
+	} else {
+		e.WriteExpr(partitionKey)
+		e.WriteQuery(" >= (")
+		e.WriteQuery(p.From)
+		e.WriteQuery(") AND ")
+		e.WriteExpr(partitionKey)
+		e.WriteQuery(" < (")
+		e.WriteQuery(p.To)
+		e.WriteQueryByte(')')
+	}
+	e.WriteQuery(" RETURNING *) INSERT INTO ")
+	e.WriteExpr(partition)
+	e.WriteQuery(" SELECT * FROM moved")
+	e.WriteEnd()
+	e.WriteQueryByte('\n')
+
+	e.WriteQuery("ALTER TABLE ")
+	e.WriteExpr(t)
+	e.WriteQuery(" ATTACH PARTITION ")
+	e.WriteExpr(defaultPartition)
+	e.WriteQuery(" DEFAULT")
+	e.WriteEnd()
+	return e
+}
+
+func (c *PostgreSQLConnector) DropPartition(t *builder.Table, p *builder.Partition) builder.SqlExpr {
+	return c.DropTable(partitionTable(t, p.Name))
+}
+
+func (c *PostgreSQLConnector) SupportRowValue() bool {
+	return true
+}
+
+func (c *PostgreSQLConnector) LastInsertID(col *builder.Column) builder.SqlExpr {
+	tableName := col.Table.Name
+	if col.Table.Schema != "" {
+		tableName = col.Table.Schema + "." + tableName
+	}
+	return builder.Expr("currval(pg_get_serial_sequence(?, ?))", tableName, col.Name)
+}
+
+// PartitionNames returns names of partitions of table in the connecting database
+func (c *PostgreSQLConnector) PartitionNames(db sqlx.DBExecutor, t *builder.Table) ([]string, error) {
+	return partitionNamesFromDB(db, t)
+}
+
+func partitionTable(t *builder.Table, name string) *builder.Table {
+	return &builder.Table{Name: name, Schema: t.Schema}
+}
+
+func defaultPartitionName(t *builder.Table) string {
+	return t.Name + "_default"
+}
+
 func (c *PostgreSQLConnector) DropTable(t *builder.Table) builder.SqlExpr {
 	e := builder.Expr("DROP TABLE IF EXISTS ")
 	e.WriteExpr(t)
@@ -315,7 +918,7 @@ func (c *PostgreSQLConnector) AddColumn(col *builder.Column) builder.SqlExpr {
 	e.WriteQuery(" ADD COLUMN ")
 	e.WriteExpr(col)
 	e.WriteQueryByte(' ')
-	e.WriteExpr(c.DataType(col.ColumnType))
+	e.WriteExpr(c.columnDataType(col))
 	e.WriteEnd()
 	return e
 }
@@ -359,7 +962,7 @@ func (c *PostgreSQLConnector) ModifyColumn(col *builder.Column, prev *builder.Co
 		e.WriteQuery(" ALTER COLUMN ")
 		e.WriteExpr(col)
 		e.WriteQuery(" TYPE ")
-		e.WriteQuery(dbDataType)
+		e.WriteQuery(c.qualifyDataType(col, dbDataType))
 
 		e.WriteQuery(" /* FROM ")
 		e.WriteQuery(prevDbDataType)
@@ -421,8 +1024,24 @@ func (c *PostgreSQLConnector) DataType(columnType *builder.ColumnType) builder.S
 	return builder.Expr(dbDataType + autocompleteSize(dbDataType, columnType) + c.dataTypeModify(columnType, dbDataType))
 }
 
+// columnDataType as DataType, but native enum type qualified by schema of table
+func (c *PostgreSQLConnector) columnDataType(col *builder.Column) builder.SqlExpr {
+	dbDataType := c.qualifyDataType(col, dealias(c.dbDataType(col.ColumnType.Type, col.ColumnType)))
+	return builder.Expr(dbDataType + autocompleteSize(dbDataType, col.ColumnType) + c.dataTypeModify(col.ColumnType, dbDataType))
+}
+
+func (c *PostgreSQLConnector) qualifyDataType(col *builder.Column, dbDataType string) string {
+	if col.Table == nil {
+		return dbDataType
+	}
+	if _, ok := nativeEnumOf(col.ColumnType); ok {
+		return enumTypeName(col.Table.Schema, dbDataType)
+	}
+	return dbDataType
+}
+
 func (c *PostgreSQLConnector) dataType(typ typex.Type, columnType *builder.ColumnType) string {
-	dbDataType := dealias(c.dbDataType(columnType.Type, columnType))
+	dbDataType := dealias(c.dbDataType(typ, columnType))
 	return dbDataType + autocompleteSize(dbDataType, columnType)
 }
 
@@ -460,6 +1079,8 @@ func (c *PostgreSQLConnector) dbDataType(typ typex.Type, columnType *builder.Col
 		if typ.Elem().Kind() == reflect.Uint8 {
 			return "bytea"
 		}
+		// element size is not kept by information_schema, so array always without size
+		return dealias(c.dbDataType(typ.Elem(), columnType)) + "[]"
 	case reflect.String:
 		size := columnType.Length
 		if size < 65535/3 {
@@ -471,16 +1092,6 @@ func (c *PostgreSQLConnector) dbDataType(typ typex.Type, columnType *builder.Col
 	switch typ.Name() {
 	case "Hstore":
 		return "hstore"
-	case "ByteaArray":
-		return c.dataType(typex.FromRType(reflect.TypeOf(pq.ByteaArray{[]byte("")}[0])), columnType) + "[]"
-	case "BoolArray":
-		return c.dataType(typex.FromRType(reflect.TypeOf(pq.BoolArray{true}[0])), columnType) + "[]"
-	case "Float64Array":
-		return c.dataType(typex.FromRType(reflect.TypeOf(pq.Float64Array{0}[0])), columnType) + "[]"
-	case "Int64Array":
-		return c.dataType(typex.FromRType(reflect.TypeOf(pq.Int64Array{0}[0])), columnType) + "[]"
-	case "StringArray":
-		return c.dataType(typex.FromRType(reflect.TypeOf(pq.StringArray{""}[0])), columnType) + "[]"
 	case "NullInt64":
 		return "bigint"
 	case "NullFloat64":
@@ -6,8 +6,11 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/go-courier/enumeration"
+	"github.com/go-courier/sqlx/v2"
 	"github.com/go-courier/sqlx/v2/builder"
 	"github.com/go-courier/sqlx/v2/builder/buidertestingutils"
+	"github.com/go-courier/sqlx/v2/datatypes"
 	"github.com/onsi/gomega"
 )
 
@@ -88,6 +91,296 @@ func TestPostgreSQLConnector(t *testing.T) {
 		},
 	}
 
+	t.Run("ArrayDataType", func(t *testing.T) {
+		tableWithArray := builder.T("t",
+			builder.Col("f_tags").Type(datatypes.StringArray{}, ",default='{}'"),
+			builder.Col("f_ids").Type([]int64{}, ",null"),
+		)
+
+		gomega.NewWithT(t).Expect(c.AddColumn(tableWithArray.Col("f_tags"))).
+			To(buidertestingutils.BeExpr( /* language=PostgreSQL */ "ALTER TABLE t ADD COLUMN f_tags character varying[] NOT NULL DEFAULT '{}'::character varying[];"))
+		gomega.NewWithT(t).Expect(c.AddColumn(tableWithArray.Col("f_ids"))).
+			To(buidertestingutils.BeExpr( /* language=PostgreSQL */ "ALTER TABLE t ADD COLUMN f_ids bigint[];"))
+	})
+
+	t.Run("GeometryDataType", func(t *testing.T) {
+		tableWithGeometry := builder.T("t",
+			builder.Col("f_location").Type(datatypes.Point{}, ""),
+		)
+
+		gomega.NewWithT(t).Expect(c.AddColumn(tableWithGeometry.Col("f_location"))).
+			To(buidertestingutils.BeExpr( /* language=PostgreSQL */ "ALTER TABLE t ADD COLUMN f_location geometry(Point,4326) NOT NULL;"))
+	})
+
+	t.Run("DecimalDataType", func(t *testing.T) {
+		tableWithDecimal := builder.T("t",
+			builder.Col("f_amount").Type(datatypes.Decimal{}, ",size=20,decimal=4,default='0'"),
+		)
+
+		gomega.NewWithT(t).Expect(c.AddColumn(tableWithDecimal.Col("f_amount"))).
+			To(buidertestingutils.BeExpr( /* language=PostgreSQL */ "ALTER TABLE t ADD COLUMN f_amount numeric(20,4) NOT NULL DEFAULT '0'::numeric;"))
+	})
+
+	t.Run("Partition", func(t *testing.T) {
+		tableWithPartition := builder.T("t_event",
+			builder.Col("f_id").Field("ID").Type(uint64(0), ""),
+			builder.Col("f_created_at").Field("CreatedAt").Type(int64(0), ""),
+			builder.PrimaryKey(builder.Cols("f_id", "f_created_at")),
+		)
+		tableWithPartition.Partition = builder.ParsePartitionDef("RANGE", "CreatedAt")
+
+		exprs := c.CreateTableIsNotExists(tableWithPartition)
+		gomega.NewWithT(t).Expect(exprs).To(gomega.HaveLen(2))
+		gomega.NewWithT(t).Expect(exprs[0]).To(buidertestingutils.BeExpr( /* language=PostgreSQL */ `CREATE TABLE IF NOT EXISTS t_event (
+	f_id bigint NOT NULL,
+	f_created_at bigint NOT NULL,
+	PRIMARY KEY (f_id,f_created_at)
+) PARTITION BY RANGE (f_created_at);`))
+		gomega.NewWithT(t).Expect(exprs[1]).To(buidertestingutils.BeExpr( /* language=PostgreSQL */ "CREATE TABLE IF NOT EXISTS t_event_default PARTITION OF t_event DEFAULT;"))
+
+		p := &builder.Partition{Name: "t_event_202610", From: "1790812800", To: "1793491200"}
+
+		gomega.NewWithT(t).Expect(c.AddPartition(tableWithPartition, p)).
+			To(buidertestingutils.BeExpr( /* language=PostgreSQL */ `ALTER TABLE t_event DETACH PARTITION t_event_default;
+CREATE TABLE IF NOT EXISTS t_event_202610 PARTITION OF t_event FOR VALUES FROM (1790812800) TO (1793491200);
+WITH moved AS (DELETE FROM t_event_default WHERE (f_created_at) >= (1790812800) AND (f_created_at) < (1793491200) RETURNING *) INSERT INTO t_event_202610 SELECT * FROM moved;
+ALTER TABLE t_event ATTACH PARTITION t_event_default DEFAULT;`))
+		gomega.NewWithT(t).Expect(c.DropPartition(tableWithPartition, p)).
+			To(buidertestingutils.BeExpr( /* language=PostgreSQL */ "DROP TABLE IF EXISTS t_event_202610;"))
+
+		tableWithListPartition := builder.T("t_event",
+			builder.Col("f_id").Field("ID").Type(uint64(0), ""),
+			builder.Col("f_region").Field("Region").Type("", ",size=16"),
+			builder.PrimaryKey(builder.Cols("f_id", "f_region")),
+		)
+		tableWithListPartition.Partition = builder.ParsePartitionDef("LIST", "Region")
+
+		gomega.NewWithT(t).Expect(c.AddPartition(tableWithListPartition, &builder.Partition{Name: "t_event_cn", In: []string{"'CN'"}})).
+			To(buidertestingutils.BeExpr( /* language=PostgreSQL */ `ALTER TABLE t_event DETACH PARTITION t_event_default;
+CREATE TABLE IF NOT EXISTS t_event_cn PARTITION OF t_event FOR VALUES IN ('CN');
+WITH moved AS (DELETE FROM t_event_default WHERE (f_region) IN ('CN') RETURNING *) INSERT INTO t_event_cn SELECT * FROM moved;
+ALTER TABLE t_event ATTACH PARTITION t_event_default DEFAULT;`))
+	})
+
+	t.Run("View", func(t *testing.T) {
+		view := builder.V("v_user", builder.Select(table.MustCols("F_id", "f_name")).From(table))
+
+		exprs := c.CreateOrReplaceView(view)
+		gomega.NewWithT(t).Expect(exprs).To(gomega.HaveLen(2))
+		gomega.NewWithT(t).Expect(exprs[0]).To(buidertestingutils.BeExpr( /* language=PostgreSQL */ "CREATE OR REPLACE VIEW v_user AS SELECT f_id,f_name FROM t;"))
+		gomega.NewWithT(t).Expect(exprs[1]).To(buidertestingutils.BeExpr( /* language=PostgreSQL */ "COMMENT ON VIEW v_user IS '" + viewComment(view, "") + "';"))
+
+		gomega.NewWithT(t).Expect(c.viewDiff(view, &ViewSchema{VIEW_NAME: "v_user", VIEW_KIND: "v", VIEW_COMMENT: viewComment(view, "")})).To(gomega.HaveLen(0))
+
+		materializedView := builder.MaterializedV("v_user", builder.Select(table.MustCols("F_id", "f_name")).From(table),
+			builder.Col("f_id").Field("ID").Type(uint64(0), ""),
+			builder.UniqueIndex("i_id", builder.Cols("f_id")),
+		)
+
+		exprs = c.viewDiff(materializedView, &ViewSchema{VIEW_NAME: "v_user", VIEW_KIND: "v", VIEW_COMMENT: viewComment(view, "")})
+		gomega.NewWithT(t).Expect(exprs).To(gomega.HaveLen(5))
+		gomega.NewWithT(t).Expect(exprs[0]).To(buidertestingutils.BeExpr( /* language=PostgreSQL */ "DROP VIEW IF EXISTS v_user;"))
+		gomega.NewWithT(t).Expect(exprs[1]).To(buidertestingutils.BeExpr( /* language=PostgreSQL */ "DROP MATERIALIZED VIEW IF EXISTS v_user;"))
+		gomega.NewWithT(t).Expect(exprs[2]).To(buidertestingutils.BeExpr( /* language=PostgreSQL */ "CREATE MATERIALIZED VIEW v_user AS SELECT f_id,f_name FROM t;"))
+		gomega.NewWithT(t).Expect(exprs[3]).To(buidertestingutils.BeExpr( /* language=PostgreSQL */ "CREATE UNIQUE INDEX v_user_i_id ON v_user (f_id);"))
+
+		gomega.NewWithT(t).Expect(c.RefreshMaterializedView(materializedView, true)).
+			To(buidertestingutils.BeExpr( /* language=PostgreSQL */ "REFRESH MATERIALIZED VIEW CONCURRENTLY v_user;"))
+
+		changedView := builder.V("v_user", builder.Select(table.MustCols("F_id")).From(table))
+
+		exprs = c.viewDiff(changedView, &ViewSchema{VIEW_NAME: "v_user", VIEW_KIND: "v", VIEW_COMMENT: viewComment(view, "")})
+		gomega.NewWithT(t).Expect(exprs).To(gomega.HaveLen(3))
+		gomega.NewWithT(t).Expect(exprs[0]).To(buidertestingutils.BeExpr( /* language=PostgreSQL */ "DROP VIEW IF EXISTS v_user;"))
+		gomega.NewWithT(t).Expect(exprs[1]).To(buidertestingutils.BeExpr( /* language=PostgreSQL */ "CREATE OR REPLACE VIEW v_user AS SELECT f_id FROM t;"))
+
+		exprs = c.ownedBy("test").CreateOrReplaceView(view)
+		gomega.NewWithT(t).Expect(exprs[1]).To(buidertestingutils.BeExpr( /* language=PostgreSQL */ "COMMENT ON VIEW v_user IS '" + viewComment(view, "test") + "';"))
+		gomega.NewWithT(t).Expect(viewComment(view, "test")).To(gomega.HaveSuffix(" database:test"))
+	})
+
+	t.Run("ViewsToDrop", func(t *testing.T) {
+		d := sqlx.NewDatabase("test")
+		d.AddTable(table)
+
+		vUser := builder.V("v_user", builder.Select(table.MustCols("F_id")).From(table))
+		vUserName := builder.V("v_user_name", builder.Select(table.MustCols("F_name")).From(table))
+		vUserOfView := builder.V("v_user_of_view", builder.Select(nil).From(vUser))
+
+		d.AddView(vUser)
+		d.AddView(vUserName)
+		d.AddView(vUserOfView)
+		d.AddView(builder.V("v_changed", builder.Select(table.MustCols("F_id")).From(table)))
+
+		prevViews := map[string]*ViewSchema{
+			"v_user":         {VIEW_NAME: "v_user", VIEW_KIND: "v", VIEW_COMMENT: viewComment(vUser, "test")},
+			"v_user_name":    {VIEW_NAME: "v_user_name", VIEW_KIND: "v", VIEW_COMMENT: viewComment(vUserName, "test")},
+			"v_user_of_view": {VIEW_NAME: "v_user_of_view", VIEW_KIND: "v", VIEW_COMMENT: viewComment(vUserOfView, "test")},
+			"v_changed":      {VIEW_NAME: "v_changed", VIEW_KIND: "m", VIEW_COMMENT: "sqlx:changed"},
+			"v_removed":      {VIEW_NAME: "v_removed", VIEW_KIND: "v", VIEW_COMMENT: "sqlx:removed database:test"},
+			"v_of_other":     {VIEW_NAME: "v_of_other", VIEW_KIND: "v", VIEW_COMMENT: "sqlx:other database:other"},
+			"v_manual":       {VIEW_NAME: "v_manual", VIEW_KIND: "v", VIEW_COMMENT: ""},
+		}
+
+		dependencies := map[string][]string{
+			"v_user":         {"t"},
+			"v_user_of_view": {"v_user"},
+			"v_changed":      {"t"},
+			"v_manual":       {"t"},
+		}
+
+		names := func(list []*ViewSchema) []string {
+			viewNames := make([]string, len(list))
+			for i := range list {
+				viewNames[i] = list[i].VIEW_NAME
+			}
+			return viewNames
+		}
+
+		gomega.NewWithT(t).Expect(names(viewsToDrop(d, prevViews, dependencies, map[string]bool{}))).
+			To(gomega.Equal([]string{"v_changed", "v_removed"}))
+
+		gomega.NewWithT(t).Expect(names(viewsToDrop(d, prevViews, dependencies, map[string]bool{"t": true}))).
+			To(gomega.Equal([]string{"v_changed", "v_removed", "v_user_of_view", "v_user"}))
+
+		gomega.NewWithT(t).Expect(c.DropView(prevViewTable(prevViews["v_changed"], ""))).
+			To(buidertestingutils.BeExpr( /* language=PostgreSQL */ "DROP MATERIALIZED VIEW IF EXISTS v_changed;"))
+	})
+
+	t.Run("Routine", func(t *testing.T) {
+		fn := &builder.StoredFunction{Name: "fn_add_one", Def: "(v integer) RETURNS integer LANGUAGE sql", Body: "SELECT v + 1"}
+
+		exprs := c.CreateOrReplaceFunction(fn)
+		gomega.NewWithT(t).Expect(exprs).To(gomega.HaveLen(3))
+		gomega.NewWithT(t).Expect(exprs[0]).To(buidertestingutils.BeExpr( /* language=PostgreSQL */ "DROP FUNCTION IF EXISTS fn_add_one;"))
+		gomega.NewWithT(t).Expect(exprs[1]).To(buidertestingutils.BeExpr( /* language=PostgreSQL */ "CREATE FUNCTION fn_add_one(v integer) RETURNS integer LANGUAGE sql AS $$\nSELECT v + 1\n$$;"))
+		gomega.NewWithT(t).Expect(exprs[2]).To(buidertestingutils.BeExpr( /* language=PostgreSQL */ "COMMENT ON FUNCTION fn_add_one IS '" + fn.Version() + "';"))
+		gomega.NewWithT(t).Expect(c.DropFunction(fn.WithSchema("s"))).To(buidertestingutils.BeExpr( /* language=PostgreSQL */ "DROP FUNCTION IF EXISTS s.fn_add_one;"))
+
+		tableWithOnUpdate := builder.T("t_user",
+			builder.Col("f_name").Type("", ",size=128,default=''"),
+			builder.Col("f_updated_at").Type(int64(0), ",default='0',onupdate=extract(epoch from now())"),
+		)
+
+		d := sqlx.NewDatabase("test")
+		d.AddTable(tableWithOnUpdate)
+
+		functions, tables := c.routinesWithOnUpdate(d)
+		gomega.NewWithT(t).Expect(functions).To(gomega.HaveLen(1))
+		gomega.NewWithT(t).Expect(tables[0].Triggers).To(gomega.HaveLen(1))
+		gomega.NewWithT(t).Expect(tableWithOnUpdate.Triggers).To(gomega.HaveLen(0))
+
+		gomega.NewWithT(t).Expect(c.CreateOrReplaceFunction(functions[0])[1]).To(buidertestingutils.BeExpr( /* language=PostgreSQL */ `CREATE FUNCTION t_user_onupdate() RETURNS trigger LANGUAGE plpgsql AS $$
+BEGIN
+	IF NEW IS DISTINCT FROM OLD AND NEW.f_updated_at IS NOT DISTINCT FROM OLD.f_updated_at THEN
+		NEW.f_updated_at = extract(epoch from now());
+	END IF;
+	RETURN NEW;
+END
+$$;`))
+
+		exprs = c.CreateOrReplaceTrigger(tables[0], tables[0].Triggers[0])
+		gomega.NewWithT(t).Expect(exprs).To(gomega.HaveLen(3))
+		gomega.NewWithT(t).Expect(exprs[0]).To(buidertestingutils.BeExpr( /* language=PostgreSQL */ "DROP TRIGGER IF EXISTS t_user_onupdate ON t_user;"))
+		gomega.NewWithT(t).Expect(exprs[1]).To(buidertestingutils.BeExpr( /* language=PostgreSQL */ "CREATE TRIGGER t_user_onupdate BEFORE UPDATE ON t_user FOR EACH ROW EXECUTE PROCEDURE t_user_onupdate();"))
+		gomega.NewWithT(t).Expect(exprs[2]).To(buidertestingutils.BeExpr( /* language=PostgreSQL */ "COMMENT ON TRIGGER t_user_onupdate ON t_user IS '" + tables[0].Triggers[0].Version() + "';"))
+
+		gomega.NewWithT(t).Expect(builder.RoutinesDiff(c, "", functions, tables,
+			map[string]string{"t_user_onupdate": functions[0].Version()},
+			map[string]map[string]string{"t_user": {"t_user_onupdate": tables[0].Triggers[0].Version()}},
+		)).To(gomega.HaveLen(0))
+
+		// table not registered any more
+		exprs = builder.RoutinesDiff(c, "", nil, nil,
+			map[string]string{"t_user_onupdate": functions[0].Version()},
+			map[string]map[string]string{"t_user": {"t_user_onupdate": tables[0].Triggers[0].Version()}},
+		)
+		gomega.NewWithT(t).Expect(exprs).To(gomega.HaveLen(2))
+		gomega.NewWithT(t).Expect(exprs[0]).To(buidertestingutils.BeExpr( /* language=PostgreSQL */ "DROP TRIGGER IF EXISTS t_user_onupdate ON t_user;"))
+		gomega.NewWithT(t).Expect(exprs[1]).To(buidertestingutils.BeExpr( /* language=PostgreSQL */ "DROP FUNCTION IF EXISTS t_user_onupdate;"))
+
+		t.Run("owner marked in comment", func(t *testing.T) {
+			exprs := c.ownedBy("test").CreateOrReplaceFunction(fn)
+			gomega.NewWithT(t).Expect(exprs[2]).To(buidertestingutils.BeExpr( /* language=PostgreSQL */ "COMMENT ON FUNCTION fn_add_one IS '" + fn.Version() + " database:test';"))
+		})
+
+		t.Run("only routines owned", func(t *testing.T) {
+			prevFunctions, prevTriggers := ownedRoutineVersions(d, functions,
+				[]FunctionSchema{
+					{FUNCTION_NAME: "t_user_onupdate", FUNCTION_COMMENT: functions[0].Version()},
+					{FUNCTION_NAME: "fn_removed", FUNCTION_COMMENT: fn.Version() + " database:test"},
+					{FUNCTION_NAME: "fn_of_other", FUNCTION_COMMENT: fn.Version() + " database:other"},
+					{FUNCTION_NAME: "fn_unmarked", FUNCTION_COMMENT: fn.Version()},
+					{FUNCTION_NAME: "fn_unmanaged"},
+				},
+				[]TriggerSchema{
+					{TABLE_NAME: "t_user", TRIGGER_NAME: "t_user_onupdate", TRIGGER_COMMENT: tables[0].Triggers[0].Version()},
+					{TABLE_NAME: "t_order", TRIGGER_NAME: "t_order_onupdate", TRIGGER_COMMENT: tables[0].Triggers[0].Version()},
+				},
+			)
+
+			gomega.NewWithT(t).Expect(prevFunctions).To(gomega.Equal(map[string]string{
+				"t_user_onupdate": functions[0].Version(),
+				"fn_removed":      fn.Version(),
+			}))
+			gomega.NewWithT(t).Expect(prevTriggers).To(gomega.Equal(map[string]map[string]string{
+				"t_user": {"t_user_onupdate": tables[0].Triggers[0].Version()},
+			}))
+		})
+	})
+
+	t.Run("NativeEnumDataType", func(t *testing.T) {
+		tableWithEnum := builder.T("t",
+			builder.Col("f_protocol").Type(datatypes.NativeEnum[Protocol]{}, ",default='HTTP'"),
+		)
+
+		gomega.NewWithT(t).Expect(c.AddColumn(tableWithEnum.Col("f_protocol"))).
+			To(buidertestingutils.BeExpr( /* language=PostgreSQL */ "ALTER TABLE t ADD COLUMN f_protocol protocol NOT NULL DEFAULT 'HTTP'::protocol;"))
+
+		d := sqlx.NewDatabase("test")
+		d.AddTable(tableWithEnum)
+
+		exprs, err := c.nativeEnumTypesDiff(d, map[string][]string{})
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(exprs).To(gomega.HaveLen(1))
+		gomega.NewWithT(t).Expect(exprs[0]).
+			To(buidertestingutils.BeExpr( /* language=PostgreSQL */ "CREATE TYPE protocol AS ENUM ('UNKNOWN','HTTP','HTTPS');"))
+
+		exprs, err = c.nativeEnumTypesDiff(d, map[string][]string{"protocol": {"UNKNOWN", "HTTP"}})
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(exprs).To(gomega.HaveLen(1))
+		gomega.NewWithT(t).Expect(exprs[0]).
+			To(buidertestingutils.BeExpr( /* language=PostgreSQL */ "ALTER TYPE protocol ADD VALUE IF NOT EXISTS 'HTTPS';"))
+
+		t.Run("in schema", func(t *testing.T) {
+			dInSchema := d.WithSchema("a")
+
+			exprs, err := c.nativeEnumTypesDiff(dInSchema, map[string][]string{})
+			gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+			gomega.NewWithT(t).Expect(exprs[0]).
+				To(buidertestingutils.BeExpr( /* language=PostgreSQL */ "CREATE TYPE a.protocol AS ENUM ('UNKNOWN','HTTP','HTTPS');"))
+
+			exprs, err = c.nativeEnumTypesDiff(dInSchema, map[string][]string{"protocol": {"UNKNOWN", "HTTP"}})
+			gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+			gomega.NewWithT(t).Expect(exprs[0]).
+				To(buidertestingutils.BeExpr( /* language=PostgreSQL */ "ALTER TYPE a.protocol ADD VALUE IF NOT EXISTS 'HTTPS';"))
+
+			gomega.NewWithT(t).Expect(c.AddColumn(dInSchema.Table("t").Col("f_protocol"))).
+				To(buidertestingutils.BeExpr( /* language=PostgreSQL */ "ALTER TABLE a.t ADD COLUMN f_protocol a.protocol NOT NULL DEFAULT 'HTTP'::a.protocol;"))
+		})
+
+		t.Run("conflict values of same type name", func(t *testing.T) {
+			dConflict := sqlx.NewDatabase("test")
+			dConflict.AddTable(tableWithEnum)
+			dConflict.AddTable(builder.T("t_other",
+				builder.Col("f_protocol").Type(datatypes.NativeEnum[OtherProtocol]{}, ""),
+			))
+
+			_, err := c.nativeEnumTypesDiff(dConflict, map[string][]string{})
+			gomega.NewWithT(t).Expect(err).NotTo(gomega.BeNil())
+		})
+	})
+
 	for name, c := range cases {
 		t.Run(name, func(t *testing.T) {
 			gomega.NewWithT(t).Expect(c.expr).To(buidertestingutils.BeExpr(c.expr.Ex(context.Background()).Query()))
@@ -111,3 +404,68 @@ func (Point) ValueEx() string {
 func (p Point) Value() (driver.Value, error) {
 	return fmt.Sprintf("POINT(%v %v)", p.X, p.Y), nil
 }
+
+type Protocol int
+
+const (
+	PROTOCOL_UNKNOWN Protocol = iota
+	PROTOCOL__HTTP
+	PROTOCOL__HTTPS
+)
+
+func (Protocol) TypeName() string {
+	return "example.Protocol"
+}
+
+func (v Protocol) Int() int {
+	return int(v)
+}
+
+func (v Protocol) String() string {
+	switch v {
+	case PROTOCOL__HTTP:
+		return "HTTP"
+	case PROTOCOL__HTTPS:
+		return "HTTPS"
+	}
+	return "UNKNOWN"
+}
+
+func (v Protocol) Label() string {
+	return v.String()
+}
+
+func (Protocol) ConstValues() []enumeration.IntStringerEnum {
+	return []enumeration.IntStringerEnum{PROTOCOL__HTTP, PROTOCOL__HTTPS}
+}
+
+// OtherProtocol same short type name as Protocol, but different values
+type OtherProtocol int
+
+const (
+	OTHER_PROTOCOL_UNKNOWN OtherProtocol = iota
+	OTHER_PROTOCOL__TCP
+)
+
+func (OtherProtocol) TypeName() string {
+	return "other.Protocol"
+}
+
+func (v OtherProtocol) Int() int {
+	return int(v)
+}
+
+func (v OtherProtocol) String() string {
+	if v == OTHER_PROTOCOL__TCP {
+		return "TCP"
+	}
+	return "UNKNOWN"
+}
+
+func (v OtherProtocol) Label() string {
+	return v.String()
+}
+
+func (OtherProtocol) ConstValues() []enumeration.IntStringerEnum {
+	return []enumeration.IntStringerEnum{OTHER_PROTOCOL__TCP}
+}
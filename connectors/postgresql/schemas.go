@@ -19,7 +19,9 @@ func toInterfaces(list ...string) []interface{} {
 
 var reUsing = regexp.MustCompile(`USING ([^ ]+)`)
 
-func dbFromInformationSchema(db sqlx.DBExecutor) (*sqlx.Database, error) {
+// DBFromInformationSchema read tables of db.D() from information schema,
+// all tables of the database will be read when no table registered
+func DBFromInformationSchema(db sqlx.DBExecutor) (*sqlx.Database, error) {
 	d := db.D()
 
 	dbName := d.Name
@@ -36,6 +38,18 @@ func dbFromInformationSchema(db sqlx.DBExecutor) (*sqlx.Database, error) {
 		tableSchema = d.Schema
 	}
 
+	// views and materialized views have columns too, only base tables should be read
+	if len(tableNames) == 0 {
+		names, err := baseTableNames(db, tableSchema)
+		if err != nil {
+			return nil, err
+		}
+		if len(names) == 0 {
+			return d, nil
+		}
+		tableNames = names
+	}
+
 	stmt := builder.Select(tableColumnSchema.Columns.Clone()).From(tableColumnSchema,
 		builder.Where(
 			builder.And(
@@ -50,6 +64,8 @@ func dbFromInformationSchema(db sqlx.DBExecutor) (*sqlx.Database, error) {
 		return nil, err
 	}
 
+	hasGeometryColumns := false
+
 	for i := range columnSchemaList {
 		columnSchema := columnSchemaList[i]
 
@@ -59,9 +75,62 @@ func dbFromInformationSchema(db sqlx.DBExecutor) (*sqlx.Database, error) {
 			d.AddTable(table)
 		}
 
+		if columnSchema.UDT_NAME == "geometry" {
+			hasGeometryColumns = true
+		}
+
 		table.AddCol(colFromColumnSchema(&columnSchema))
 	}
 
+	if len(columnSchemaList) > 0 {
+		columnDescriptionList := make([]ColumnDescriptionSchema, 0)
+
+		if err := db.QueryExprAndScan(columnDescriptionSchemaQuery(tableSchema), &columnDescriptionList); err != nil {
+			return nil, err
+		}
+
+		for _, columnDescription := range columnDescriptionList {
+			if table := d.Table(columnDescription.TABLE_NAME); table != nil {
+				if col := table.Col(columnDescription.COLUMN_NAME); col != nil {
+					lines := strings.Split(columnDescription.DESCRIPTION, "\n")
+					col.Comment = lines[0]
+					col.Description = lines
+				}
+			}
+		}
+	}
+
+	// information_schema not keep type modifiers of PostGIS geometry
+	if hasGeometryColumns {
+		tableGeometryColumnSchema := SchemaDatabase.T(&GeometryColumnSchema{})
+		geometryColumnSchemaList := make([]GeometryColumnSchema, 0)
+
+		err := db.QueryExprAndScan(
+			builder.Select(tableGeometryColumnSchema.Columns.Clone()).
+				From(
+					tableGeometryColumnSchema,
+					builder.Where(
+						builder.And(
+							tableGeometryColumnSchema.F("TABLE_SCHEMA").Eq(tableSchema),
+							tableGeometryColumnSchema.F("TABLE_NAME").In(toInterfaces(tableNames...)...),
+						),
+					),
+				),
+			&geometryColumnSchemaList,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, geometryColumnSchema := range geometryColumnSchemaList {
+			if table := d.Table(geometryColumnSchema.TABLE_NAME); table != nil {
+				if col := table.Col(geometryColumnSchema.COLUMN_NAME); col != nil {
+					col.DataType = geometryColumnSchema.DataType()
+				}
+			}
+		}
+	}
+
 	if tableColumnSchema.Columns.Len() != 0 {
 		tableIndexSchema := SchemaDatabase.T(&IndexSchema{})
 
@@ -87,9 +156,13 @@ func dbFromInformationSchema(db sqlx.DBExecutor) (*sqlx.Database, error) {
 
 		for _, indexSchema := range indexList {
 			table := d.Table(indexSchema.TABLE_NAME)
+			if table == nil {
+				continue
+			}
 
 			key := &builder.Key{}
-			key.Name = strings.ToLower(indexSchema.INDEX_NAME[len(table.Name)+1:])
+			// index name created by sqlx is prefixed with table name
+			key.Name = strings.ToLower(strings.TrimPrefix(indexSchema.INDEX_NAME, table.Name+"_"))
 			key.Method = strings.ToUpper(reUsing.FindString(indexSchema.INDEX_DEF)[6:])
 			key.IsUnique = strings.Contains(indexSchema.INDEX_DEF, "UNIQUE")
 
@@ -102,11 +175,41 @@ func dbFromInformationSchema(db sqlx.DBExecutor) (*sqlx.Database, error) {
 	return d, nil
 }
 
+func baseTableNames(db sqlx.DBExecutor, tableSchema string) ([]string, error) {
+	tableTableSchema := SchemaDatabase.T(&TableSchema{}).WithSchema("information_schema")
+	tableSchemaList := make([]TableSchema, 0)
+
+	err := db.QueryExprAndScan(
+		builder.Select(tableTableSchema.Columns.Clone()).
+			From(
+				tableTableSchema,
+				builder.Where(
+					builder.And(
+						tableTableSchema.F("TABLE_SCHEMA").Eq(tableSchema),
+						tableTableSchema.F("TABLE_TYPE").Eq("BASE TABLE"),
+					),
+				),
+			),
+		&tableSchemaList,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	tableNames := make([]string, len(tableSchemaList))
+	for i := range tableSchemaList {
+		tableNames[i] = tableSchemaList[i].TABLE_NAME
+	}
+	return tableNames, nil
+}
+
 var SchemaDatabase = sqlx.NewDatabase("INFORMATION_SCHEMA")
 
 func init() {
+	SchemaDatabase.Register(&TableSchema{})
 	SchemaDatabase.Register(&ColumnSchema{})
 	SchemaDatabase.Register(&IndexSchema{})
+	SchemaDatabase.Register(&GeometryColumnSchema{})
 }
 
 func colFromColumnSchema(columnSchema *ColumnSchema) *builder.Column {
@@ -135,6 +238,13 @@ func colFromColumnSchema(columnSchema *ColumnSchema) *builder.Column {
 		}
 	}
 
+	switch dataType {
+	case "ARRAY":
+		dataType = arrayDataTypeFromUdtName(columnSchema.UDT_NAME)
+	case "USER-DEFINED":
+		dataType = columnSchema.UDT_NAME
+	}
+
 	col.DataType = dataType
 
 	// numeric type
@@ -152,11 +262,44 @@ func colFromColumnSchema(columnSchema *ColumnSchema) *builder.Column {
 	return col
 }
 
+var udtNameAliases = map[string]string{
+	"int2":        "smallint",
+	"int4":        "integer",
+	"int8":        "bigint",
+	"float4":      "real",
+	"float8":      "double precision",
+	"bool":        "boolean",
+	"varchar":     "character varying",
+	"bpchar":      "character",
+	"timestamp":   "timestamp without time zone",
+	"timestamptz": "timestamp with time zone",
+}
+
+// udt_name of array is the element type name with prefix `_`, like _int8 for bigint[]
+func arrayDataTypeFromUdtName(udtName string) string {
+	elemType := strings.TrimPrefix(udtName, "_")
+	if alias, ok := udtNameAliases[elemType]; ok {
+		elemType = alias
+	}
+	return elemType + "[]"
+}
+
+type TableSchema struct {
+	TABLE_SCHEMA string `db:"table_schema"`
+	TABLE_NAME   string `db:"table_name"`
+	TABLE_TYPE   string `db:"table_type"`
+}
+
+func (TableSchema) TableName() string {
+	return "tables"
+}
+
 type ColumnSchema struct {
 	TABLE_SCHEMA             string `db:"table_schema"`
 	TABLE_NAME               string `db:"table_name"`
 	COLUMN_NAME              string `db:"column_name"`
 	DATA_TYPE                string `db:"data_type"`
+	UDT_NAME                 string `db:"udt_name"`
 	IS_NULLABLE              string `db:"is_nullable"`
 	COLUMN_DEFAULT           string `db:"column_default"`
 	CHARACTER_MAXIMUM_LENGTH uint64 `db:"character_maximum_length"`
@@ -178,3 +321,277 @@ type IndexSchema struct {
 func (IndexSchema) TableName() string {
 	return "pg_indexes"
 }
+
+// GeometryColumnSchema from view geometry_columns of PostGIS
+type GeometryColumnSchema struct {
+	TABLE_SCHEMA string `db:"f_table_schema"`
+	TABLE_NAME   string `db:"f_table_name"`
+	COLUMN_NAME  string `db:"f_geometry_column"`
+	TYPE         string `db:"type"`
+	SRID         int    `db:"srid"`
+}
+
+func (GeometryColumnSchema) TableName() string {
+	return "geometry_columns"
+}
+
+var geometryTypeNames = map[string]string{
+	"POINT":              "Point",
+	"LINESTRING":         "LineString",
+	"POLYGON":            "Polygon",
+	"MULTIPOINT":         "MultiPoint",
+	"MULTILINESTRING":    "MultiLineString",
+	"MULTIPOLYGON":       "MultiPolygon",
+	"GEOMETRYCOLLECTION": "GeometryCollection",
+}
+
+// DataType as geometry(Point,4326)
+func (s GeometryColumnSchema) DataType() string {
+	typeName, ok := geometryTypeNames[strings.ToUpper(s.TYPE)]
+	if !ok {
+		if s.SRID == 0 {
+			return "geometry"
+		}
+		typeName = "Geometry"
+	}
+	return fmt.Sprintf("geometry(%s,%d)", typeName, s.SRID)
+}
+
+// ColumnDescriptionSchema comments of columns from pg_description
+type ColumnDescriptionSchema struct {
+	TABLE_NAME  string `db:"table_name"`
+	COLUMN_NAME string `db:"column_name"`
+	DESCRIPTION string `db:"description"`
+}
+
+func columnDescriptionSchemaQuery(tableSchema string) builder.SqlExpr {
+	return builder.Expr( /* language=PostgreSQL */ `SELECT c.relname AS table_name, a.attname AS column_name, d.description AS description
+FROM pg_catalog.pg_description d
+JOIN pg_catalog.pg_class c ON c.oid = d.objoid
+JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+JOIN pg_catalog.pg_attribute a ON a.attrelid = c.oid AND a.attnum = d.objsubid
+WHERE n.nspname = ? AND d.objsubid > 0`, tableSchema)
+}
+
+// EnumTypeSchema labels of native enum types from pg_enum
+type EnumTypeSchema struct {
+	TYPE_NAME  string `db:"type_name"`
+	ENUM_LABEL string `db:"enum_label"`
+}
+
+// enum types in schema, native enum types created in the schema of tables
+func enumTypeSchemaQuery(schema string) builder.SqlExpr {
+	return builder.Expr( /* language=PostgreSQL */ `SELECT t.typname AS type_name, e.enumlabel AS enum_label
+FROM pg_catalog.pg_type t
+JOIN pg_catalog.pg_enum e ON e.enumtypid = t.oid
+JOIN pg_catalog.pg_namespace n ON n.oid = t.typnamespace
+WHERE n.nspname = ?
+ORDER BY t.typname, e.enumsortorder`, schemaOrDefault(schema))
+}
+
+// nativeEnumTypesFromDB values of native enum types in schema by type name
+func nativeEnumTypesFromDB(db sqlx.DBExecutor, schema string) (map[string][]string, error) {
+	enumTypeList := make([]EnumTypeSchema, 0)
+
+	if err := db.QueryExprAndScan(enumTypeSchemaQuery(schema), &enumTypeList); err != nil {
+		return nil, err
+	}
+
+	enumTypes := map[string][]string{}
+
+	for _, enumType := range enumTypeList {
+		enumTypes[enumType.TYPE_NAME] = append(enumTypes[enumType.TYPE_NAME], enumType.ENUM_LABEL)
+	}
+
+	return enumTypes, nil
+}
+
+func schemaOrDefault(schema string) string {
+	if schema == "" {
+		return "public"
+	}
+	return schema
+}
+
+func partitionSchemaQuery(t *builder.Table) builder.SqlExpr {
+	return builder.Expr( /* language=PostgreSQL */ `SELECT c.relname AS partition_name
+FROM pg_catalog.pg_inherits i
+JOIN pg_catalog.pg_class c ON c.oid = i.inhrelid
+JOIN pg_catalog.pg_class p ON p.oid = i.inhparent
+JOIN pg_catalog.pg_namespace n ON n.oid = p.relnamespace
+WHERE p.relname = ? AND n.nspname = ?
+ORDER BY c.relname`, t.Name, schemaOrDefault(t.Schema))
+}
+
+type PartitionSchema struct {
+	PARTITION_NAME string `db:"partition_name"`
+}
+
+// partitionNamesFromDB names of partitions of partitioned table
+func partitionNamesFromDB(db sqlx.DBExecutor, t *builder.Table) ([]string, error) {
+	partitionSchemaList := make([]PartitionSchema, 0)
+
+	if err := db.QueryExprAndScan(partitionSchemaQuery(t), &partitionSchemaList); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(partitionSchemaList))
+	for _, partitionSchema := range partitionSchemaList {
+		names = append(names, partitionSchema.PARTITION_NAME)
+	}
+
+	return names, nil
+}
+
+func viewSchemaQuery(schema string) builder.SqlExpr {
+	return builder.Expr( /* language=PostgreSQL */ `SELECT c.relname AS view_name, c.relkind::text AS view_kind, COALESCE(obj_description(c.oid, 'pg_class'), '') AS view_comment
+FROM pg_catalog.pg_class c
+JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+WHERE c.relkind IN ('v', 'm') AND n.nspname = ?`, schemaOrDefault(schema))
+}
+
+type ViewSchema struct {
+	VIEW_NAME    string `db:"view_name"`
+	VIEW_KIND    string `db:"view_kind"`
+	VIEW_COMMENT string `db:"view_comment"`
+}
+
+func (v *ViewSchema) IsMaterialized() bool {
+	return v.VIEW_KIND == "m"
+}
+
+// viewSchemasFromDB views and materialized views in schema by name
+func viewSchemasFromDB(db sqlx.DBExecutor, schema string) (map[string]*ViewSchema, error) {
+	viewSchemaList := make([]*ViewSchema, 0)
+
+	if err := db.QueryExprAndScan(viewSchemaQuery(schema), &viewSchemaList); err != nil {
+		return nil, err
+	}
+
+	viewSchemas := map[string]*ViewSchema{}
+	for _, viewSchema := range viewSchemaList {
+		viewSchemas[viewSchema.VIEW_NAME] = viewSchema
+	}
+
+	return viewSchemas, nil
+}
+
+func viewDependencySchemaQuery(schema string) builder.SqlExpr {
+	return builder.Expr( /* language=PostgreSQL */ `SELECT DISTINCT v.relname AS view_name, t.relname AS table_name
+FROM pg_catalog.pg_depend d
+JOIN pg_catalog.pg_rewrite r ON r.oid = d.objid
+JOIN pg_catalog.pg_class v ON v.oid = r.ev_class
+JOIN pg_catalog.pg_class t ON t.oid = d.refobjid
+JOIN pg_catalog.pg_namespace n ON n.oid = v.relnamespace
+WHERE d.classid = 'pg_catalog.pg_rewrite'::regclass AND d.refclassid = 'pg_catalog.pg_class'::regclass
+AND v.relkind IN ('v', 'm') AND v.oid <> t.oid AND n.nspname = ?`, schemaOrDefault(schema))
+}
+
+type ViewDependencySchema struct {
+	VIEW_NAME  string `db:"view_name"`
+	TABLE_NAME string `db:"table_name"`
+}
+
+// viewDependenciesFromDB names of tables or views which views depend on, by name of view
+func viewDependenciesFromDB(db sqlx.DBExecutor, schema string) (map[string][]string, error) {
+	viewDependencySchemaList := make([]ViewDependencySchema, 0)
+
+	if err := db.QueryExprAndScan(viewDependencySchemaQuery(schema), &viewDependencySchemaList); err != nil {
+		return nil, err
+	}
+
+	dependencies := map[string][]string{}
+	for _, viewDependency := range viewDependencySchemaList {
+		dependencies[viewDependency.VIEW_NAME] = append(dependencies[viewDependency.VIEW_NAME], viewDependency.TABLE_NAME)
+	}
+
+	return dependencies, nil
+}
+
+func functionSchemaQuery(schema string) builder.SqlExpr {
+	return builder.Expr( /* language=PostgreSQL */ `SELECT p.proname AS function_name, COALESCE(obj_description(p.oid, 'pg_proc'), '') AS function_comment
+FROM pg_catalog.pg_proc p
+JOIN pg_catalog.pg_namespace n ON n.oid = p.pronamespace
+WHERE n.nspname = ?`, schemaOrDefault(schema))
+}
+
+type FunctionSchema struct {
+	FUNCTION_NAME    string `db:"function_name"`
+	FUNCTION_COMMENT string `db:"function_comment"`
+}
+
+func triggerSchemaQuery(schema string) builder.SqlExpr {
+	return builder.Expr( /* language=PostgreSQL */ `SELECT t.tgname AS trigger_name, c.relname AS table_name, COALESCE(obj_description(t.oid, 'pg_trigger'), '') AS trigger_comment
+FROM pg_catalog.pg_trigger t
+JOIN pg_catalog.pg_class c ON c.oid = t.tgrelid
+JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+WHERE NOT t.tgisinternal AND n.nspname = ?`, schemaOrDefault(schema))
+}
+
+type TriggerSchema struct {
+	TRIGGER_NAME    string `db:"trigger_name"`
+	TABLE_NAME      string `db:"table_name"`
+	TRIGGER_COMMENT string `db:"trigger_comment"`
+}
+
+// routineVersionsFromDB versions of managed functions owned by d by name, and triggers of tables of d by table name and name.
+// functions is for functions created before owner marked, which are treated as owned when still registered.
+func routineVersionsFromDB(db sqlx.DBExecutor, d *sqlx.Database, functions []*builder.StoredFunction) (map[string]string, map[string]map[string]string, error) {
+	functionSchemaList := make([]FunctionSchema, 0)
+
+	if err := db.QueryExprAndScan(functionSchemaQuery(d.Schema), &functionSchemaList); err != nil {
+		return nil, nil, err
+	}
+
+	triggerSchemaList := make([]TriggerSchema, 0)
+
+	if err := db.QueryExprAndScan(triggerSchemaQuery(d.Schema), &triggerSchemaList); err != nil {
+		return nil, nil, err
+	}
+
+	prevFunctions, prevTriggers := ownedRoutineVersions(d, functions, functionSchemaList, triggerSchemaList)
+	return prevFunctions, prevTriggers, nil
+}
+
+// ownedRoutineVersions picks versions of routines owned by d,
+// other databases may share the schema, so their functions and triggers of their tables should be kept.
+func ownedRoutineVersions(d *sqlx.Database, functions []*builder.StoredFunction, functionSchemaList []FunctionSchema, triggerSchemaList []TriggerSchema) (map[string]string, map[string]map[string]string) {
+	registered := map[string]bool{}
+	for _, fn := range functions {
+		registered[fn.Name] = true
+	}
+
+	prevFunctions := map[string]string{}
+
+	for _, functionSchema := range functionSchemaList {
+		version := builder.ParseRoutineVersion(functionSchema.FUNCTION_COMMENT)
+		if version == "" {
+			continue
+		}
+
+		switch ownerOfComment(functionSchema.FUNCTION_COMMENT) {
+		case d.Name:
+			prevFunctions[functionSchema.FUNCTION_NAME] = version
+		case "":
+			if registered[functionSchema.FUNCTION_NAME] {
+				prevFunctions[functionSchema.FUNCTION_NAME] = version
+			}
+		}
+	}
+
+	triggers := map[string]map[string]string{}
+
+	for _, triggerSchema := range triggerSchemaList {
+		if d.Tables.Table(triggerSchema.TABLE_NAME) == nil {
+			continue
+		}
+		if version := builder.ParseRoutineVersion(triggerSchema.TRIGGER_COMMENT); version != "" {
+			if triggers[triggerSchema.TABLE_NAME] == nil {
+				triggers[triggerSchema.TABLE_NAME] = map[string]string{}
+			}
+			triggers[triggerSchema.TABLE_NAME][triggerSchema.TRIGGER_NAME] = version
+		}
+	}
+
+	return prevFunctions, triggers
+}
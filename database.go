@@ -20,6 +20,7 @@ func NewDatabase(name string) *Database {
 	return &Database{
 		Name:   name,
 		Tables: builder.Tables{},
+		Views:  builder.Tables{},
 	}
 }
 
@@ -27,6 +28,10 @@ type Database struct {
 	Name   string
 	Schema string
 	Tables builder.Tables
+	// Views created after tables by registered order, so view could depend on views registered before
+	Views builder.Tables
+	// Functions created after tables, and before triggers
+	Functions []*builder.StoredFunction
 }
 
 func (database Database) WithSchema(schema string) *Database {
@@ -40,6 +45,21 @@ func (database Database) WithSchema(schema string) *Database {
 
 	database.Tables = tables
 
+	views := builder.Tables{}
+
+	database.Views.Range(func(view *builder.Table, idx int) {
+		views.Add(view.WithSchema(database.Schema))
+	})
+
+	database.Views = views
+
+	functions := make([]*builder.StoredFunction, len(database.Functions))
+	for i := range database.Functions {
+		functions[i] = database.Functions[i].WithSchema(database.Schema)
+	}
+
+	database.Functions = functions
+
 	return &database
 }
 
@@ -66,13 +86,89 @@ func (database *Database) AddTable(table *builder.Table) {
 	database.Tables.Add(table)
 }
 
+// Register registers table of model,
+// history table will be registered too when model implements builder.WithHistoryTable
 func (database *Database) Register(model builder.Model) *builder.Table {
 	table := builder.TableFromModel(model)
 	table.Schema = database.Schema
 	database.AddTable(table)
+	if withHistoryTable, ok := model.(builder.WithHistoryTable); ok {
+		database.AddTable(builder.HistoryTable(table, withHistoryTable.HistoryTableName()))
+	}
 	return table
 }
 
+// RegisterView registers view by query, columns could be defined by tableDefinitions
+func (database *Database) RegisterView(viewName string, query builder.SqlExpr, tableDefinitions ...builder.TableDefinition) *builder.Table {
+	view := builder.V(viewName, query, tableDefinitions...)
+	view.Schema = database.Schema
+	database.AddView(view)
+	return view
+}
+
+// RegisterMaterializedView registers materialized view by query, only for postgres
+func (database *Database) RegisterMaterializedView(viewName string, query builder.SqlExpr, tableDefinitions ...builder.TableDefinition) *builder.Table {
+	view := builder.MaterializedV(viewName, query, tableDefinitions...)
+	view.Schema = database.Schema
+	database.AddView(view)
+	return view
+}
+
+func (database *Database) AddView(view *builder.Table) {
+	database.Views.Add(view)
+}
+
+func (database *Database) View(viewName string) *builder.Table {
+	return database.Views.Table(viewName)
+}
+
+// RegisterFunction registers stored function, or replaces function with same name
+func (database *Database) RegisterFunction(name string, def string, body string) *builder.StoredFunction {
+	fn := &builder.StoredFunction{
+		Schema: database.Schema,
+		Name:   name,
+		Def:    def,
+		Body:   body,
+	}
+
+	for i := range database.Functions {
+		if database.Functions[i].Name == name {
+			database.Functions[i] = fn
+			return fn
+		}
+	}
+
+	database.Functions = append(database.Functions, fn)
+	return fn
+}
+
+func (database *Database) Function(name string) *builder.StoredFunction {
+	for _, fn := range database.Functions {
+		if fn.Name == name {
+			return fn
+		}
+	}
+	return nil
+}
+
+// RegisterTrigger registers trigger of registered table
+func (database *Database) RegisterTrigger(tableName string, name string, when string, body string) *builder.Trigger {
+	table := database.Table(tableName)
+	if table == nil {
+		panic(fmt.Errorf("table %s is not registered", tableName))
+	}
+
+	trigger := &builder.Trigger{
+		Name: name,
+		When: when,
+		Body: body,
+	}
+
+	table.AddTrigger(trigger)
+
+	return trigger
+}
+
 func (database *Database) Table(tableName string) *builder.Table {
 	return database.Tables.Table(tableName)
 }
@@ -86,5 +182,9 @@ func (database *Database) T(model builder.Model) *builder.Table {
 		return t
 	}
 
-	return database.Table(model.TableName())
+	if t := database.Table(model.TableName()); t != nil {
+		return t
+	}
+
+	return database.View(model.TableName())
 }
@@ -436,3 +436,282 @@ func TestSelect(t *testing.T) {
 		})
 	}
 }
+
+type UserName struct {
+	ID   uint64 `db:"f_id"`
+	Name string `db:"f_name"`
+}
+
+func (UserName) TableName() string {
+	return "v_user_name"
+}
+
+func TestView(t *testing.T) {
+	dbTest := sqlx.NewDatabase("test_for_view")
+
+	for _, connector := range []driver.Connector{
+		mysqlConnector,
+		postgresConnector,
+	} {
+		t.Run("", func(t *testing.T) {
+			db := dbTest.OpenDB(connector)
+			table := dbTest.Register(&User{})
+			view := dbTest.RegisterView("v_user_name", builder.Select(table.MustFields("ID", "Name")).From(table))
+			builder.ScanDefToTable(view, &UserName{})
+
+			dropAll := func() {
+				db.Views.Range(func(v *builder.Table, idx int) {
+					_, _ = db.ExecExpr(db.Dialect().(builder.ViewDialect).DropView(v))
+				})
+				db.Tables.Range(func(tab *builder.Table, idx int) {
+					_, _ = db.ExecExpr(db.Dialect().DropTable(tab))
+				})
+			}
+
+			dropAll()
+			defer dropAll()
+
+			err := migration.Migrate(db, nil)
+			NewWithT(t).Expect(err).To(BeNil())
+
+			user := User{Name: uuid.New().String(), Gender: GenderMale}
+			_, err = db.ExecExpr(sqlx.InsertToDB(db, &user, nil))
+			NewWithT(t).Expect(err).To(BeNil())
+
+			t.Run("select from view", func(t *testing.T) {
+				userNames := make([]UserName, 0)
+				err := db.QueryExprAndScan(
+					builder.Select(nil).From(db.T(&UserName{}), builder.Where(view.F("Name").Eq(user.Name))),
+					&userNames,
+				)
+				NewWithT(t).Expect(err).To(BeNil())
+				NewWithT(t).Expect(userNames).To(HaveLen(1))
+			})
+
+			t.Run("migrate again", func(t *testing.T) {
+				err := migration.Migrate(db, nil)
+				NewWithT(t).Expect(err).To(BeNil())
+			})
+		})
+	}
+}
+
+type Org struct {
+	ID   uint64 `db:"f_id,autoincrement"`
+	Name string `db:"f_name,size=255,default=''"`
+}
+
+func (Org) TableName() string {
+	return "t_org"
+}
+
+func (Org) PrimaryKey() []string {
+	return []string{"ID"}
+}
+
+type OrgMember struct {
+	ID        uint64              `db:"f_id,autoincrement"`
+	OrgID     uint64              `db:"f_org_id"`
+	UserID    uint64              `db:"f_user_id"`
+	DeletedAt datatypes.Timestamp `db:"f_deleted_at,default='0'"`
+}
+
+func (OrgMember) TableName() string {
+	return "t_org_member"
+}
+
+func (OrgMember) PrimaryKey() []string {
+	return []string{"ID"}
+}
+
+func (OrgMember) ColRelations() map[string][]string {
+	return map[string][]string{
+		"OrgID":  {"Org", "ID"},
+		"UserID": {"User", "ID"},
+	}
+}
+
+type OrgWithMembers struct {
+	Org
+	Members []OrgMember
+}
+
+type OrgMemberWithOrgAndUser struct {
+	OrgMember
+	Org  *Org
+	User *User
+}
+
+func TestPreload(t *testing.T) {
+	dbTest := sqlx.NewDatabase("test_for_preload")
+
+	for _, connector := range []driver.Connector{
+		mysqlConnector,
+		postgresConnector,
+	} {
+		t.Run("", func(t *testing.T) {
+			db := dbTest.OpenDB(connector)
+			dbTest.Register(&User{})
+			dbTest.Register(&Org{})
+			dbTest.Register(&OrgMember{})
+
+			dropAll := func() {
+				db.Tables.Range(func(tab *builder.Table, idx int) {
+					_, _ = db.ExecExpr(db.Dialect().DropTable(tab))
+				})
+			}
+
+			dropAll()
+			defer dropAll()
+
+			err := migration.Migrate(db, nil)
+			NewWithT(t).Expect(err).To(BeNil())
+
+			user := User{Name: uuid.New().String(), Gender: GenderMale}
+			_, err = db.ExecExpr(sqlx.InsertToDB(db, &user, nil))
+			NewWithT(t).Expect(err).To(BeNil())
+			NewWithT(t).Expect(db.QueryExprAndScan(builder.Select(nil).From(db.T(&user), builder.Where(db.T(&user).F("Name").Eq(user.Name))), &user)).To(BeNil())
+
+			orgs := []OrgWithMembers{{Org: Org{Name: "a"}}, {Org: Org{Name: "b"}}}
+
+			for i := range orgs {
+				_, err := db.ExecExpr(sqlx.InsertToDB(db, &orgs[i].Org, nil))
+				NewWithT(t).Expect(err).To(BeNil())
+			}
+
+			NewWithT(t).Expect(db.QueryExprAndScan(builder.Select(nil).From(db.T(&Org{}), builder.OrderBy(builder.AscOrder(db.T(&Org{}).F("ID")))), &orgs)).To(BeNil())
+
+			for i := 0; i < 3; i++ {
+				_, err := db.ExecExpr(sqlx.InsertToDB(db, &OrgMember{OrgID: orgs[0].ID, UserID: user.ID}, nil))
+				NewWithT(t).Expect(err).To(BeNil())
+			}
+
+			t.Run("has many", func(t *testing.T) {
+				err := sqlx.Preload(db, &orgs, "Members")
+				NewWithT(t).Expect(err).To(BeNil())
+				NewWithT(t).Expect(orgs[0].Members).To(HaveLen(3))
+				NewWithT(t).Expect(orgs[1].Members).To(HaveLen(0))
+			})
+
+			t.Run("belongs to", func(t *testing.T) {
+				members := make([]*OrgMemberWithOrgAndUser, 0)
+				err := db.QueryExprAndScan(builder.Select(nil).From(db.T(&OrgMember{})), &members)
+				NewWithT(t).Expect(err).To(BeNil())
+
+				err = sqlx.Preload(db, &members, "Org", "User")
+				NewWithT(t).Expect(err).To(BeNil())
+				NewWithT(t).Expect(members).To(HaveLen(3))
+				NewWithT(t).Expect(members[0].Org.Name).To(Equal("a"))
+				NewWithT(t).Expect(members[0].User.Name).To(Equal(user.Name))
+			})
+
+			t.Run("skip soft deleted", func(t *testing.T) {
+				table := db.T(&OrgMember{})
+
+				_, err := db.ExecExpr(
+					builder.Update(table).
+						Set(table.AssignmentsByFieldValues(builder.FieldValues{"DeletedAt": datatypes.Timestamp(time.Now())})...).
+						Where(table.F("ID").Eq(orgs[0].Members[0].ID)),
+				)
+				NewWithT(t).Expect(err).To(BeNil())
+
+				err = sqlx.Preload(db, &orgs, "Members")
+				NewWithT(t).Expect(err).To(BeNil())
+				NewWithT(t).Expect(orgs[0].Members).To(HaveLen(2))
+			})
+		})
+	}
+}
+
+func TestPreloadWithUnregisteredModel(t *testing.T) {
+	dbTest := sqlx.NewDatabase("test_for_preload_unregistered")
+	dbTest.Register(&OrgMember{})
+	dbTest.Register(&User{})
+
+	db := dbTest.OpenDB(postgresConnector)
+
+	t.Run("model of target", func(t *testing.T) {
+		orgs := []OrgWithMembers{{Org: Org{ID: 1}}}
+		err := sqlx.Preload(db, &orgs, "Members")
+		NewWithT(t).Expect(err).NotTo(BeNil())
+		NewWithT(t).Expect(err.Error()).To(ContainSubstring("is not registered"))
+	})
+
+	t.Run("model of field", func(t *testing.T) {
+		members := []OrgMemberWithOrgAndUser{{OrgMember: OrgMember{ID: 1, OrgID: 1}}}
+		err := sqlx.Preload(db, &members, "Org")
+		NewWithT(t).Expect(err).NotTo(BeNil())
+		NewWithT(t).Expect(err.Error()).To(ContainSubstring("is not registered"))
+	})
+}
+
+type Place struct {
+	ID       uint64          `db:"f_id,autoincrement"`
+	Location datatypes.Point `db:"f_location"`
+}
+
+func (Place) TableName() string {
+	return "t_place"
+}
+
+func (Place) PrimaryKey() []string {
+	return []string{"ID"}
+}
+
+func TestGeometry(t *testing.T) {
+	dbTest := sqlx.NewDatabase("test_for_geometry")
+
+	for _, connector := range []driver.Connector{
+		mysqlConnector,
+		postgresConnector,
+	} {
+		t.Run("", func(t *testing.T) {
+			db := dbTest.OpenDB(connector)
+			table := dbTest.Register(&Place{})
+
+			defer func() {
+				_, _ = db.ExecExpr(db.Dialect().DropTable(table))
+			}()
+
+			err := migration.Migrate(db, nil)
+			NewWithT(t).Expect(err).To(BeNil())
+
+			// longitude and latitude of Beijing, latitude out of range when axes swapped
+			place := Place{Location: datatypes.Point{X: 116.4, Y: 39.9}}
+
+			_, err = db.ExecExpr(sqlx.InsertToDB(db, &place, nil))
+			NewWithT(t).Expect(err).To(BeNil())
+
+			t.Run("scan", func(t *testing.T) {
+				places := make([]Place, 0)
+				err := db.QueryExprAndScan(builder.Select(nil).From(table), &places)
+				NewWithT(t).Expect(err).To(BeNil())
+				NewWithT(t).Expect(places).To(HaveLen(1))
+				NewWithT(t).Expect(places[0].Location).To(Equal(place.Location))
+			})
+
+			t.Run("ST_AsText and ST_X", func(t *testing.T) {
+				asText, x := "ST_AsText(?)", "ST_X(?)"
+				if db.Dialect().DriverName() == "mysql" {
+					asText, x = "ST_AsText(?, 'axis-order=long-lat')", "ST_Longitude(?)"
+				}
+
+				result := struct {
+					Text string  `db:"f_text"`
+					X    float64 `db:"f_x"`
+				}{}
+
+				err := db.QueryExprAndScan(
+					builder.Select(builder.MultiWith(",",
+						builder.Alias(builder.Expr(asText, table.F("Location")), "f_text"),
+						builder.Alias(builder.Expr(x, table.F("Location")), "f_x"),
+					)).From(table),
+					&result,
+				)
+				NewWithT(t).Expect(err).To(BeNil())
+				NewWithT(t).Expect(result.Text).To(Equal("POINT(116.4 39.9)"))
+				NewWithT(t).Expect(result.X).To(Equal(116.4))
+			})
+		})
+	}
+}
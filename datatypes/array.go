@@ -0,0 +1,104 @@
+package datatypes
+
+import (
+	"database/sql"
+	"database/sql/driver"
+
+	"github.com/lib/pq"
+)
+
+// StringArray stores as postgres text array like text[] or varchar[]
+type StringArray []string
+
+var _ interface {
+	sql.Scanner
+	driver.Valuer
+} = (*StringArray)(nil)
+
+func (a *StringArray) Scan(src interface{}) error {
+	return (*pq.StringArray)(a).Scan(src)
+}
+
+func (a StringArray) Value() (driver.Value, error) {
+	return pq.StringArray(a).Value()
+}
+
+// Int64Array stores as postgres bigint[]
+type Int64Array []int64
+
+var _ interface {
+	sql.Scanner
+	driver.Valuer
+} = (*Int64Array)(nil)
+
+func (a *Int64Array) Scan(src interface{}) error {
+	return (*pq.Int64Array)(a).Scan(src)
+}
+
+func (a Int64Array) Value() (driver.Value, error) {
+	return pq.Int64Array(a).Value()
+}
+
+// Int32Array stores as postgres integer[]
+type Int32Array []int32
+
+var _ interface {
+	sql.Scanner
+	driver.Valuer
+} = (*Int32Array)(nil)
+
+func (a *Int32Array) Scan(src interface{}) error {
+	return (*pq.Int32Array)(a).Scan(src)
+}
+
+func (a Int32Array) Value() (driver.Value, error) {
+	return pq.Int32Array(a).Value()
+}
+
+// Float64Array stores as postgres double precision[]
+type Float64Array []float64
+
+var _ interface {
+	sql.Scanner
+	driver.Valuer
+} = (*Float64Array)(nil)
+
+func (a *Float64Array) Scan(src interface{}) error {
+	return (*pq.Float64Array)(a).Scan(src)
+}
+
+func (a Float64Array) Value() (driver.Value, error) {
+	return pq.Float64Array(a).Value()
+}
+
+// Float32Array stores as postgres real[]
+type Float32Array []float32
+
+var _ interface {
+	sql.Scanner
+	driver.Valuer
+} = (*Float32Array)(nil)
+
+func (a *Float32Array) Scan(src interface{}) error {
+	return (*pq.Float32Array)(a).Scan(src)
+}
+
+func (a Float32Array) Value() (driver.Value, error) {
+	return pq.Float32Array(a).Value()
+}
+
+// BoolArray stores as postgres boolean[]
+type BoolArray []bool
+
+var _ interface {
+	sql.Scanner
+	driver.Valuer
+} = (*BoolArray)(nil)
+
+func (a *BoolArray) Scan(src interface{}) error {
+	return (*pq.BoolArray)(a).Scan(src)
+}
+
+func (a BoolArray) Value() (driver.Value, error) {
+	return pq.BoolArray(a).Value()
+}
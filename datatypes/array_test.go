@@ -0,0 +1,61 @@
+package datatypes
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestArray(t *testing.T) {
+	t.Run("StringArray", func(t *testing.T) {
+		a := StringArray{"a", "b c", `d"`}
+
+		value, err := a.Value()
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(value).To(gomega.Equal(`{"a","b c","d\""}`))
+
+		a2 := StringArray{}
+		err = a2.Scan([]byte(value.(string)))
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(a2).To(gomega.Equal(a))
+	})
+
+	t.Run("Int64Array", func(t *testing.T) {
+		a := Int64Array{1, 2, 3}
+
+		value, err := a.Value()
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(value).To(gomega.Equal(`{1,2,3}`))
+
+		a2 := Int64Array{}
+		err = a2.Scan([]byte(value.(string)))
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(a2).To(gomega.Equal(a))
+	})
+
+	t.Run("BoolArray", func(t *testing.T) {
+		a := BoolArray{true, false}
+
+		value, err := a.Value()
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(value).To(gomega.Equal(`{t,f}`))
+
+		a2 := BoolArray{}
+		err = a2.Scan([]byte(value.(string)))
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(a2).To(gomega.Equal(a))
+	})
+
+	t.Run("nil as NULL", func(t *testing.T) {
+		var a StringArray
+
+		value, err := a.Value()
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(value).To(gomega.BeNil())
+
+		a2 := StringArray{"a"}
+		err = a2.Scan(nil)
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(a2).To(gomega.BeNil())
+	})
+}
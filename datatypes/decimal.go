@@ -0,0 +1,295 @@
+package datatypes
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+var DecimalZero = Decimal{}
+
+// NewDecimal creates decimal as unscaled * 10^-scale, negative scale will be normalized to 0
+func NewDecimal(unscaled int64, scale int32) Decimal {
+	i := big.NewInt(unscaled)
+	if scale < 0 {
+		i.Mul(i, pow10(int64(-scale)))
+		scale = 0
+	}
+	return Decimal{unscaled: i, scale: scale}
+}
+
+func NewDecimalFromInt(i int64) Decimal {
+	return NewDecimal(i, 0)
+}
+
+func NewDecimalFromFloat(f float64) Decimal {
+	d, _ := ParseDecimal(strconv.FormatFloat(f, 'f', -1, 64))
+	return d
+}
+
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// ParseDecimal parse decimal from string like 123.45, -0.1 or 1.2e3
+func ParseDecimal(s string) (Decimal, error) {
+	str := strings.TrimSpace(s)
+
+	exp := int64(0)
+
+	if i := strings.IndexAny(str, "eE"); i != -1 {
+		e, err := strconv.ParseInt(str[i+1:], 10, 32)
+		if err != nil {
+			return DecimalZero, fmt.Errorf("invalid decimal %s", s)
+		}
+		exp = e
+		str = str[0:i]
+	}
+
+	scale := int64(0)
+
+	if i := strings.IndexByte(str, '.'); i != -1 {
+		scale = int64(len(str) - i - 1)
+		str = str[0:i] + str[i+1:]
+	}
+
+	unscaled, ok := new(big.Int).SetString(str, 10)
+	if !ok {
+		return DecimalZero, fmt.Errorf("invalid decimal %s", s)
+	}
+
+	scale = scale - exp
+
+	if scale < 0 {
+		unscaled.Mul(unscaled, pow10(-scale))
+		scale = 0
+	}
+
+	return Decimal{unscaled: unscaled, scale: int32(scale)}, nil
+}
+
+// Decimal arbitrary-precision decimal number, as unscaled * 10^-scale
+// stores as decimal in mysql and numeric in postgres, precision and scale come from tag size and decimal,
+// size is required in mysql, as decimal without size means decimal(10,0).
+// openapi:strfmt decimal
+type Decimal struct {
+	unscaled *big.Int
+	scale    int32
+}
+
+func (Decimal) DataType(driverName string) string {
+	if driverName == "postgres" {
+		return "numeric"
+	}
+	return "decimal"
+}
+
+func (d Decimal) int() *big.Int {
+	if d.unscaled == nil {
+		return new(big.Int)
+	}
+	return d.unscaled
+}
+
+func (d Decimal) Scale() int32 {
+	return d.scale
+}
+
+func (d Decimal) Sign() int {
+	return d.int().Sign()
+}
+
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+func (d Decimal) rescale(scale int32) *big.Int {
+	i := new(big.Int).Set(d.int())
+	if scale > d.scale {
+		i.Mul(i, pow10(int64(scale-d.scale)))
+	}
+	return i
+}
+
+func (d Decimal) Cmp(d2 Decimal) int {
+	scale := maxScale(d, d2)
+	return d.rescale(scale).Cmp(d2.rescale(scale))
+}
+
+func (d Decimal) Equal(d2 Decimal) bool {
+	return d.Cmp(d2) == 0
+}
+
+func (d Decimal) Add(d2 Decimal) Decimal {
+	scale := maxScale(d, d2)
+	return Decimal{unscaled: new(big.Int).Add(d.rescale(scale), d2.rescale(scale)), scale: scale}
+}
+
+func (d Decimal) Sub(d2 Decimal) Decimal {
+	scale := maxScale(d, d2)
+	return Decimal{unscaled: new(big.Int).Sub(d.rescale(scale), d2.rescale(scale)), scale: scale}
+}
+
+func (d Decimal) Mul(d2 Decimal) Decimal {
+	return Decimal{unscaled: new(big.Int).Mul(d.int(), d2.int()), scale: d.scale + d2.scale}
+}
+
+// Div d / d2, rounded half away from zero to scale
+func (d Decimal) Div(d2 Decimal, scale int32) Decimal {
+	if d2.IsZero() {
+		panic(fmt.Errorf("decimal division by zero"))
+	}
+	r := new(big.Rat).Quo(d.Rat(), d2.Rat())
+	return decimalFromRat(r, scale)
+}
+
+func (d Decimal) Neg() Decimal {
+	return Decimal{unscaled: new(big.Int).Neg(d.int()), scale: d.scale}
+}
+
+func (d Decimal) Abs() Decimal {
+	return Decimal{unscaled: new(big.Int).Abs(d.int()), scale: d.scale}
+}
+
+// Round rounded half away from zero to scale
+func (d Decimal) Round(scale int32) Decimal {
+	if scale >= d.scale {
+		return Decimal{unscaled: d.rescale(scale), scale: scale}
+	}
+	return decimalFromRat(d.Rat(), scale)
+}
+
+func (d Decimal) Rat() *big.Rat {
+	return new(big.Rat).SetFrac(d.int(), pow10(int64(d.scale)))
+}
+
+func (d Decimal) Float64() float64 {
+	f, _ := d.Rat().Float64()
+	return f
+}
+
+func (d Decimal) String() string {
+	i := d.int()
+
+	if d.scale <= 0 {
+		return i.String()
+	}
+
+	digits := new(big.Int).Abs(i).String()
+
+	if n := int(d.scale) + 1 - len(digits); n > 0 {
+		digits = strings.Repeat("0", n) + digits
+	}
+
+	buf := bytes.NewBuffer(nil)
+	if i.Sign() < 0 {
+		buf.WriteByte('-')
+	}
+	buf.WriteString(digits[0 : len(digits)-int(d.scale)])
+	buf.WriteByte('.')
+	buf.WriteString(digits[len(digits)-int(d.scale):])
+	return buf.String()
+}
+
+var _ interface {
+	sql.Scanner
+	driver.Valuer
+} = (*Decimal)(nil)
+
+func (d *Decimal) Scan(value interface{}) (err error) {
+	switch v := value.(type) {
+	case []byte:
+		*d, err = ParseDecimal(string(v))
+	case string:
+		*d, err = ParseDecimal(v)
+	case int64:
+		*d = NewDecimalFromInt(v)
+	case float64:
+		*d = NewDecimalFromFloat(v)
+	case nil:
+		*d = DecimalZero
+	default:
+		return fmt.Errorf("cannot sql.Scan() datatypes.Decimal from: %#v", v)
+	}
+	return
+}
+
+func (d Decimal) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+var _ interface {
+	encoding.TextMarshaler
+	encoding.TextUnmarshaler
+} = (*Decimal)(nil)
+
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Decimal) UnmarshalText(data []byte) (err error) {
+	if len(data) == 0 {
+		*d = DecimalZero
+		return nil
+	}
+	*d, err = ParseDecimal(string(data))
+	return
+}
+
+var _ interface {
+	json.Unmarshaler
+	json.Marshaler
+} = (*Decimal)(nil)
+
+// MarshalJSON as string to avoid losing precision in float64 of json decoders
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(d.String())), nil
+}
+
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	str := string(data)
+	if str == "null" {
+		return nil
+	}
+	if s, err := strconv.Unquote(str); err == nil {
+		str = s
+	}
+	return d.UnmarshalText([]byte(str))
+}
+
+func decimalFromRat(r *big.Rat, scale int32) Decimal {
+	num := new(big.Int).Mul(r.Num(), pow10(int64(scale)))
+	q, m := new(big.Int).QuoRem(num, r.Denom(), new(big.Int))
+
+	// half away from zero
+	if m.Sign() != 0 && new(big.Int).Mul(new(big.Int).Abs(m), big.NewInt(2)).Cmp(r.Denom()) >= 0 {
+		if num.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+
+	return Decimal{unscaled: q, scale: scale}
+}
+
+func maxScale(d Decimal, d2 Decimal) int32 {
+	if d.scale > d2.scale {
+		return d.scale
+	}
+	return d2.scale
+}
+
+func pow10(n int64) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(n), nil)
+}
@@ -0,0 +1,87 @@
+package datatypes
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestDecimal(t *testing.T) {
+	t.Run("Parse", func(t *testing.T) {
+		cases := map[string]string{
+			"123.45":  "123.45",
+			"-0.010":  "-0.010",
+			".5":      "0.5",
+			"1.2e3":   "1200",
+			"1.2E-3":  "0.0012",
+			"0":       "0",
+			"1000000": "1000000",
+		}
+
+		for input, expect := range cases {
+			d, err := ParseDecimal(input)
+			gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+			gomega.NewWithT(t).Expect(d.String()).To(gomega.Equal(expect))
+		}
+
+		gomega.NewWithT(t).Expect(NewDecimal(12, -2).String()).To(gomega.Equal("1200"))
+		gomega.NewWithT(t).Expect(NewDecimal(12, -2).Equal(MustParseDecimal("1.2e3"))).To(gomega.BeTrue())
+		gomega.NewWithT(t).Expect(NewDecimal(12, 2).String()).To(gomega.Equal("0.12"))
+
+		_, err := ParseDecimal("1.2.3")
+		gomega.NewWithT(t).Expect(err).NotTo(gomega.BeNil())
+	})
+
+	t.Run("Arithmetic", func(t *testing.T) {
+		a := MustParseDecimal("0.1")
+		b := MustParseDecimal("0.2")
+
+		gomega.NewWithT(t).Expect(a.Add(b).String()).To(gomega.Equal("0.3"))
+		gomega.NewWithT(t).Expect(a.Sub(b).String()).To(gomega.Equal("-0.1"))
+		gomega.NewWithT(t).Expect(a.Mul(b).String()).To(gomega.Equal("0.02"))
+		gomega.NewWithT(t).Expect(NewDecimalFromInt(10).Div(NewDecimalFromInt(3), 2).String()).To(gomega.Equal("3.33"))
+		gomega.NewWithT(t).Expect(NewDecimalFromInt(-2).Div(NewDecimalFromInt(3), 2).String()).To(gomega.Equal("-0.67"))
+		gomega.NewWithT(t).Expect(MustParseDecimal("2.345").Round(2).String()).To(gomega.Equal("2.35"))
+		gomega.NewWithT(t).Expect(MustParseDecimal("-2.345").Round(2).String()).To(gomega.Equal("-2.35"))
+		gomega.NewWithT(t).Expect(MustParseDecimal("2.3").Round(2).String()).To(gomega.Equal("2.30"))
+		gomega.NewWithT(t).Expect(MustParseDecimal("1.50").Equal(MustParseDecimal("1.5"))).To(gomega.BeTrue())
+		gomega.NewWithT(t).Expect(MustParseDecimal("1.51").Cmp(MustParseDecimal("1.5"))).To(gomega.Equal(1))
+		gomega.NewWithT(t).Expect(DecimalZero.IsZero()).To(gomega.BeTrue())
+	})
+
+	t.Run("Marshal & Unmarshal", func(t *testing.T) {
+		d := MustParseDecimal("12345678901234567890.123456789")
+
+		data, err := json.Marshal(d)
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(string(data)).To(gomega.Equal(`"12345678901234567890.123456789"`))
+
+		d2 := Decimal{}
+		err = json.Unmarshal(data, &d2)
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(d2.Equal(d)).To(gomega.BeTrue())
+
+		d3 := Decimal{}
+		err = json.Unmarshal([]byte(`1.25`), &d3)
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(d3.String()).To(gomega.Equal("1.25"))
+	})
+
+	t.Run("Scan & Value", func(t *testing.T) {
+		d := MustParseDecimal("99.99")
+
+		value, err := d.Value()
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(value).To(gomega.Equal("99.99"))
+
+		d2 := Decimal{}
+		err = d2.Scan([]byte("99.99"))
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(d2.Equal(d)).To(gomega.BeTrue())
+
+		err = d2.Scan(int64(3))
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(d2.String()).To(gomega.Equal("3"))
+	})
+}
@@ -0,0 +1,441 @@
+package datatypes
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// SRID of all geometry values, WGS 84
+const SRID = 4326
+
+const (
+	wkbPoint      uint32 = 1
+	wkbLineString uint32 = 2
+	wkbPolygon    uint32 = 3
+
+	ewkbSRIDFlag uint32 = 0x20000000
+)
+
+// Point X as longitude, Y as latitude
+// stores as point SRID 4326 in mysql 8.0+ and geometry(Point,4326) in postgres with PostGIS
+type Point struct {
+	X float64
+	Y float64
+}
+
+func (Point) DataType(driverName string) string {
+	return geometryDataType(driverName, "Point")
+}
+
+func (Point) ValueExOf(driverName string) string {
+	return geometryValueEx(driverName)
+}
+
+func (p Point) Value() (driver.Value, error) {
+	return p.String(), nil
+}
+
+func (p Point) String() string {
+	return "POINT(" + p.coords() + ")"
+}
+
+func (p Point) coords() string {
+	return strconv.FormatFloat(p.X, 'f', -1, 64) + " " + strconv.FormatFloat(p.Y, 'f', -1, 64)
+}
+
+func (p *Point) Scan(src interface{}) error {
+	return scanGeometry(src, wkbPoint, p)
+}
+
+// LineString stores as linestring SRID 4326 in mysql and geometry(LineString,4326) in postgres with PostGIS
+type LineString []Point
+
+func (LineString) DataType(driverName string) string {
+	return geometryDataType(driverName, "LineString")
+}
+
+func (LineString) ValueExOf(driverName string) string {
+	return geometryValueEx(driverName)
+}
+
+func (l LineString) Value() (driver.Value, error) {
+	return l.String(), nil
+}
+
+func (l LineString) String() string {
+	return "LINESTRING" + l.coords()
+}
+
+func (l LineString) coords() string {
+	buf := bytes.NewBufferString("(")
+	for i, p := range l {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteString(p.coords())
+	}
+	buf.WriteByte(')')
+	return buf.String()
+}
+
+func (l *LineString) Scan(src interface{}) error {
+	return scanGeometry(src, wkbLineString, l)
+}
+
+// Polygon rings of Polygon, first one is exterior ring, others are interior rings
+// stores as polygon SRID 4326 in mysql and geometry(Polygon,4326) in postgres with PostGIS
+type Polygon []LineString
+
+func (Polygon) DataType(driverName string) string {
+	return geometryDataType(driverName, "Polygon")
+}
+
+func (Polygon) ValueExOf(driverName string) string {
+	return geometryValueEx(driverName)
+}
+
+func (p Polygon) Value() (driver.Value, error) {
+	return p.String(), nil
+}
+
+func (p Polygon) String() string {
+	buf := bytes.NewBufferString("POLYGON(")
+	for i, ring := range p {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteString(ring.coords())
+	}
+	buf.WriteByte(')')
+	return buf.String()
+}
+
+func (p *Polygon) Scan(src interface{}) error {
+	return scanGeometry(src, wkbPolygon, p)
+}
+
+var _ interface {
+	sql.Scanner
+	driver.Valuer
+} = (*Point)(nil)
+
+var _ interface {
+	sql.Scanner
+	driver.Valuer
+} = (*LineString)(nil)
+
+var _ interface {
+	sql.Scanner
+	driver.Valuer
+} = (*Polygon)(nil)
+
+// geometryValueEx mysql 8.0+ takes SRID 4326 in lat-long axis order,
+// so axis order should be declared for X as longitude
+func geometryValueEx(driverName string) string {
+	if driverName == "mysql" {
+		return "ST_GeomFromText(?, " + strconv.Itoa(SRID) + ", 'axis-order=long-lat')"
+	}
+	return "ST_GeomFromText(?, " + strconv.Itoa(SRID) + ")"
+}
+
+// geometryDataType restricts SRID of column, spatial index could be used by functions with values in same SRID,
+// mysql 8.0+ required for SRID attribute of column
+func geometryDataType(driverName string, geometryType string) string {
+	if driverName == "postgres" {
+		return "geometry(" + geometryType + "," + strconv.Itoa(SRID) + ")"
+	}
+	return strings.ToLower(geometryType) + " SRID " + strconv.Itoa(SRID)
+}
+
+// scanGeometry supports
+// hex (E)WKB from PostGIS,
+// mysql internal geometry format (4 bytes SRID + WKB),
+// WKB and WKT (with optional SRID=4326; prefix)
+func scanGeometry(src interface{}, geometryType uint32, dest interface{}) error {
+	var data []byte
+
+	switch v := src.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	case nil:
+		return setGeometry(dest, nil)
+	default:
+		return fmt.Errorf("cannot sql.Scan() geometry from: %#v", v)
+	}
+
+	if len(data) == 0 {
+		return setGeometry(dest, nil)
+	}
+
+	g, err := UnmarshalGeometry(data)
+	if err != nil {
+		return err
+	}
+
+	return setGeometry(dest, g)
+}
+
+func setGeometry(dest interface{}, g interface{}) error {
+	switch d := dest.(type) {
+	case *Point:
+		if g == nil {
+			*d = Point{}
+			return nil
+		}
+		if p, ok := g.(Point); ok {
+			*d = p
+			return nil
+		}
+	case *LineString:
+		if g == nil {
+			*d = nil
+			return nil
+		}
+		if l, ok := g.(LineString); ok {
+			*d = l
+			return nil
+		}
+	case *Polygon:
+		if g == nil {
+			*d = nil
+			return nil
+		}
+		if p, ok := g.(Polygon); ok {
+			*d = p
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot scan %T into %T", g, dest)
+}
+
+// UnmarshalGeometry unmarshal Point, LineString or Polygon from hex (E)WKB, mysql internal format, WKB or WKT
+func UnmarshalGeometry(data []byte) (interface{}, error) {
+	if isWKT(data) {
+		return ParseWKT(string(data))
+	}
+
+	if isHex(data) {
+		b := make([]byte, hex.DecodedLen(len(data)))
+		if _, err := hex.Decode(b, data); err == nil {
+			data = b
+		}
+	}
+
+	// mysql internal format
+	if len(data) > 4 && (data[4] == 0 || data[4] == 1) {
+		if g, err := UnmarshalWKB(data[4:]); err == nil {
+			return g, nil
+		}
+	}
+
+	return UnmarshalWKB(data)
+}
+
+// UnmarshalWKB unmarshal Point, LineString or Polygon from (E)WKB
+func UnmarshalWKB(data []byte) (interface{}, error) {
+	r := &wkbReader{data: data}
+	g := r.readGeometry()
+	if r.err != nil {
+		return nil, r.err
+	}
+	if r.offset != len(data) {
+		return nil, fmt.Errorf("invalid wkb, %d bytes left", len(data)-r.offset)
+	}
+	return g, nil
+}
+
+type wkbReader struct {
+	data   []byte
+	offset int
+	order  binary.ByteOrder
+	err    error
+}
+
+func (r *wkbReader) read(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if r.offset+n > len(r.data) {
+		r.err = fmt.Errorf("invalid wkb, unexpected end")
+		return nil
+	}
+	b := r.data[r.offset : r.offset+n]
+	r.offset += n
+	return b
+}
+
+func (r *wkbReader) uint32() uint32 {
+	b := r.read(4)
+	if b == nil {
+		return 0
+	}
+	return r.order.Uint32(b)
+}
+
+func (r *wkbReader) float64() float64 {
+	b := r.read(8)
+	if b == nil {
+		return 0
+	}
+	return math.Float64frombits(r.order.Uint64(b))
+}
+
+func (r *wkbReader) point() Point {
+	return Point{X: r.float64(), Y: r.float64()}
+}
+
+func (r *wkbReader) points() LineString {
+	n := r.uint32()
+	if r.err != nil || int(n)*16 > len(r.data)-r.offset {
+		r.err = fmt.Errorf("invalid wkb, unexpected end")
+		return nil
+	}
+	l := make(LineString, n)
+	for i := range l {
+		l[i] = r.point()
+	}
+	return l
+}
+
+func (r *wkbReader) readGeometry() interface{} {
+	b := r.read(1)
+	if b == nil {
+		return nil
+	}
+
+	switch b[0] {
+	case 0:
+		r.order = binary.BigEndian
+	case 1:
+		r.order = binary.LittleEndian
+	default:
+		r.err = fmt.Errorf("invalid wkb byte order %d", b[0])
+		return nil
+	}
+
+	typ := r.uint32()
+
+	if typ&ewkbSRIDFlag != 0 {
+		_ = r.uint32()
+		typ = typ &^ ewkbSRIDFlag
+	}
+
+	switch typ {
+	case wkbPoint:
+		return r.point()
+	case wkbLineString:
+		return r.points()
+	case wkbPolygon:
+		n := r.uint32()
+		if r.err != nil || int(n)*4 > len(r.data)-r.offset {
+			r.err = fmt.Errorf("invalid wkb, unexpected end")
+			return nil
+		}
+		p := make(Polygon, n)
+		for i := range p {
+			p[i] = r.points()
+		}
+		return p
+	}
+
+	if r.err == nil {
+		r.err = fmt.Errorf("unsupported wkb geometry type %d", typ)
+	}
+	return nil
+}
+
+// ParseWKT parse Point, LineString or Polygon from WKT like POINT(1 2)
+func ParseWKT(wkt string) (interface{}, error) {
+	s := strings.TrimSpace(wkt)
+
+	if strings.HasPrefix(strings.ToUpper(s), "SRID=") {
+		if i := strings.IndexByte(s, ';'); i != -1 {
+			s = s[i+1:]
+		}
+	}
+
+	i := strings.IndexByte(s, '(')
+	if i == -1 || s[len(s)-1] != ')' {
+		return nil, fmt.Errorf("invalid wkt %s", wkt)
+	}
+
+	body := s[i+1 : len(s)-1]
+
+	switch strings.ToUpper(strings.TrimSpace(s[0:i])) {
+	case "POINT":
+		return parseWKTPoint(body)
+	case "LINESTRING":
+		return parseWKTPoints(body)
+	case "POLYGON":
+		p := Polygon{}
+		for _, ring := range strings.Split(body, "),") {
+			ring = strings.Trim(strings.TrimSpace(ring), "()")
+			l, err := parseWKTPoints(ring)
+			if err != nil {
+				return nil, err
+			}
+			p = append(p, l)
+		}
+		return p, nil
+	}
+
+	return nil, fmt.Errorf("unsupported wkt %s", wkt)
+}
+
+func parseWKTPoints(s string) (LineString, error) {
+	l := LineString{}
+	for _, coords := range strings.Split(s, ",") {
+		p, err := parseWKTPoint(coords)
+		if err != nil {
+			return nil, err
+		}
+		l = append(l, p)
+	}
+	return l, nil
+}
+
+func parseWKTPoint(s string) (Point, error) {
+	parts := strings.Fields(s)
+	if len(parts) != 2 {
+		return Point{}, fmt.Errorf("invalid wkt point %s", s)
+	}
+	x, err := strconv.ParseFloat(parts[0], 64)
+	if err != nil {
+		return Point{}, err
+	}
+	y, err := strconv.ParseFloat(parts[1], 64)
+	if err != nil {
+		return Point{}, err
+	}
+	return Point{X: x, Y: y}, nil
+}
+
+func isWKT(data []byte) bool {
+	for _, prefix := range []string{"POINT", "LINESTRING", "POLYGON", "SRID="} {
+		if len(data) >= len(prefix) && strings.EqualFold(string(data[0:len(prefix)]), prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func isHex(data []byte) bool {
+	if len(data)%2 != 0 {
+		return false
+	}
+	for _, c := range data {
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,63 @@
+package datatypes
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestGeometry(t *testing.T) {
+	t.Run("Value", func(t *testing.T) {
+		value, err := Point{X: 120.1, Y: 30.2}.Value()
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(value).To(gomega.Equal("POINT(120.1 30.2)"))
+
+		value, err = LineString{{X: 0, Y: 0}, {X: 1, Y: 1}}.Value()
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(value).To(gomega.Equal("LINESTRING(0 0,1 1)"))
+
+		value, err = Polygon{{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 1, Y: 1}, {X: 0, Y: 0}}}.Value()
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(value).To(gomega.Equal("POLYGON((0 0,1 0,1 1,0 0))"))
+	})
+
+	t.Run("Scan hex EWKB from PostGIS", func(t *testing.T) {
+		p := Point{}
+		err := p.Scan([]byte("0101000020E6100000000000000000F03F0000000000000040"))
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(p).To(gomega.Equal(Point{X: 1, Y: 2}))
+	})
+
+	t.Run("Scan mysql internal format", func(t *testing.T) {
+		data, _ := hex.DecodeString("E61000000101000000000000000000F03F0000000000000040")
+
+		p := Point{}
+		err := p.Scan(data)
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(p).To(gomega.Equal(Point{X: 1, Y: 2}))
+	})
+
+	t.Run("Scan WKB", func(t *testing.T) {
+		data, _ := hex.DecodeString("01020000000200000000000000000000000000000000000000000000000000F03F000000000000F03F")
+
+		l := LineString{}
+		err := l.Scan(data)
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(l).To(gomega.Equal(LineString{{X: 0, Y: 0}, {X: 1, Y: 1}}))
+	})
+
+	t.Run("Scan WKT", func(t *testing.T) {
+		p := Polygon{}
+		err := p.Scan("SRID=4326;POLYGON((0 0,1 0,1 1,0 0),(0.2 0.2,0.4 0.2,0.4 0.4,0.2 0.2))")
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(p).To(gomega.HaveLen(2))
+		gomega.NewWithT(t).Expect(p[1][1]).To(gomega.Equal(Point{X: 0.4, Y: 0.2}))
+	})
+
+	t.Run("Scan mismatched type", func(t *testing.T) {
+		p := Point{}
+		err := p.Scan("LINESTRING(0 0,1 1)")
+		gomega.NewWithT(t).Expect(err).NotTo(gomega.BeNil())
+	})
+}
@@ -0,0 +1,154 @@
+package datatypes
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/go-courier/enumeration"
+)
+
+func NewNativeEnum[T enumeration.IntStringerEnum](v T) NativeEnum[T] {
+	return NativeEnum[T]{V: v}
+}
+
+// NativeEnum stores String() key of enum T instead of Int(),
+// as enum('A','B') in mysql and native enum type named by NativeEnumTypeName in postgres.
+//
+// Zero value of T (UNKNOWN) is one of values too, and will be written as its key like zero of int enum,
+// so column could be NOT NULL.
+type NativeEnum[T enumeration.IntStringerEnum] struct {
+	V T
+}
+
+func (e NativeEnum[T]) DataType(driverName string) string {
+	if driverName == "postgres" {
+		return e.NativeEnumTypeName()
+	}
+
+	values := e.NativeEnumValues()
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = "'" + strings.ReplaceAll(v, "'", "''") + "'"
+	}
+	return "enum(" + strings.Join(quoted, ",") + ")"
+}
+
+// NativeEnumTypeName lower snake case of short type name of T
+func (e NativeEnum[T]) NativeEnumTypeName() string {
+	typeName := e.V.TypeName()
+	if i := strings.LastIndex(typeName, "."); i != -1 {
+		typeName = typeName[i+1:]
+	}
+	return toLowerSnakeCase(typeName)
+}
+
+// NativeEnumValues key of zero value of T first, then keys of ConstValues
+func (e NativeEnum[T]) NativeEnumValues() []string {
+	var zero T
+
+	constValues := e.V.ConstValues()
+	values := make([]string, 0, len(constValues)+1)
+	values = append(values, zero.String())
+
+	for _, v := range constValues {
+		if v.Int() != 0 {
+			values = append(values, v.String())
+		}
+	}
+	return values
+}
+
+func (e NativeEnum[T]) TypeName() string {
+	return e.V.TypeName()
+}
+
+func (e NativeEnum[T]) Int() int {
+	return e.V.Int()
+}
+
+func (e NativeEnum[T]) String() string {
+	return e.V.String()
+}
+
+func (e NativeEnum[T]) Label() string {
+	return e.V.Label()
+}
+
+func (e NativeEnum[T]) ConstValues() []enumeration.IntStringerEnum {
+	return e.V.ConstValues()
+}
+
+var _ interface {
+	sql.Scanner
+	driver.Valuer
+	enumeration.IntStringerEnum
+} = (*NativeEnum[enumeration.IntStringerEnum])(nil)
+
+func (e *NativeEnum[T]) Scan(src interface{}) error {
+	var zero T
+	var key string
+
+	switch v := src.(type) {
+	case []byte:
+		key = string(v)
+	case string:
+		key = v
+	case nil:
+		*e = NativeEnum[T]{}
+		return nil
+	default:
+		return fmt.Errorf("cannot sql.Scan() NativeEnum from: %#v", v)
+	}
+
+	if key == zero.String() {
+		*e = NativeEnum[T]{}
+		return nil
+	}
+
+	for _, v := range e.V.ConstValues() {
+		if v.String() == key {
+			if t, ok := v.(T); ok {
+				e.V = t
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("unknown value %s of %s", key, e.V.TypeName())
+}
+
+func (e NativeEnum[T]) Value() (driver.Value, error) {
+	return e.V.String(), nil
+}
+
+var _ interface {
+	json.Unmarshaler
+	json.Marshaler
+} = (*NativeEnum[enumeration.IntStringerEnum])(nil)
+
+func (e NativeEnum[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.V)
+}
+
+func (e *NativeEnum[T]) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &e.V)
+}
+
+func toLowerSnakeCase(s string) string {
+	b := strings.Builder{}
+	runes := []rune(s)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,91 @@
+package datatypes
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-courier/enumeration"
+	"github.com/onsi/gomega"
+)
+
+type PullPolicy int
+
+const (
+	PULL_POLICY_UNKNOWN PullPolicy = iota
+	PULL_POLICY__ALWAYS
+	PULL_POLICY__IF_NOT_PRESENT
+)
+
+func (PullPolicy) TypeName() string {
+	return "github.com/go-courier/sqlx/v2/datatypes.PullPolicy"
+}
+
+func (v PullPolicy) Int() int {
+	return int(v)
+}
+
+func (v PullPolicy) String() string {
+	switch v {
+	case PULL_POLICY__ALWAYS:
+		return "ALWAYS"
+	case PULL_POLICY__IF_NOT_PRESENT:
+		return "IF_NOT_PRESENT"
+	}
+	return "UNKNOWN"
+}
+
+func (v PullPolicy) Label() string {
+	return v.String()
+}
+
+func (PullPolicy) ConstValues() []enumeration.IntStringerEnum {
+	return []enumeration.IntStringerEnum{PULL_POLICY__ALWAYS, PULL_POLICY__IF_NOT_PRESENT}
+}
+
+func (v PullPolicy) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+func TestNativeEnum(t *testing.T) {
+	t.Run("DataType", func(t *testing.T) {
+		e := NativeEnum[PullPolicy]{}
+
+		gomega.NewWithT(t).Expect(e.DataType("mysql")).To(gomega.Equal("enum('UNKNOWN','ALWAYS','IF_NOT_PRESENT')"))
+		gomega.NewWithT(t).Expect(e.DataType("postgres")).To(gomega.Equal("pull_policy"))
+	})
+
+	t.Run("Scan & Value", func(t *testing.T) {
+		e := NativeEnum[PullPolicy]{}
+
+		err := e.Scan([]byte("IF_NOT_PRESENT"))
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(e).To(gomega.Equal(NewNativeEnum(PULL_POLICY__IF_NOT_PRESENT)))
+
+		value, err := e.Value()
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(value).To(gomega.Equal("IF_NOT_PRESENT"))
+
+		err = e.Scan("NEVER")
+		gomega.NewWithT(t).Expect(err).NotTo(gomega.BeNil())
+
+		err = e.Scan(nil)
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+
+		value, err = e.Value()
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(value).To(gomega.Equal("UNKNOWN"))
+
+		err = e.Scan([]byte("ALWAYS"))
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+
+		err = e.Scan("UNKNOWN")
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(e).To(gomega.Equal(NativeEnum[PullPolicy]{}))
+	})
+
+	t.Run("Marshal", func(t *testing.T) {
+		data, err := json.Marshal(NewNativeEnum(PULL_POLICY__ALWAYS))
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(string(data)).To(gomega.Equal(`"ALWAYS"`))
+	})
+}
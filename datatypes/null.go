@@ -0,0 +1,88 @@
+package datatypes
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"reflect"
+
+	"github.com/go-courier/sqlx/v2/scanner/nullable"
+)
+
+func NewNull[T any](v T) Null[T] {
+	return Null[T]{V: v, Valid: true}
+}
+
+// Null value of T which could be NULL, column will be nullable with data type of T.
+//
+// Null[T]{} will be written as NULL, not skipped as zero field.
+// To leave the column unchanged (like PATCH), use *Null[T] and keep it nil.
+type Null[T any] struct {
+	V     T
+	Valid bool
+}
+
+func (n Null[T]) UnderlyingType() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// Ptr returns nil when NULL
+func (n Null[T]) Ptr() *T {
+	if !n.Valid {
+		return nil
+	}
+	v := n.V
+	return &v
+}
+
+var _ interface {
+	sql.Scanner
+	driver.Valuer
+} = (*Null[int])(nil)
+
+func (n *Null[T]) Scan(src interface{}) error {
+	if src == nil {
+		*n = Null[T]{}
+		return nil
+	}
+	if err := nullable.NewNullIgnoreScanner(&n.V).Scan(src); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+func (n Null[T]) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	if valuer, ok := interface{}(n.V).(driver.Valuer); ok {
+		return valuer.Value()
+	}
+	return driver.DefaultParameterConverter.ConvertValue(n.V)
+}
+
+var _ interface {
+	json.Unmarshaler
+	json.Marshaler
+} = (*Null[int])(nil)
+
+func (n Null[T]) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.V)
+}
+
+func (n *Null[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*n = Null[T]{}
+		return nil
+	}
+	if err := json.Unmarshal(data, &n.V); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
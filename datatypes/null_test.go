@@ -0,0 +1,59 @@
+package datatypes
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestNull(t *testing.T) {
+	t.Run("Marshal & Unmarshal", func(t *testing.T) {
+		type Data struct {
+			Name  Null[string] `json:"name"`
+			Count Null[int]    `json:"count"`
+		}
+
+		data, err := json.Marshal(Data{Name: NewNull("x")})
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(string(data)).To(gomega.Equal(`{"name":"x","count":null}`))
+
+		d := Data{Count: NewNull(1)}
+		err = json.Unmarshal([]byte(`{"name":"y","count":null}`), &d)
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(d).To(gomega.Equal(Data{Name: NewNull("y")}))
+	})
+
+	t.Run("Scan & Value", func(t *testing.T) {
+		n := Null[int64]{}
+
+		err := n.Scan(int64(1))
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(n).To(gomega.Equal(NewNull(int64(1))))
+
+		value, err := n.Value()
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(value).To(gomega.Equal(int64(1)))
+
+		err = n.Scan(nil)
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(n.Valid).To(gomega.BeFalse())
+		gomega.NewWithT(t).Expect(n.Ptr()).To(gomega.BeNil())
+
+		value, err = n.Value()
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(value).To(gomega.BeNil())
+	})
+
+	t.Run("Scan & Value with Valuer", func(t *testing.T) {
+		n := Null[Decimal]{}
+
+		err := n.Scan([]byte("1.50"))
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(n.Valid).To(gomega.BeTrue())
+
+		value, err := n.Value()
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(value).To(gomega.Equal("1.50"))
+	})
+}
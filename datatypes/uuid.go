@@ -0,0 +1,161 @@
+package datatypes
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var UUIDZero = UUID{}
+
+func NewUUID() UUID {
+	return UUID(uuid.New())
+}
+
+// NewUUIDv7 time-ordered uuid, first 48 bits are unix milliseconds
+// https://datatracker.ietf.org/doc/html/rfc9562#name-uuid-version-7
+func NewUUIDv7() UUID {
+	u := uuid.New()
+
+	ms := make([]byte, 8)
+	binary.BigEndian.PutUint64(ms, uint64(time.Now().UnixNano()/int64(time.Millisecond)))
+	copy(u[0:6], ms[2:])
+
+	// version 7, variant is already RFC4122 from uuid.New()
+	u[6] = (u[6] & 0x0f) | 0x70
+
+	return UUID(u)
+}
+
+func ParseUUID(s string) (UUID, error) {
+	u, err := uuid.Parse(s)
+	if err != nil {
+		return UUIDZero, err
+	}
+	return UUID(u), nil
+}
+
+// UUID stores as uuid in postgres and char(36) in mysql
+// openapi:strfmt uuid
+type UUID uuid.UUID
+
+func (UUID) DataType(driverName string) string {
+	if driverName == "postgres" {
+		return "uuid"
+	}
+	return "char(36)"
+}
+
+var _ interface {
+	sql.Scanner
+	driver.Valuer
+} = (*UUID)(nil)
+
+func (u *UUID) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		if len(v) == 16 {
+			copy(u[:], v)
+			return nil
+		}
+		return u.UnmarshalText(v)
+	case string:
+		return u.UnmarshalText([]byte(v))
+	case nil:
+		*u = UUIDZero
+	default:
+		return fmt.Errorf("cannot sql.Scan() datatypes.UUID from: %#v", v)
+	}
+	return nil
+}
+
+func (u UUID) Value() (driver.Value, error) {
+	return u.String(), nil
+}
+
+func (u UUID) String() string {
+	return uuid.UUID(u).String()
+}
+
+func (u UUID) IsZero() bool {
+	return u == UUIDZero
+}
+
+var _ interface {
+	encoding.TextMarshaler
+	encoding.TextUnmarshaler
+} = (*UUID)(nil)
+
+func (u UUID) MarshalText() ([]byte, error) {
+	if u.IsZero() {
+		return []byte(""), nil
+	}
+	return []byte(u.String()), nil
+}
+
+func (u *UUID) UnmarshalText(data []byte) (err error) {
+	if len(data) == 0 {
+		*u = UUIDZero
+		return nil
+	}
+	*u, err = ParseUUID(string(data))
+	return
+}
+
+// BinaryUUID stores as binary(16) in mysql and uuid in postgres
+// openapi:strfmt uuid
+type BinaryUUID UUID
+
+func (BinaryUUID) DataType(driverName string) string {
+	if driverName == "postgres" {
+		return "uuid"
+	}
+	return "binary(16)"
+}
+
+// ValueExOf value is 16 bytes, which should be converted to uuid in postgres
+func (BinaryUUID) ValueExOf(driverName string) string {
+	if driverName == "postgres" {
+		return "encode(?, 'hex')::uuid"
+	}
+	return "?"
+}
+
+var _ interface {
+	sql.Scanner
+	driver.Valuer
+} = (*BinaryUUID)(nil)
+
+func (u *BinaryUUID) Scan(value interface{}) error {
+	return (*UUID)(u).Scan(value)
+}
+
+func (u BinaryUUID) Value() (driver.Value, error) {
+	return u[:], nil
+}
+
+func (u BinaryUUID) String() string {
+	return UUID(u).String()
+}
+
+func (u BinaryUUID) IsZero() bool {
+	return UUID(u).IsZero()
+}
+
+var _ interface {
+	encoding.TextMarshaler
+	encoding.TextUnmarshaler
+} = (*BinaryUUID)(nil)
+
+func (u BinaryUUID) MarshalText() ([]byte, error) {
+	return UUID(u).MarshalText()
+}
+
+func (u *BinaryUUID) UnmarshalText(data []byte) error {
+	return (*UUID)(u).UnmarshalText(data)
+}
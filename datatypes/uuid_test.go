@@ -0,0 +1,71 @@
+package datatypes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+)
+
+func TestUUID(t *testing.T) {
+	t.Run("Marshal & Unmarshal", func(t *testing.T) {
+		u, err := ParseUUID("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+
+		data, err := u.MarshalText()
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(string(data)).To(gomega.Equal("6ba7b810-9dad-11d1-80b4-00c04fd430c8"))
+
+		u2 := UUIDZero
+		gomega.NewWithT(t).Expect(u2.IsZero()).To(gomega.BeTrue())
+
+		err = u2.UnmarshalText(data)
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(u2).To(gomega.Equal(u))
+	})
+
+	t.Run("Scan & Value", func(t *testing.T) {
+		u := NewUUID()
+
+		value, err := u.Value()
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(value).To(gomega.Equal(u.String()))
+
+		u2 := UUID{}
+		err = u2.Scan([]byte(value.(string)))
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(u2).To(gomega.Equal(u))
+
+		bu := BinaryUUID(u)
+
+		binaryValue, err := bu.Value()
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(binaryValue).To(gomega.HaveLen(16))
+
+		bu2 := BinaryUUID{}
+		err = bu2.Scan(binaryValue)
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(bu2).To(gomega.Equal(bu))
+
+		// uuid in text from postgres
+		bu3 := BinaryUUID{}
+		err = bu3.Scan(u.String())
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(bu3).To(gomega.Equal(bu))
+
+		gomega.NewWithT(t).Expect(bu.DataType("postgres")).To(gomega.Equal("uuid"))
+		gomega.NewWithT(t).Expect(bu.ValueExOf("postgres")).To(gomega.Equal("encode(?, 'hex')::uuid"))
+		gomega.NewWithT(t).Expect(bu.DataType("mysql")).To(gomega.Equal("binary(16)"))
+		gomega.NewWithT(t).Expect(bu.ValueExOf("mysql")).To(gomega.Equal("?"))
+	})
+
+	t.Run("v7", func(t *testing.T) {
+		u1 := NewUUIDv7()
+		time.Sleep(2 * time.Millisecond)
+		u2 := NewUUIDv7()
+
+		gomega.NewWithT(t).Expect(u1[6] >> 4).To(gomega.Equal(byte(7)))
+		gomega.NewWithT(t).Expect(u1[8] >> 6).To(gomega.Equal(byte(2)))
+		gomega.NewWithT(t).Expect(u1.String() < u2.String()).To(gomega.BeTrue())
+	})
+}
@@ -8,10 +8,13 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/go-courier/sqlx/v2/builder"
+	"github.com/go-courier/sqlx/v2/scanner"
 )
 
 var ErrNotTx = errors.New("db is not *sql.Tx")
 var ErrNotDB = errors.New("db is not *sql.DB")
+var ErrPartitionUnsupported = errors.New("dialect not support partitions")
+var ErrViewUnsupported = errors.New("dialect not support views")
 
 type SqlExecutor interface {
 	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
@@ -30,6 +33,16 @@ type Migrator interface {
 	Migrate(ctx context.Context, db DBExecutor) error
 }
 
+// SchemaIntrospector read tables of the connecting database from information schema
+type SchemaIntrospector interface {
+	Introspect(db DBExecutor) (*Database, error)
+}
+
+// PartitionIntrospector read partitions of table in the connecting database
+type PartitionIntrospector interface {
+	PartitionNames(db DBExecutor, t *builder.Table) ([]string, error)
+}
+
 type TableResolver interface {
 	// T return table of the connecting database
 	T(model builder.Model) *builder.Table
@@ -100,7 +113,7 @@ func (d *DB) D() *Database {
 }
 
 func (d *DB) ExecExpr(expr builder.SqlExpr) (sql.Result, error) {
-	e := builder.ResolveExprContext(d.Context(), expr)
+	e := builder.ResolveExprContext(builder.ContextWithDriverName(d.Context(), d.dialect.DriverName()), expr)
 	if builder.IsNilExpr(e) {
 		return nil, nil
 	}
@@ -118,7 +131,7 @@ func (d *DB) ExecExpr(expr builder.SqlExpr) (sql.Result, error) {
 }
 
 func (d *DB) QueryExpr(expr builder.SqlExpr) (*sql.Rows, error) {
-	e := builder.ResolveExprContext(d.Context(), expr)
+	e := builder.ResolveExprContext(builder.ContextWithDriverName(d.Context(), d.dialect.DriverName()), expr)
 	if builder.IsNilExpr(e) {
 		return nil, nil
 	}
@@ -133,7 +146,18 @@ func (d *DB) QueryExprAndScan(expr builder.SqlExpr, v interface{}) error {
 	if err != nil {
 		return err
 	}
-	return Scan(rows, v)
+
+	ctx := d.Context()
+
+	// fields of model of table selected from should be filled in strict mode
+	if opts := scanner.OptionsFromContext(ctx); opts.Strict && opts.TableName == "" {
+		if stmt, ok := expr.(*builder.StmtSelect); ok && stmt.Table() != nil {
+			opts.TableName = stmt.Table().Name
+			ctx = scanner.ContextWithOptions(ctx, opts)
+		}
+	}
+
+	return ScanContext(ctx, rows, v)
 }
 
 func (d *DB) IsTx() bool {
@@ -5,7 +5,9 @@ import (
 )
 
 type SqlMetaEnum struct {
-	TName string `db:"F_table_name,size=64"`
+	// schema of table, t_sql_meta_enum could be shared by databases in different schemas
+	Schema string `db:"F_schema,size=64,default=''"`
+	TName  string `db:"F_table_name,size=64"`
 	CName string `db:"F_column_name,size=64"`
 	Value int    `db:"F_value"`
 	Type  string `db:"F_type,size=255"`
@@ -18,5 +20,5 @@ func (*SqlMetaEnum) TableName() string {
 }
 
 func (*SqlMetaEnum) UniqueIndexes() builder.Indexes {
-	return builder.Indexes{"I_enum": {"TName", "CName", "Value"}}
+	return builder.Indexes{"I_enum": {"Schema", "TName", "CName", "Value"}}
 }
@@ -1,75 +1,216 @@
 package enummeta
 
 import (
+	"io"
+	"sort"
+
 	"github.com/go-courier/enumeration"
 	"github.com/go-courier/sqlx/v2"
 	"github.com/go-courier/sqlx/v2/builder"
 	typex "github.com/go-courier/x/types"
 )
 
+// SyncEnum syncs enum values of columns of tables in db.D() into t_sql_meta_enum
 func SyncEnum(db sqlx.DBExecutor) error {
-	metaEnumTable := builder.T((&SqlMetaEnum{}).TableName())
+	return SyncEnumWithOutput(db, nil)
+}
+
+// SyncEnumWithOutput syncs enum values by diff of rows keyed by I_enum.
+// only rows of tables owned by db.D() in its schema will be touched, so t_sql_meta_enum could be shared.
+// when output is not nil, statements will be written to output instead of executed.
+func SyncEnumWithOutput(db sqlx.DBExecutor, output io.Writer) error {
+	metaEnumTable := MetaEnumTable()
 
 	dialect := db.Dialect()
+	d := db.D()
+	db = db.WithSchema("")
 
-	builder.ScanDefToTable(metaEnumTable, &SqlMetaEnum{})
+	exec := func(expr builder.SqlExpr) error {
+		if expr == nil || expr.IsNil() {
+			return nil
+		}
 
-	task := sqlx.NewTasks(db.WithSchema(""))
+		if output != nil {
+			_, _ = io.WriteString(output, builder.ResolveExpr(expr).Query())
+			_, _ = io.WriteString(output, "\n")
+			return nil
+		}
 
-	task = task.With(func(db sqlx.DBExecutor) error {
-		_, err := db.ExecExpr(dialect.DropTable(metaEnumTable))
+		_, err := db.ExecExpr(expr)
 		return err
-	})
+	}
+
+	for _, expr := range dialect.CreateTableIsNotExists(metaEnumTable) {
+		if err := exec(expr); err != nil {
+			return err
+		}
+	}
+
+	sqlMetaEnums := SqlMetaEnumsFromDatabase(d)
+
+	prevSqlMetaEnums := make([]*SqlMetaEnum, 0)
+
+	if tableNames := d.Tables.TableNames(); len(tableNames) > 0 {
+		stmt := builder.Select(nil).From(
+			metaEnumTable,
+			builder.Where(builder.And(
+				metaEnumTable.F("Schema").Eq(d.Schema),
+				metaEnumTable.F("TName").In(stringsToArgs(tableNames)...),
+			)),
+		)
 
-	exprs := dialect.CreateTableIsNotExists(metaEnumTable)
+		if err := db.QueryExprAndScan(stmt, &prevSqlMetaEnums); err != nil {
+			// t_sql_meta_enum may not be created yet when only output statements
+			if output == nil {
+				return err
+			}
+		}
+	}
 
-	for i := range exprs {
-		expr := exprs[i]
-		task = task.With(func(db sqlx.DBExecutor) error {
-			_, err := db.ExecExpr(expr)
+	for _, expr := range DiffSqlMetaEnums(metaEnumTable, dialect, prevSqlMetaEnums, sqlMetaEnums) {
+		if err := exec(expr); err != nil {
 			return err
-		})
+		}
 	}
 
-	{
-		// insert values
-		stmtForInsert := builder.Insert().Into(metaEnumTable)
-		vals := make([]interface{}, 0)
-
-		columns := &builder.Columns{}
-
-		db.D().Tables.Range(func(table *builder.Table, idx int) {
-			table.Columns.Range(func(col *builder.Column, idx int) {
-				if rv, ok := typex.TryNew(col.ColumnType.Type); ok {
-					if enumValue, ok := rv.Interface().(enumeration.Enum); ok {
-						for _, enum := range enumValue.ConstValues() {
-							sqlMetaEnum := &SqlMetaEnum{
-								TName: table.Name,
-								CName: col.Name,
-								Type:  enum.TypeName(),
-								Value: enum.Int(),
-								Key:   enum.String(),
-								Label: enum.Label(),
-							}
-							fieldValues := builder.FieldValuesFromStructByNonZero(sqlMetaEnum, "Value")
-							cols, values := metaEnumTable.ColumnsAndValuesByFieldValues(fieldValues)
-							vals = append(vals, values...)
-							columns = cols
-						}
+	return nil
+}
+
+// MetaEnumTable returns table of SqlMetaEnum
+func MetaEnumTable() *builder.Table {
+	metaEnumTable := builder.T((&SqlMetaEnum{}).TableName())
+	builder.ScanDefToTable(metaEnumTable, &SqlMetaEnum{})
+	return metaEnumTable
+}
+
+// SqlMetaEnumsFromDatabase collects enum values of columns of tables in d
+func SqlMetaEnumsFromDatabase(d *sqlx.Database) []*SqlMetaEnum {
+	sqlMetaEnums := make([]*SqlMetaEnum, 0)
+
+	d.Tables.Range(func(table *builder.Table, idx int) {
+		table.Columns.Range(func(col *builder.Column, idx int) {
+			if rv, ok := typex.TryNew(col.ColumnType.Type); ok {
+				if enumValue, ok := rv.Interface().(enumeration.Enum); ok {
+					for _, enum := range enumValue.ConstValues() {
+						sqlMetaEnums = append(sqlMetaEnums, &SqlMetaEnum{
+							Schema: table.Schema,
+							TName:  table.Name,
+							CName:  col.Name,
+							Type:   enum.TypeName(),
+							Value:  enum.Int(),
+							Key:    enum.String(),
+							Label:  enum.Label(),
+						})
 					}
 				}
-			})
+			}
 		})
+	})
 
-		if len(vals) > 0 {
-			stmtForInsert = stmtForInsert.Values(columns, vals...)
+	return sqlMetaEnums
+}
 
-			task = task.With(func(db sqlx.DBExecutor) error {
-				_, err := db.ExecExpr(stmtForInsert)
-				return err
-			})
+// DiffSqlMetaEnums returns statements to sync prev to next.
+// inserts and changes are upserted by I_enum, so concurrent syncs will not conflict;
+// rows of prev not in next will be deleted.
+func DiffSqlMetaEnums(metaEnumTable *builder.Table, dialect builder.Dialect, prev []*SqlMetaEnum, next []*SqlMetaEnum) (exprList []builder.SqlExpr) {
+	prevSqlMetaEnums := map[sqlMetaEnumKey]*SqlMetaEnum{}
+	for i := range prev {
+		prevSqlMetaEnums[prev[i].key()] = prev[i]
+	}
+
+	nextSqlMetaEnums := map[sqlMetaEnumKey]bool{}
+
+	for i := range next {
+		sqlMetaEnum := next[i]
+		nextSqlMetaEnums[sqlMetaEnum.key()] = true
+
+		if prevSqlMetaEnum, ok := prevSqlMetaEnums[sqlMetaEnum.key()]; ok && *prevSqlMetaEnum == *sqlMetaEnum {
+			continue
+		}
+
+		exprList = append(exprList, upsertSqlMetaEnum(metaEnumTable, dialect, sqlMetaEnum))
+	}
+
+	removed := make([]*SqlMetaEnum, 0)
+	for i := range prev {
+		if !nextSqlMetaEnums[prev[i].key()] {
+			removed = append(removed, prev[i])
 		}
 	}
 
-	return task.Do()
+	sort.Slice(removed, func(i, j int) bool {
+		return removed[i].key().less(removed[j].key())
+	})
+
+	for _, sqlMetaEnum := range removed {
+		exprList = append(exprList, builder.Delete().From(
+			metaEnumTable,
+			builder.Where(
+				builder.And(
+					metaEnumTable.F("Schema").Eq(sqlMetaEnum.Schema),
+					metaEnumTable.F("TName").Eq(sqlMetaEnum.TName),
+					metaEnumTable.F("CName").Eq(sqlMetaEnum.CName),
+					metaEnumTable.F("Value").Eq(sqlMetaEnum.Value),
+				),
+			),
+		))
+	}
+
+	return
+}
+
+func upsertSqlMetaEnum(metaEnumTable *builder.Table, dialect builder.Dialect, sqlMetaEnum *SqlMetaEnum) builder.SqlExpr {
+	fieldValues := builder.FieldValuesFromStructByNonZero(sqlMetaEnum, "Schema", "Value", "Type", "Key", "Label")
+	cols, values := metaEnumTable.ColumnsAndValuesByFieldValues(fieldValues)
+
+	assignments := builder.Assignments{
+		metaEnumTable.F("Type").ValueBy(sqlMetaEnum.Type),
+		metaEnumTable.F("Key").ValueBy(sqlMetaEnum.Key),
+		metaEnumTable.F("Label").ValueBy(sqlMetaEnum.Label),
+	}
+
+	additions := builder.Additions{}
+
+	switch dialect.DriverName() {
+	case "mysql":
+		additions = append(additions, builder.OnDuplicateKeyUpdate(assignments...))
+	case "postgres":
+		indexFields, _ := metaEnumTable.Fields("Schema", "TName", "CName", "Value")
+		additions = append(additions, builder.OnConflict(indexFields).DoUpdateSet(assignments...))
+	}
+
+	return builder.Insert().Into(metaEnumTable, additions...).Values(cols, values...)
+}
+
+type sqlMetaEnumKey struct {
+	Schema string
+	TName  string
+	CName  string
+	Value  int
+}
+
+func (k sqlMetaEnumKey) less(other sqlMetaEnumKey) bool {
+	if k.Schema != other.Schema {
+		return k.Schema < other.Schema
+	}
+	if k.TName != other.TName {
+		return k.TName < other.TName
+	}
+	if k.CName != other.CName {
+		return k.CName < other.CName
+	}
+	return k.Value < other.Value
+}
+
+func (e *SqlMetaEnum) key() sqlMetaEnumKey {
+	return sqlMetaEnumKey{Schema: e.Schema, TName: e.TName, CName: e.CName, Value: e.Value}
+}
+
+func stringsToArgs(values []string) []interface{} {
+	args := make([]interface{}, len(values))
+	for i := range values {
+		args[i] = values[i]
+	}
+	return args
 }
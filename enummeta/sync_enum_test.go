@@ -0,0 +1,146 @@
+package enummeta_test
+
+import (
+	"testing"
+
+	"github.com/go-courier/enumeration"
+	"github.com/go-courier/sqlx/v2"
+	"github.com/go-courier/sqlx/v2/builder/buidertestingutils"
+	"github.com/go-courier/sqlx/v2/enummeta"
+	"github.com/go-courier/sqlx/v2/mysqlconnector"
+	"github.com/go-courier/sqlx/v2/postgresqlconnector"
+	"github.com/onsi/gomega"
+)
+
+func TestDiffSqlMetaEnums(t *testing.T) {
+	metaEnumTable := enummeta.MetaEnumTable()
+
+	prev := []*enummeta.SqlMetaEnum{
+		{TName: "t_user", CName: "f_gender", Type: "Gender", Value: 1, Key: "MALE", Label: "男"},
+		{TName: "t_user", CName: "f_gender", Type: "Gender", Value: 2, Key: "FEMALE", Label: "F"},
+		{TName: "t_user", CName: "f_gender", Type: "Gender", Value: 3, Key: "OTHER", Label: "其他"},
+	}
+
+	next := []*enummeta.SqlMetaEnum{
+		{TName: "t_user", CName: "f_gender", Type: "Gender", Value: 1, Key: "MALE", Label: "男"},
+		{TName: "t_user", CName: "f_gender", Type: "Gender", Value: 2, Key: "FEMALE", Label: "女"},
+	}
+
+	t.Run("unchanged", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(enummeta.DiffSqlMetaEnums(metaEnumTable, &mysqlconnector.MysqlConnector{}, next, next)).To(gomega.HaveLen(0))
+	})
+
+	t.Run("mysql", func(t *testing.T) {
+		exprList := enummeta.DiffSqlMetaEnums(metaEnumTable, &mysqlconnector.MysqlConnector{}, prev, next)
+		gomega.NewWithT(t).Expect(exprList).To(gomega.HaveLen(2))
+
+		gomega.NewWithT(t).Expect(exprList[0]).To(buidertestingutils.BeExpr(
+			"INSERT INTO t_sql_meta_enum (f_column_name,f_key,f_label,f_schema,f_table_name,f_type,f_value) VALUES (?,?,?,?,?,?,?)\nON DUPLICATE KEY UPDATE f_type = ?, f_key = ?, f_label = ?",
+			"f_gender", "FEMALE", "女", "", "t_user", "Gender", 2, "Gender", "FEMALE", "女",
+		))
+		gomega.NewWithT(t).Expect(exprList[1]).To(buidertestingutils.BeExpr(
+			"DELETE FROM t_sql_meta_enum\nWHERE (f_schema = ?) AND (f_table_name = ?) AND (f_column_name = ?) AND (f_value = ?)",
+			"", "t_user", "f_gender", 3,
+		))
+	})
+
+	t.Run("postgres", func(t *testing.T) {
+		exprList := enummeta.DiffSqlMetaEnums(metaEnumTable, &postgresqlconnector.PostgreSQLConnector{}, nil, next[0:1])
+		gomega.NewWithT(t).Expect(exprList).To(gomega.HaveLen(1))
+
+		gomega.NewWithT(t).Expect(exprList[0]).To(buidertestingutils.BeExpr(
+			"INSERT INTO t_sql_meta_enum (f_column_name,f_key,f_label,f_schema,f_table_name,f_type,f_value) VALUES (?,?,?,?,?,?,?)\nON CONFLICT (f_schema,f_table_name,f_column_name,f_value) DO UPDATE SET f_type = ?, f_key = ?, f_label = ?",
+			"f_gender", "MALE", "男", "", "t_user", "Gender", 1, "Gender", "MALE", "男",
+		))
+	})
+}
+
+type Gender int
+
+const (
+	GENDER_UNKNOWN Gender = iota
+	GENDER__MALE
+	GENDER__FEMALE
+)
+
+func (Gender) TypeName() string {
+	return "Gender"
+}
+
+func (v Gender) Int() int {
+	return int(v)
+}
+
+func (v Gender) String() string {
+	switch v {
+	case GENDER__MALE:
+		return "MALE"
+	case GENDER__FEMALE:
+		return "FEMALE"
+	}
+	return "UNKNOWN"
+}
+
+func (v Gender) Label() string {
+	switch v {
+	case GENDER__MALE:
+		return "男"
+	case GENDER__FEMALE:
+		return "女"
+	}
+	return "UNKNOWN"
+}
+
+func (Gender) ConstValues() []enumeration.IntStringerEnum {
+	return []enumeration.IntStringerEnum{GENDER__MALE, GENDER__FEMALE}
+}
+
+type User struct {
+	Name   string `db:"f_name,size=255"`
+	Gender Gender `db:"f_gender"`
+}
+
+func (User) TableName() string {
+	return "t_user"
+}
+
+func TestSqlMetaEnumsFromDatabase(t *testing.T) {
+	d := sqlx.NewDatabase("test")
+	d.Register(&User{})
+
+	gomega.NewWithT(t).Expect(enummeta.SqlMetaEnumsFromDatabase(d)).To(gomega.Equal([]*enummeta.SqlMetaEnum{
+		{TName: "t_user", CName: "f_gender", Type: "Gender", Value: 1, Key: "MALE", Label: "男"},
+		{TName: "t_user", CName: "f_gender", Type: "Gender", Value: 2, Key: "FEMALE", Label: "女"},
+	}))
+}
+
+func TestSqlMetaEnumsOfDatabasesInSchemas(t *testing.T) {
+	metaEnumTable := enummeta.MetaEnumTable()
+
+	d := sqlx.NewDatabase("test")
+	d.Register(&User{})
+
+	a := enummeta.SqlMetaEnumsFromDatabase(d.WithSchema("a"))
+	b := enummeta.SqlMetaEnumsFromDatabase(d.WithSchema("b"))
+
+	gomega.NewWithT(t).Expect(a[0].Schema).To(gomega.Equal("a"))
+	gomega.NewWithT(t).Expect(b[0].Schema).To(gomega.Equal("b"))
+
+	t.Run("rows of same table in other schema are different", func(t *testing.T) {
+		exprList := enummeta.DiffSqlMetaEnums(metaEnumTable, &postgresqlconnector.PostgreSQLConnector{}, a, b)
+		gomega.NewWithT(t).Expect(exprList).To(gomega.HaveLen(4))
+
+		gomega.NewWithT(t).Expect(exprList[0]).To(buidertestingutils.BeExpr(
+			"INSERT INTO t_sql_meta_enum (f_column_name,f_key,f_label,f_schema,f_table_name,f_type,f_value) VALUES (?,?,?,?,?,?,?)\nON CONFLICT (f_schema,f_table_name,f_column_name,f_value) DO UPDATE SET f_type = ?, f_key = ?, f_label = ?",
+			"f_gender", "MALE", "男", "b", "t_user", "Gender", 1, "Gender", "MALE", "男",
+		))
+		gomega.NewWithT(t).Expect(exprList[2]).To(buidertestingutils.BeExpr(
+			"DELETE FROM t_sql_meta_enum\nWHERE (f_schema = ?) AND (f_table_name = ?) AND (f_column_name = ?) AND (f_value = ?)",
+			"a", "t_user", "f_gender", 1,
+		))
+	})
+
+	t.Run("unchanged in own schema", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(enummeta.DiffSqlMetaEnums(metaEnumTable, &postgresqlconnector.PostgreSQLConnector{}, b, b)).To(gomega.HaveLen(0))
+	})
+}
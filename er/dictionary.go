@@ -0,0 +1,388 @@
+package er
+
+import (
+	"bytes"
+	"context"
+	htmltemplate "html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/go-courier/sqlx/v2"
+	"github.com/go-courier/sqlx/v2/builder"
+)
+
+// DataDictionaryFromDB collects tables of database as data dictionary,
+// data types of columns are resolved by each dialect
+func DataDictionaryFromDB(database *sqlx.Database, dialects ...builder.Dialect) *DataDictionary {
+	dd := &DataDictionary{Name: database.Name, Schema: database.Schema}
+
+	for _, dialect := range dialects {
+		dd.Dialects = append(dd.Dialects, dialect.DriverName())
+	}
+
+	tables := map[string]*DictTable{}
+
+	for _, tableName := range database.Tables.TableNames() {
+		table := database.Table(tableName)
+
+		t := &DictTable{Name: table.Name}
+		t.Summary, t.Desc = summaryAndDesc(table.Description)
+
+		table.Columns.Range(func(col *builder.Column, idx int) {
+			if col.DeprecatedActions != nil {
+				return
+			}
+
+			c := &DictCol{
+				Name:          col.Name,
+				FieldName:     col.FieldName,
+				DataTypes:     map[string]string{},
+				Null:          col.Null,
+				AutoIncrement: col.AutoIncrement,
+				Enum:          sortedEnums(enumsOfColumn(col)),
+			}
+
+			c.Summary, c.Desc = summaryAndDesc(col.Description)
+
+			for _, dialect := range dialects {
+				c.DataTypes[dialect.DriverName()] = dataTypeWithoutModifiers(builder.ResolveExpr(dialect.DataType(col.ColumnType)).Query())
+			}
+
+			if col.Default != nil {
+				c.Default = *col.Default
+			} else if col.AutoUUID != "" {
+				c.Default = "uuid" + col.AutoUUID
+			}
+
+			if len(col.Relation) == 2 {
+				if relTable := database.Tables.Model(col.Relation[0]); relTable != nil {
+					if relCol := relTable.F(col.Relation[1]); relCol != nil {
+						c.Rel = &DictRef{Table: relTable.Name, Col: relCol.Name}
+					}
+				}
+			}
+
+			t.Cols = append(t.Cols, c)
+		})
+
+		table.Keys.Range(func(key *builder.Key, idx int) {
+			t.Keys = append(t.Keys, &DictKey{
+				Name:      key.Name,
+				Method:    key.Method,
+				IsUnique:  key.IsUnique,
+				IsPrimary: key.IsPrimary(),
+				Def:       key.Def.TableExpr(table).Ex(context.Background()).Query(),
+			})
+		})
+
+		tables[t.Name] = t
+		dd.Tables = append(dd.Tables, t)
+	}
+
+	for _, t := range dd.Tables {
+		for _, c := range t.Cols {
+			if c.Rel != nil {
+				if relTable, ok := tables[c.Rel.Table]; ok {
+					relTable.ReferencedBy = append(relTable.ReferencedBy, DictRef{Table: t.Name, Col: c.Name})
+				}
+			}
+		}
+	}
+
+	return dd
+}
+
+// DataDictionary of database, could be output as markdown or html pages, one page per table
+type DataDictionary struct {
+	Name     string
+	Schema   string
+	Dialects []string
+	Tables   []*DictTable
+}
+
+type DictTable struct {
+	Name    string
+	Summary string
+	Desc    string
+	Cols    []*DictCol
+	Keys    []*DictKey
+	// ReferencedBy columns of other tables which relate to this table
+	ReferencedBy []DictRef
+}
+
+type DictCol struct {
+	Name      string
+	FieldName string
+	// DataTypes data type by driver name
+	DataTypes     map[string]string
+	Null          bool
+	Default       string
+	AutoIncrement bool
+	Summary       string
+	Desc          string
+	Enum          []EREnum
+	Rel           *DictRef
+}
+
+type DictRef struct {
+	Table string
+	Col   string
+}
+
+type DictKey struct {
+	Name      string
+	Method    string
+	IsUnique  bool
+	IsPrimary bool
+	Def       string
+}
+
+func (k *DictKey) Kind() string {
+	if k.IsPrimary {
+		return "PRIMARY"
+	}
+	if k.IsUnique {
+		return "UNIQUE"
+	}
+	return "INDEX"
+}
+
+// Output writes index and pages of tables into dir, format should be markdown or html
+func (dd *DataDictionary) Output(dir string, format string) error {
+	ext := ".md"
+	if format == "html" {
+		ext = ".html"
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	writeFile := func(name string, render func(w io.Writer) error) error {
+		buf := bytes.NewBuffer(nil)
+		if err := render(buf); err != nil {
+			return err
+		}
+		return os.WriteFile(filepath.Join(dir, name+ext), buf.Bytes(), 0o644)
+	}
+
+	if err := writeFile("index", func(w io.Writer) error {
+		return dd.RenderIndex(w, format)
+	}); err != nil {
+		return err
+	}
+
+	for i := range dd.Tables {
+		t := dd.Tables[i]
+		if err := writeFile(t.Name, func(w io.Writer) error {
+			return dd.RenderTable(w, t, format)
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RenderIndex renders list of tables
+func (dd *DataDictionary) RenderIndex(w io.Writer, format string) error {
+	return dd.render(w, format, "index", dd)
+}
+
+// RenderTable renders page of table
+func (dd *DataDictionary) RenderTable(w io.Writer, t *DictTable, format string) error {
+	return dd.render(w, format, "table", struct {
+		*DataDictionary
+		Table *DictTable
+	}{
+		DataDictionary: dd,
+		Table:          t,
+	})
+}
+
+type dictTemplate interface {
+	ExecuteTemplate(w io.Writer, name string, data interface{}) error
+}
+
+func (dd *DataDictionary) render(w io.Writer, format string, name string, data interface{}) error {
+	var tpl dictTemplate = markdownDictTemplate
+	ext := ".md"
+
+	if format == "html" {
+		tpl = htmlDictTemplate
+		ext = ".html"
+	}
+
+	return tpl.ExecuteTemplate(w, name, struct {
+		Ext  string
+		Data interface{}
+	}{
+		Ext:  ext,
+		Data: data,
+	})
+}
+
+func summaryAndDesc(description []string) (string, string) {
+	if len(description) == 0 {
+		return "", ""
+	}
+	return description[0], strings.Join(description[1:], "\n")
+}
+
+var dictFuncs = map[string]interface{}{
+	"dataType": func(c *DictCol, dialect string) string {
+		return c.DataTypes[dialect]
+	},
+	// cell escapes value for markdown table cell
+	"cell": func(s string) string {
+		return strings.NewReplacer("|", `\|`, "\n", "<br/>").Replace(s)
+	},
+}
+
+var markdownDictTemplate = template.Must(template.New("").Funcs(dictFuncs).Parse(`
+{{- define "index" -}}
+{{- $ext := .Ext -}}
+{{- with .Data -}}
+# {{ .Name }}
+{{ if .Schema }}
+Schema: {{ .Schema }}
+{{ end }}
+| Table | Summary |
+| --- | --- |
+{{ range .Tables -}}
+| [{{ .Name }}]({{ .Name }}{{ $ext }}) | {{ cell .Summary }} |
+{{ end -}}
+{{- end -}}
+{{- end -}}
+
+{{- define "table" -}}
+{{- $ext := .Ext -}}
+{{- with .Data -}}
+{{- $dialects := .Dialects -}}
+{{- with .Table -}}
+# {{ .Name }}
+
+[« index](index{{ $ext }})
+{{ if .Summary }}
+{{ .Summary }}
+{{ end -}}
+{{ if .Desc }}
+{{ .Desc }}
+{{ end }}
+## Columns
+
+| Column |{{ range $dialects }} {{ . }} |{{ end }} Null | Default | Description |
+| --- |{{ range $dialects }} --- |{{ end }} --- | --- | --- |
+{{ range $col := .Cols -}}
+| <a id="{{ $col.Name }}"></a>{{ $col.Name }} |{{ range $dialects }} {{ cell (dataType $col .) }} |{{ end }} {{ if $col.Null }}YES{{ else }}NO{{ end }} | {{ if $col.AutoIncrement }}AUTO_INCREMENT{{ else }}{{ cell $col.Default }}{{ end }} | {{ cell $col.Summary }}{{ if $col.Desc }}<br/>{{ cell $col.Desc }}{{ end }}{{ if $col.Rel }} → [{{ $col.Rel.Table }}.{{ $col.Rel.Col }}]({{ $col.Rel.Table }}{{ $ext }}#{{ $col.Rel.Col }}){{ end }} |
+{{ end -}}
+{{ if .Keys }}
+## Indexes
+
+| Name | Kind | Method | Definition |
+| --- | --- | --- | --- |
+{{ range .Keys -}}
+| {{ .Name }} | {{ .Kind }} | {{ .Method }} | {{ cell .Def }} |
+{{ end -}}
+{{ end -}}
+{{ range $col := .Cols -}}
+{{ if $col.Enum }}
+## Enum of {{ $col.Name }}
+
+| Value | Name | Label |
+| --- | --- | --- |
+{{ range $col.Enum -}}
+| {{ .Value }} | {{ .Name }} | {{ cell .Label }} |
+{{ end -}}
+{{ end -}}
+{{ end -}}
+{{ if .ReferencedBy }}
+## Referenced by
+
+{{ range .ReferencedBy -}}
+* [{{ .Table }}.{{ .Col }}]({{ .Table }}{{ $ext }}#{{ .Col }})
+{{ end -}}
+{{ end -}}
+{{- end -}}
+{{- end -}}
+{{- end -}}
+`))
+
+var htmlDictTemplate = htmltemplate.Must(htmltemplate.New("").Funcs(dictFuncs).Parse(`
+{{- define "index" -}}
+{{- $ext := .Ext -}}
+{{- with .Data -}}
+<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>{{ .Name }}</title></head>
+<body>
+<h1>{{ .Name }}</h1>
+{{ if .Schema }}<p>Schema: {{ .Schema }}</p>{{ end }}
+<table>
+<tr><th>Table</th><th>Summary</th></tr>
+{{ range .Tables -}}
+<tr><td><a href="{{ .Name }}{{ $ext }}">{{ .Name }}</a></td><td>{{ .Summary }}</td></tr>
+{{ end -}}
+</table>
+</body>
+</html>
+{{ end -}}
+{{- end -}}
+
+{{- define "table" -}}
+{{- $ext := .Ext -}}
+{{- with .Data -}}
+{{- $dialects := .Dialects -}}
+{{- with .Table -}}
+<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>{{ .Name }}</title></head>
+<body>
+<h1>{{ .Name }}</h1>
+<p><a href="index{{ $ext }}">« index</a></p>
+{{ if .Summary }}<p>{{ .Summary }}</p>{{ end }}
+{{ if .Desc }}<pre>{{ .Desc }}</pre>{{ end }}
+<h2>Columns</h2>
+<table>
+<tr><th>Column</th>{{ range $dialects }}<th>{{ . }}</th>{{ end }}<th>Null</th><th>Default</th><th>Description</th></tr>
+{{ range $col := .Cols -}}
+<tr id="{{ $col.Name }}"><td>{{ $col.Name }}</td>{{ range $dialects }}<td>{{ dataType $col . }}</td>{{ end }}<td>{{ if $col.Null }}YES{{ else }}NO{{ end }}</td><td>{{ if $col.AutoIncrement }}AUTO_INCREMENT{{ else }}{{ $col.Default }}{{ end }}</td><td>{{ $col.Summary }}{{ if $col.Desc }}<br/>{{ $col.Desc }}{{ end }}{{ if $col.Rel }} → <a href="{{ $col.Rel.Table }}{{ $ext }}#{{ $col.Rel.Col }}">{{ $col.Rel.Table }}.{{ $col.Rel.Col }}</a>{{ end }}</td></tr>
+{{ end -}}
+</table>
+{{ if .Keys -}}
+<h2>Indexes</h2>
+<table>
+<tr><th>Name</th><th>Kind</th><th>Method</th><th>Definition</th></tr>
+{{ range .Keys -}}
+<tr><td>{{ .Name }}</td><td>{{ .Kind }}</td><td>{{ .Method }}</td><td>{{ .Def }}</td></tr>
+{{ end -}}
+</table>
+{{ end -}}
+{{ range $col := .Cols -}}
+{{ if $col.Enum -}}
+<h2>Enum of {{ $col.Name }}</h2>
+<table>
+<tr><th>Value</th><th>Name</th><th>Label</th></tr>
+{{ range $col.Enum -}}
+<tr><td>{{ .Value }}</td><td>{{ .Name }}</td><td>{{ .Label }}</td></tr>
+{{ end -}}
+</table>
+{{ end -}}
+{{ end -}}
+{{ if .ReferencedBy -}}
+<h2>Referenced by</h2>
+<ul>
+{{ range .ReferencedBy -}}
+<li><a href="{{ .Table }}{{ $ext }}#{{ .Col }}">{{ .Table }}.{{ .Col }}</a></li>
+{{ end -}}
+</ul>
+{{ end -}}
+</body>
+</html>
+{{ end -}}
+{{- end -}}
+{{- end -}}
+`))
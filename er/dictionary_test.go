@@ -0,0 +1,199 @@
+package er
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-courier/enumeration"
+	"github.com/go-courier/sqlx/v2"
+	"github.com/go-courier/sqlx/v2/builder"
+	"github.com/go-courier/sqlx/v2/mysqlconnector"
+	"github.com/go-courier/sqlx/v2/postgresqlconnector"
+	"github.com/onsi/gomega"
+)
+
+type Gender int
+
+const (
+	GenderMale Gender = iota + 1
+	GenderFemale
+)
+
+func (Gender) TypeName() string {
+	return "Gender"
+}
+
+func (g Gender) Int() int {
+	return int(g)
+}
+
+func (g Gender) String() string {
+	switch g {
+	case GenderMale:
+		return "MALE"
+	case GenderFemale:
+		return "FEMALE"
+	}
+	return "UNKNOWN"
+}
+
+func (g Gender) Label() string {
+	switch g {
+	case GenderMale:
+		return "男"
+	case GenderFemale:
+		return "女"
+	}
+	return "UNKNOWN"
+}
+
+func (Gender) ConstValues() []enumeration.IntStringerEnum {
+	return []enumeration.IntStringerEnum{GenderMale, GenderFemale}
+}
+
+type User struct {
+	ID     uint64 `db:"f_id,autoincrement"`
+	Name   string `db:"f_name,default=''"`
+	Gender Gender `db:"f_gender,default='0'"`
+}
+
+func (User) TableName() string {
+	return "t_user"
+}
+
+func (User) TableDescription() []string {
+	return []string{"用户", "注册用户"}
+}
+
+func (User) PrimaryKey() []string {
+	return []string{"ID"}
+}
+
+func (User) ColDescriptions() map[string][]string {
+	return map[string][]string{
+		"Name": {"姓名", "a | b"},
+	}
+}
+
+type Org struct {
+	ID     uint64 `db:"f_id,autoincrement"`
+	UserID uint64 `db:"f_user_id,null"`
+}
+
+func (Org) TableName() string {
+	return "t_org"
+}
+
+func (Org) PrimaryKey() []string {
+	return []string{"ID"}
+}
+
+func (Org) UniqueIndexes() map[string][]string {
+	return map[string][]string{"i_user": {"UserID"}}
+}
+
+func (Org) ColRelations() map[string][]string {
+	return map[string][]string{
+		"UserID": {"User", "ID"},
+	}
+}
+
+func TestDataDictionary(t *testing.T) {
+	d := sqlx.NewDatabase("test")
+	d.Register(&User{})
+	d.Register(&Org{})
+
+	dd := DataDictionaryFromDB(d, &mysqlconnector.MysqlConnector{}, &postgresqlconnector.PostgreSQLConnector{})
+
+	t.Run("collect", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(dd.Dialects).To(gomega.Equal([]string{"mysql", "postgres"}))
+		gomega.NewWithT(t).Expect(dd.Tables).To(gomega.HaveLen(2))
+
+		user := dd.Tables[0]
+		gomega.NewWithT(t).Expect(user.Name).To(gomega.Equal("t_user"))
+		gomega.NewWithT(t).Expect(user.Cols[0].DataTypes).To(gomega.Equal(map[string]string{
+			"mysql":    "bigint unsigned",
+			"postgres": "bigserial",
+		}))
+		gomega.NewWithT(t).Expect(user.Cols[2].Enum).To(gomega.HaveLen(2))
+		gomega.NewWithT(t).Expect(user.ReferencedBy).To(gomega.Equal([]DictRef{{Table: "t_org", Col: "f_user_id"}}))
+	})
+
+	t.Run("markdown", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+		err := dd.RenderTable(buf, dd.Tables[0], "markdown")
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(buf.String()).To(gomega.Equal(`# t_user
+
+[« index](index.md)
+
+用户
+
+注册用户
+
+## Columns
+
+| Column | mysql | postgres | Null | Default | Description |
+| --- | --- | --- | --- | --- | --- |
+| <a id="f_id"></a>f_id | bigint unsigned | bigserial | NO | AUTO_INCREMENT |  |
+| <a id="f_name"></a>f_name | varchar(255) | character varying(255) | NO | '' | 姓名<br/>a \| b |
+| <a id="f_gender"></a>f_gender | int | integer | NO | '0' |  |
+
+## Indexes
+
+| Name | Kind | Method | Definition |
+| --- | --- | --- | --- |
+| primary | PRIMARY |  | (f_id) |
+
+## Enum of f_gender
+
+| Value | Name | Label |
+| --- | --- | --- |
+| 1 | MALE | 男 |
+| 2 | FEMALE | 女 |
+
+## Referenced by
+
+* [t_org.f_user_id](t_org.md#f_user_id)
+`))
+
+		buf = bytes.NewBuffer(nil)
+		err = dd.RenderTable(buf, dd.Tables[1], "markdown")
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(buf.String()).To(gomega.ContainSubstring("| <a id=\"f_user_id\"></a>f_user_id | bigint unsigned | bigint | YES |  |  → [t_user.f_id](t_user.md#f_id) |"))
+	})
+
+	t.Run("html", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+		err := dd.RenderTable(buf, dd.Tables[1], "html")
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(buf.String()).To(gomega.ContainSubstring(`<a href="t_user.html#f_id">t_user.f_id</a>`))
+	})
+
+	t.Run("output", func(t *testing.T) {
+		dir := t.TempDir()
+
+		err := dd.Output(dir, "markdown")
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+
+		index, _ := os.ReadFile(filepath.Join(dir, "index.md"))
+		gomega.NewWithT(t).Expect(string(index)).To(gomega.ContainSubstring("| [t_user](t_user.md) | 用户 |"))
+
+		info, err := os.Stat(filepath.Join(dir, "t_org.md"))
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(info.Mode().Perm() &^ 0o644).To(gomega.BeZero())
+	})
+}
+
+func TestKeyCols(t *testing.T) {
+	table := builder.T("t_user",
+		builder.Col("f_name").Field("Name").Type("", ""),
+		builder.Col("f_nickname").Field("Nickname").Type("", ""),
+	)
+
+	gomega.NewWithT(t).Expect(keyCols(table, builder.Index("i_name", nil, "(#Name,#Nickname)"))).To(gomega.Equal([]string{"f_name", "f_nickname"}))
+	gomega.NewWithT(t).Expect(keyCols(table, builder.Index("i_lower_name", nil, "(lower(#Name), coalesce(#Nickname, ','))"))).To(gomega.Equal([]string{"lower(f_name)", "coalesce(f_nickname, ',')"}))
+	gomega.NewWithT(t).Expect(keyCols(table, builder.Index("i_name_length", nil, "(length(#Name)) + (1)"))).To(gomega.Equal([]string{"(length(f_name)) + (1)"}))
+}
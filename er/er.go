@@ -12,7 +12,7 @@ import (
 )
 
 func DatabaseERFromDB(database *sqlx.Database, dialect builder.Dialect) *ERDatabase {
-	erd := &ERDatabase{Name: database.Name, Tables: map[string]*ERTable{}}
+	erd := &ERDatabase{Name: database.Name, Schema: database.Schema, Tables: map[string]*ERTable{}}
 
 	database.Tables.Range(func(table *builder.Table, idx int) {
 		t := &ERTable{Name: table.Name, Cols: map[string]*ERCol{}, Keys: map[string]*ERKey{}}
@@ -33,8 +33,11 @@ func DatabaseERFromDB(database *sqlx.Database, dialect builder.Dialect) *ERDatab
 			}
 
 			c := &ERCol{
-				Name:     col.Name,
-				DataType: builder.ResolveExpr(dialect.DataType(col.ColumnType)).Query(),
+				Name:          col.Name,
+				DataType:      builder.ResolveExpr(dialect.DataType(col.ColumnType)).Query(),
+				Null:          col.Null,
+				Default:       col.Default,
+				AutoIncrement: col.AutoIncrement,
 			}
 
 			if len(col.Description) > 0 {
@@ -45,23 +48,7 @@ func DatabaseERFromDB(database *sqlx.Database, dialect builder.Dialect) *ERDatab
 				}
 			}
 
-			if rv, ok := typex.TryNew(col.ColumnType.Type); ok {
-				if rv.CanInterface() {
-					if emum, ok := rv.Interface().(enumeration.Enum); ok {
-
-						c.Enum = map[string]EREnum{}
-
-						for _, e := range emum.ConstValues() {
-							em := EREnum{}
-							em.Value = e.Int()
-							em.Name = e.String()
-							em.Label = e.Label()
-
-							c.Enum[em.Name] = em
-						}
-					}
-				}
-			}
+			c.Enum = enumsOfColumn(col)
 
 			if len(col.Relation) == 2 {
 				relTable := database.Tables.Model(col.Relation[0])
@@ -84,24 +71,133 @@ func DatabaseERFromDB(database *sqlx.Database, dialect builder.Dialect) *ERDatab
 				Name:      key.Name,
 				Method:    key.Method,
 				IsUnique:  key.IsUnique,
-				IsPrimary: key.Name == "primary",
-			}
-
-			if q := key.Def.TableExpr(table).Ex(context.Background()).Query(); q != "" {
-				// todo rename
-				k.Cols = []string{q}
+				IsPrimary: key.IsPrimary(),
+				Cols:      keyCols(table, key),
 			}
 
 			t.Keys[k.Name] = k
 		})
 	})
 
+	erd.Relations = erd.relations()
+
 	return erd
 }
 
+// keyCols column names of key, or expression parts for functional index
+func keyCols(table *builder.Table, key *builder.Key) []string {
+	if len(key.Def.ColNames) > 0 {
+		return key.Def.ColNames
+	}
+
+	if key.Def.Expr == "" {
+		cols := make([]string, 0, len(key.Def.FieldNames))
+		for _, fieldName := range key.Def.FieldNames {
+			if col := table.F(fieldName); col != nil {
+				cols = append(cols, col.Name)
+			}
+		}
+		return cols
+	}
+
+	q := strings.TrimSpace(key.Def.TableExpr(table).Ex(context.Background()).Query())
+	if q == "" {
+		return nil
+	}
+
+	return splitExprParts(unwrapGroup(q))
+}
+
+// unwrapGroup (a,b) to a,b, but keep (a)+(b)
+func unwrapGroup(q string) string {
+	if !strings.HasPrefix(q, "(") || !strings.HasSuffix(q, ")") {
+		return q
+	}
+
+	depth := 0
+	for i, c := range q {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 && i != len(q)-1 {
+				return q
+			}
+		}
+	}
+
+	return q[1 : len(q)-1]
+}
+
+// splitExprParts split by top level comma
+func splitExprParts(q string) []string {
+	parts := make([]string, 0)
+
+	depth := 0
+	inQuote := false
+	start := 0
+
+	for i, c := range q {
+		switch c {
+		case '\'':
+			inQuote = !inQuote
+		case '(':
+			if !inQuote {
+				depth++
+			}
+		case ')':
+			if !inQuote {
+				depth--
+			}
+		case ',':
+			if !inQuote && depth == 0 {
+				parts = append(parts, strings.TrimSpace(q[start:i]))
+				start = i + 1
+			}
+		}
+	}
+
+	return append(parts, strings.TrimSpace(q[start:]))
+}
+
+func enumsOfColumn(col *builder.Column) map[string]EREnum {
+	if rv, ok := typex.TryNew(col.ColumnType.Type); ok {
+		if rv.CanInterface() {
+			if emum, ok := rv.Interface().(enumeration.Enum); ok {
+				enums := map[string]EREnum{}
+
+				for _, e := range emum.ConstValues() {
+					em := EREnum{}
+					em.Value = e.Int()
+					em.Name = e.String()
+					em.Label = e.Label()
+
+					enums[em.Name] = em
+				}
+
+				return enums
+			}
+		}
+	}
+	return nil
+}
+
 type ERDatabase struct {
-	Name   string              `json:"name"`
-	Tables map[string]*ERTable `json:"tables"`
+	Name      string              `json:"name"`
+	Schema    string              `json:"schema"`
+	Tables    map[string]*ERTable `json:"tables"`
+	Relations []*ERRelation       `json:"relations"`
+}
+
+// ERRelation from column with rel to the referenced column
+type ERRelation struct {
+	From    string `json:"from"`
+	FromCol string `json:"fromCol"`
+	To      string `json:"to"`
+	ToCol   string `json:"toCol"`
+	// OneToOne when the column is unique, otherwise many to one
+	OneToOne bool `json:"oneToOne"`
 }
 
 type ERTable struct {
@@ -113,12 +209,15 @@ type ERTable struct {
 }
 
 type ERCol struct {
-	Name     string            `json:"name"`
-	DataType string            `json:"dataType"`
-	Enum     map[string]EREnum `json:"enum"`
-	Summary  string            `json:"summary"`
-	Desc     string            `json:"desc"`
-	Rel      []string          `json:"rel"`
+	Name          string            `json:"name"`
+	DataType      string            `json:"dataType"`
+	Null          bool              `json:"null"`
+	Default       *string           `json:"default"`
+	AutoIncrement bool              `json:"autoIncrement"`
+	Enum          map[string]EREnum `json:"enum"`
+	Summary       string            `json:"summary"`
+	Desc          string            `json:"desc"`
+	Rel           []string          `json:"rel"`
 }
 
 type EREnum struct {
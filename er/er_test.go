@@ -2,10 +2,12 @@ package er_test
 
 import (
 	"encoding/json"
+	"testing"
 
 	"github.com/go-courier/sqlx/v2/er"
 	"github.com/go-courier/sqlx/v2/generator/__examples__/database"
 	"github.com/go-courier/sqlx/v2/postgresqlconnector"
+	"github.com/onsi/gomega"
 )
 
 func ExampleDatabaseERFromDB() {
@@ -13,3 +15,25 @@ func ExampleDatabaseERFromDB() {
 	_, _ = json.MarshalIndent(ers, "", "  ")
 	// Output:
 }
+
+func TestDatabaseERFromDB(t *testing.T) {
+	erd := er.DatabaseERFromDB(database.DBTest, &postgresqlconnector.PostgreSQLConnector{})
+
+	user := erd.Tables["t_user"]
+
+	gomega.NewWithT(t).Expect(user.Keys["primary"].IsPrimary).To(gomega.BeTrue())
+	gomega.NewWithT(t).Expect(user.Keys["primary"].Cols).To(gomega.Equal([]string{"f_id"}))
+	gomega.NewWithT(t).Expect(user.Keys["i_name"].Cols).To(gomega.Equal([]string{"f_name", "f_deleted_at"}))
+	gomega.NewWithT(t).Expect(user.Keys["i_geom"].Cols).To(gomega.Equal([]string{"f_geom"}))
+
+	gomega.NewWithT(t).Expect(user.Cols["f_id"].AutoIncrement).To(gomega.BeTrue())
+	gomega.NewWithT(t).Expect(*user.Cols["f_name"].Default).To(gomega.Equal("''"))
+	gomega.NewWithT(t).Expect(user.Cols["f_name"].Null).To(gomega.BeFalse())
+
+	gomega.NewWithT(t).Expect(erd.Relations).To(gomega.Equal([]*er.ERRelation{{
+		From:    "t_org",
+		FromCol: "user_id",
+		To:      "t_user",
+		ToCol:   "f_id",
+	}}))
+}
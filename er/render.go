@@ -0,0 +1,323 @@
+package er
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Renderer renders ERDatabase as diagram source
+type Renderer func(w io.Writer, erd *ERDatabase) error
+
+// Renderers of supported formats
+var Renderers = map[string]Renderer{
+	"mermaid":  RenderMermaid,
+	"plantuml": RenderPlantUML,
+	"dot":      RenderDOT,
+}
+
+// RenderMermaid renders ERDatabase as Mermaid erDiagram
+func RenderMermaid(w io.Writer, erd *ERDatabase) error {
+	buf := bytes.NewBufferString("erDiagram\n")
+
+	for _, t := range erd.sortedTables() {
+		buf.WriteString("    " + t.Name + " {\n")
+
+		for _, c := range t.sortedCols() {
+			buf.WriteString("        " + mermaidType(c.dataType()) + " " + c.Name)
+
+			if markers := t.colMarkers(c); len(markers) > 0 {
+				buf.WriteString(" " + strings.Join(markers, ","))
+			}
+
+			if comment := c.comment(); comment != "" {
+				buf.WriteString(" " + strconv.Quote(strings.ReplaceAll(comment, `"`, `'`)))
+			}
+
+			buf.WriteString("\n")
+		}
+
+		buf.WriteString("    }\n")
+	}
+
+	for _, rel := range erd.relations() {
+		card := "||--o{"
+		if rel.OneToOne {
+			card = "||--o|"
+		}
+		buf.WriteString("    " + rel.To + " " + card + " " + rel.From + " : " + strconv.Quote(rel.FromCol) + "\n")
+	}
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// RenderPlantUML renders ERDatabase as PlantUML entities
+func RenderPlantUML(w io.Writer, erd *ERDatabase) error {
+	buf := bytes.NewBufferString("@startuml\n")
+
+	for _, t := range erd.sortedTables() {
+		buf.WriteString("entity " + strconv.Quote(t.Name) + " {\n")
+
+		cols := t.sortedCols()
+		pkCount := 0
+		for _, c := range cols {
+			if t.isPrimaryCol(c.Name) {
+				pkCount++
+			}
+		}
+
+		for i, c := range cols {
+			if pkCount > 0 && i == pkCount {
+				buf.WriteString("  --\n")
+			}
+
+			buf.WriteString("  ")
+			if t.isPrimaryCol(c.Name) {
+				buf.WriteString("* ")
+			}
+			buf.WriteString(c.Name + " : " + c.dataType())
+
+			for _, marker := range t.colMarkers(c) {
+				buf.WriteString(" <<" + marker + ">>")
+			}
+
+			if comment := c.comment(); comment != "" {
+				buf.WriteString(" // " + comment)
+			}
+
+			buf.WriteString("\n")
+		}
+
+		buf.WriteString("}\n")
+	}
+
+	for _, rel := range erd.relations() {
+		card := "||--o{"
+		if rel.OneToOne {
+			card = "||--o|"
+		}
+		buf.WriteString(strconv.Quote(rel.To) + " " + card + " " + strconv.Quote(rel.From) + " : " + rel.FromCol + "\n")
+	}
+
+	buf.WriteString("@enduml\n")
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// RenderDOT renders ERDatabase as Graphviz DOT with html-like labels
+func RenderDOT(w io.Writer, erd *ERDatabase) error {
+	buf := bytes.NewBufferString("digraph " + strconv.Quote(erd.Name) + " {\n")
+	buf.WriteString("  rankdir=LR;\n")
+	buf.WriteString("  node [shape=plaintext];\n")
+
+	for _, t := range erd.sortedTables() {
+		buf.WriteString("  " + strconv.Quote(t.Name) + " [label=<")
+		buf.WriteString(`<table border="0" cellborder="1" cellspacing="0">`)
+		buf.WriteString(`<tr><td bgcolor="lightgray"><b>` + html.EscapeString(t.Name) + `</b></td></tr>`)
+
+		for _, c := range t.sortedCols() {
+			buf.WriteString(`<tr><td port=` + strconv.Quote(c.Name) + ` align="left">`)
+
+			label := c.Name + ": " + c.dataType()
+			if markers := t.colMarkers(c); len(markers) > 0 {
+				label += " [" + strings.Join(markers, ",") + "]"
+			}
+			buf.WriteString(html.EscapeString(label))
+
+			if comment := c.comment(); comment != "" {
+				buf.WriteString(`<br/><i>` + html.EscapeString(comment) + `</i>`)
+			}
+
+			buf.WriteString(`</td></tr>`)
+		}
+
+		buf.WriteString("</table>>];\n")
+	}
+
+	for _, rel := range erd.relations() {
+		arrowtail := "crow"
+		if rel.OneToOne {
+			arrowtail = "teeodot"
+		}
+		buf.WriteString(fmt.Sprintf(
+			"  %s:%s -> %s:%s [dir=both, arrowtail=%s, arrowhead=tee];\n",
+			strconv.Quote(rel.From), strconv.Quote(rel.FromCol),
+			strconv.Quote(rel.To), strconv.Quote(rel.ToCol),
+			arrowtail,
+		))
+	}
+
+	buf.WriteString("}\n")
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func (erd *ERDatabase) sortedTables() []*ERTable {
+	names := make([]string, 0, len(erd.Tables))
+	for name := range erd.Tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	tables := make([]*ERTable, len(names))
+	for i, name := range names {
+		tables[i] = erd.Tables[name]
+	}
+	return tables
+}
+
+// relations from column with Rel to the referenced column,
+// one to one when the column is unique
+func (erd *ERDatabase) relations() []*ERRelation {
+	relations := make([]*ERRelation, 0)
+
+	for _, t := range erd.sortedTables() {
+		for _, c := range t.sortedCols() {
+			if len(c.Rel) != 2 {
+				continue
+			}
+			if _, ok := erd.Tables[c.Rel[0]]; !ok {
+				continue
+			}
+			relations = append(relations, &ERRelation{
+				From:     t.Name,
+				FromCol:  c.Name,
+				To:       c.Rel[0],
+				ToCol:    c.Rel[1],
+				OneToOne: t.isUniqueCol(c.Name),
+			})
+		}
+	}
+
+	return relations
+}
+
+// sortedCols primary cols first, then others by name
+func (t *ERTable) sortedCols() []*ERCol {
+	cols := make([]*ERCol, 0, len(t.Cols))
+	for _, c := range t.Cols {
+		cols = append(cols, c)
+	}
+
+	sort.Slice(cols, func(i, j int) bool {
+		pi, pj := t.isPrimaryCol(cols[i].Name), t.isPrimaryCol(cols[j].Name)
+		if pi != pj {
+			return pi
+		}
+		return cols[i].Name < cols[j].Name
+	})
+
+	return cols
+}
+
+func (t *ERTable) isPrimaryCol(colName string) bool {
+	for _, k := range t.Keys {
+		if k.IsPrimary && k.hasCol(colName) {
+			return true
+		}
+	}
+	return false
+}
+
+// isUniqueCol is true when col is primary or unique key only with the col
+func (t *ERTable) isUniqueCol(colName string) bool {
+	for _, k := range t.Keys {
+		if (k.IsPrimary || k.IsUnique) && len(k.Cols) == 1 && k.hasCol(colName) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *ERTable) colMarkers(c *ERCol) []string {
+	markers := make([]string, 0)
+
+	if t.isPrimaryCol(c.Name) {
+		markers = append(markers, "PK")
+	} else {
+		for _, k := range t.Keys {
+			if k.IsUnique && !k.IsPrimary && k.hasCol(c.Name) {
+				markers = append(markers, "UK")
+				break
+			}
+		}
+	}
+
+	if len(c.Rel) == 2 {
+		markers = append(markers, "FK")
+	}
+
+	return markers
+}
+
+func (k *ERKey) hasCol(colName string) bool {
+	for _, name := range k.Cols {
+		if name == colName {
+			return true
+		}
+	}
+	return false
+}
+
+var dataTypeModifiers = []string{" NOT NULL", " NULL", " DEFAULT ", " AUTO_INCREMENT", " ON UPDATE "}
+
+func (c *ERCol) dataType() string {
+	return dataTypeWithoutModifiers(c.DataType)
+}
+
+func dataTypeWithoutModifiers(dataType string) string {
+	for _, modifier := range dataTypeModifiers {
+		if i := strings.Index(dataType, modifier); i != -1 {
+			dataType = dataType[0:i]
+		}
+	}
+	return dataType
+}
+
+func (c *ERCol) comment() string {
+	comment := c.Summary
+
+	if enums := c.sortedEnums(); len(enums) > 0 {
+		values := make([]string, len(enums))
+		for i, e := range enums {
+			values[i] = strconv.Itoa(e.Value) + ":" + e.Name
+			if e.Label != "" && e.Label != e.Name {
+				values[i] += "(" + e.Label + ")"
+			}
+		}
+
+		if comment != "" {
+			comment += " "
+		}
+		comment += "enum " + strings.Join(values, ", ")
+	}
+
+	return comment
+}
+
+func (c *ERCol) sortedEnums() []EREnum {
+	return sortedEnums(c.Enum)
+}
+
+func sortedEnums(enum map[string]EREnum) []EREnum {
+	enums := make([]EREnum, 0, len(enum))
+	for _, e := range enum {
+		enums = append(enums, e)
+	}
+	sort.Slice(enums, func(i, j int) bool {
+		return enums[i].Value < enums[j].Value
+	})
+	return enums
+}
+
+// mermaid type should be one word
+func mermaidType(dataType string) string {
+	return strings.NewReplacer(" ", "_", ",", "_").Replace(dataType)
+}
@@ -0,0 +1,111 @@
+package er
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func erDatabaseForRender() *ERDatabase {
+	return &ERDatabase{
+		Name: "test",
+		Tables: map[string]*ERTable{
+			"t_user": {
+				Name: "t_user",
+				Cols: map[string]*ERCol{
+					"f_id":   {Name: "f_id", DataType: "bigserial NOT NULL"},
+					"f_name": {Name: "f_name", DataType: "character varying(255) NOT NULL DEFAULT ''::character varying", Summary: "姓名"},
+					"f_gender": {Name: "f_gender", DataType: "integer NOT NULL DEFAULT '0'::integer", Enum: map[string]EREnum{
+						"MALE":   {Value: 1, Name: "MALE", Label: "男"},
+						"FEMALE": {Value: 2, Name: "FEMALE", Label: "女"},
+					}},
+				},
+				Keys: map[string]*ERKey{
+					"primary": {Name: "primary", IsUnique: true, IsPrimary: true, Cols: []string{"f_id"}},
+					"i_name":  {Name: "i_name", IsUnique: true, Cols: []string{"f_name"}},
+				},
+			},
+			"t_org": {
+				Name: "t_org",
+				Cols: map[string]*ERCol{
+					"f_id":      {Name: "f_id", DataType: "bigserial NOT NULL"},
+					"f_user_id": {Name: "f_user_id", DataType: "bigint NOT NULL", Rel: []string{"t_user", "f_id"}},
+				},
+				Keys: map[string]*ERKey{
+					"primary": {Name: "primary", IsUnique: true, IsPrimary: true, Cols: []string{"f_id"}},
+				},
+			},
+			"t_profile": {
+				Name: "t_profile",
+				Cols: map[string]*ERCol{
+					"f_user_id": {Name: "f_user_id", DataType: "bigint NOT NULL", Rel: []string{"t_user", "f_id"}},
+				},
+				Keys: map[string]*ERKey{
+					"i_user": {Name: "i_user", IsUnique: true, Cols: []string{"f_user_id"}},
+				},
+			},
+		},
+	}
+}
+
+func TestRender(t *testing.T) {
+	t.Run("Mermaid", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+		err := RenderMermaid(buf, erDatabaseForRender())
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(buf.String()).To(gomega.Equal(`erDiagram
+    t_org {
+        bigserial f_id PK
+        bigint f_user_id FK
+    }
+    t_profile {
+        bigint f_user_id UK,FK
+    }
+    t_user {
+        bigserial f_id PK
+        integer f_gender "enum 1:MALE(男), 2:FEMALE(女)"
+        character_varying(255) f_name UK "姓名"
+    }
+    t_user ||--o{ t_org : "f_user_id"
+    t_user ||--o| t_profile : "f_user_id"
+`))
+	})
+
+	t.Run("PlantUML", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+		err := RenderPlantUML(buf, erDatabaseForRender())
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(buf.String()).To(gomega.Equal(`@startuml
+entity "t_org" {
+  * f_id : bigserial <<PK>>
+  --
+  f_user_id : bigint <<FK>>
+}
+entity "t_profile" {
+  f_user_id : bigint <<UK>> <<FK>>
+}
+entity "t_user" {
+  * f_id : bigserial <<PK>>
+  --
+  f_gender : integer // enum 1:MALE(男), 2:FEMALE(女)
+  f_name : character varying(255) <<UK>> // 姓名
+}
+"t_user" ||--o{ "t_org" : f_user_id
+"t_user" ||--o| "t_profile" : f_user_id
+@enduml
+`))
+	})
+
+	t.Run("DOT", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+		err := RenderDOT(buf, erDatabaseForRender())
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+
+		dot := buf.String()
+		gomega.NewWithT(t).Expect(dot).To(gomega.HavePrefix("digraph \"test\" {\n"))
+		gomega.NewWithT(t).Expect(dot).To(gomega.ContainSubstring(`<tr><td port="f_name" align="left">f_name: character varying(255) [UK]<br/><i>姓名</i></td></tr>`))
+		gomega.NewWithT(t).Expect(dot).To(gomega.ContainSubstring(`"t_org":"f_user_id" -> "t_user":"f_id" [dir=both, arrowtail=crow, arrowhead=tee];`))
+		gomega.NewWithT(t).Expect(dot).To(gomega.ContainSubstring(`"t_profile":"f_user_id" -> "t_user":"f_id" [dir=both, arrowtail=teeodot, arrowhead=tee];`))
+	})
+}
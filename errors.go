@@ -11,6 +11,16 @@ func NewSqlError(tpe sqlErrType, msg string) *SqlError {
 	}
 }
 
+// NewNotFoundError returns SqlError of NotFound, which could be checked by DBErr(err).IsNotFound()
+func NewNotFoundError(msg string) *SqlError {
+	return NewSqlError(sqlErrTypeNotFound, msg)
+}
+
+// NewConflictError returns SqlError of Conflict, which could be checked by DBErr(err).IsConflict()
+func NewConflictError(msg string) *SqlError {
+	return NewSqlError(sqlErrTypeConflict, msg)
+}
+
 type SqlError struct {
 	Type sqlErrType
 	Msg  string
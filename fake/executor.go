@@ -0,0 +1,73 @@
+package fake
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/go-courier/sqlx/v2"
+	"github.com/go-courier/sqlx/v2/builder"
+)
+
+// ErrUnsupported returned by executor of fake table for calls which need a real database
+var ErrUnsupported = errors.New("unsupported by fake executor")
+
+// Executor returns DBExecutor of fake table, for calling hooks of model in fake repositories.
+// database of executor only contains the table, queries will fail with ErrUnsupported.
+func (t *Table) Executor() sqlx.DBExecutor {
+	d := sqlx.NewDatabase("fake")
+	d.AddTable(t.table)
+
+	return &executor{d: d, ctx: context.Background()}
+}
+
+type executor struct {
+	d   *sqlx.Database
+	ctx context.Context
+}
+
+func (e *executor) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return nil, fmt.Errorf("exec %s: %w", query, ErrUnsupported)
+}
+
+func (e *executor) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return nil, fmt.Errorf("query %s: %w", query, ErrUnsupported)
+}
+
+func (e *executor) ExecExpr(expr builder.SqlExpr) (sql.Result, error) {
+	return nil, fmt.Errorf("exec expr: %w", ErrUnsupported)
+}
+
+func (e *executor) QueryExpr(expr builder.SqlExpr) (*sql.Rows, error) {
+	return nil, fmt.Errorf("query expr: %w", ErrUnsupported)
+}
+
+func (e *executor) QueryExprAndScan(expr builder.SqlExpr, v interface{}) error {
+	return fmt.Errorf("query expr: %w", ErrUnsupported)
+}
+
+func (e *executor) T(model builder.Model) *builder.Table {
+	return e.d.T(model)
+}
+
+// Dialect panics, as there is no database connected
+func (e *executor) Dialect() builder.Dialect {
+	panic(fmt.Errorf("dialect: %w", ErrUnsupported))
+}
+
+func (e *executor) D() *sqlx.Database {
+	return e.d
+}
+
+func (e *executor) WithSchema(schema string) sqlx.DBExecutor {
+	return &executor{d: e.d.WithSchema(schema), ctx: e.ctx}
+}
+
+func (e *executor) Context() context.Context {
+	return e.ctx
+}
+
+func (e *executor) WithContext(ctx context.Context) sqlx.DBExecutor {
+	return &executor{d: e.d, ctx: ctx}
+}
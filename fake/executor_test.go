@@ -0,0 +1,23 @@
+package fake
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-courier/sqlx/v2/builder"
+	"github.com/onsi/gomega"
+)
+
+func TestTable_Executor(t *testing.T) {
+	table := NewTable(&User{})
+	db := table.Executor()
+
+	gomega.NewWithT(t).Expect(db.T(&User{})).To(gomega.Equal(table.T()))
+	gomega.NewWithT(t).Expect(db.D().Table("t_user")).To(gomega.Equal(table.T()))
+
+	_, err := db.ExecExpr(builder.Delete().From(db.T(&User{})))
+	gomega.NewWithT(t).Expect(errors.Is(err, ErrUnsupported)).To(gomega.BeTrue())
+
+	err = db.QueryExprAndScan(builder.Select(nil).From(db.T(&User{})), &[]User{})
+	gomega.NewWithT(t).Expect(errors.Is(err, ErrUnsupported)).To(gomega.BeTrue())
+}
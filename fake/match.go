@@ -0,0 +1,360 @@
+package fake
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-courier/sqlx/v2/builder"
+)
+
+// Match evaluates condition on row, row should be struct value of model.
+//
+// only conditions composed by And, Or, Xor and created by methods of Column are supported, like
+// Eq, Neq, Gt, Gte, Lt, Lte, In, NotIn, Like, NotLike, IsNull, IsNotNull, Between and NotBetween.
+func Match(row reflect.Value, condition builder.SqlCondition) (bool, error) {
+	if builder.IsNilExpr(condition) {
+		return true, nil
+	}
+
+	switch c := condition.(type) {
+	case *builder.ComposedCondition:
+		return matchComposed(row, c)
+	case *builder.Condition:
+		if e, ok := c.Expr().(*builder.Ex); ok {
+			return matchEx(row, e)
+		}
+	}
+
+	return false, unsupported(condition)
+}
+
+func matchComposed(row reflect.Value, c *builder.ComposedCondition) (bool, error) {
+	matched := 0
+	count := 0
+
+	for _, condition := range c.Conditions() {
+		if builder.IsNilExpr(condition) {
+			continue
+		}
+
+		ok, err := Match(row, condition)
+		if err != nil {
+			return false, err
+		}
+
+		count++
+		if ok {
+			matched++
+		}
+	}
+
+	switch c.Op() {
+	case "AND":
+		return matched == count, nil
+	case "OR":
+		return matched > 0, nil
+	case "XOR":
+		return matched%2 == 1, nil
+	}
+
+	return false, unsupported(c)
+}
+
+func matchEx(row reflect.Value, e *builder.Ex) (bool, error) {
+	args := e.Args()
+	if len(args) == 0 {
+		return false, unsupported(e)
+	}
+
+	col, ok := args[0].(*builder.Column)
+	if !ok {
+		return false, unsupported(e)
+	}
+
+	fv := row.FieldByName(col.FieldName)
+	if !fv.IsValid() {
+		return false, fmt.Errorf("missing field %s of %s", col.FieldName, row.Type())
+	}
+
+	v := valueOf(fv.Interface())
+	values := flatten(args[1:])
+
+	query := e.Query()
+
+	switch query {
+	case "? IS NULL":
+		return v == nil, nil
+	case "? IS NOT NULL":
+		return v != nil, nil
+	}
+
+	if v == nil {
+		// NULL never matches comparisons
+		return false, nil
+	}
+
+	switch {
+	case query == "? = ?" && len(values) == 1:
+		return compareBy(v, values[0], func(c int) bool { return c == 0 })
+	case query == "? <> ?" && len(values) == 1:
+		return compareBy(v, values[0], func(c int) bool { return c != 0 })
+	case query == "? > ?" && len(values) == 1:
+		return compareBy(v, values[0], func(c int) bool { return c > 0 })
+	case query == "? >= ?" && len(values) == 1:
+		return compareBy(v, values[0], func(c int) bool { return c >= 0 })
+	case query == "? < ?" && len(values) == 1:
+		return compareBy(v, values[0], func(c int) bool { return c < 0 })
+	case query == "? <= ?" && len(values) == 1:
+		return compareBy(v, values[0], func(c int) bool { return c <= 0 })
+	case query == "? LIKE ?" && len(values) == 1:
+		return like(v, values[0])
+	case query == "? NOT LIKE ?" && len(values) == 1:
+		ok, err := like(v, values[0])
+		return !ok, err
+	case query == "? BETWEEN ? AND ?" && len(values) == 2:
+		return between(v, values[0], values[1])
+	case query == "? NOT BETWEEN ? AND ?" && len(values) == 2:
+		ok, err := between(v, values[0], values[1])
+		return !ok, err
+	case strings.HasPrefix(query, "? IN ("):
+		return in(v, values)
+	case strings.HasPrefix(query, "? NOT IN ("):
+		ok, err := in(v, values)
+		return !ok, err
+	}
+
+	return false, unsupported(e)
+}
+
+func compareBy(a interface{}, b interface{}, check func(c int) bool) (bool, error) {
+	b = valueOf(b)
+	if b == nil {
+		return false, nil
+	}
+	c, ok := compare(a, b)
+	if !ok {
+		return false, fmt.Errorf("cannot compare %T with %T", a, b)
+	}
+	return check(c), nil
+}
+
+func between(v interface{}, left interface{}, right interface{}) (bool, error) {
+	ok, err := compareBy(v, left, func(c int) bool { return c >= 0 })
+	if err != nil || !ok {
+		return false, err
+	}
+	return compareBy(v, right, func(c int) bool { return c <= 0 })
+}
+
+func in(v interface{}, values []interface{}) (bool, error) {
+	for _, value := range values {
+		ok, err := compareBy(v, value, func(c int) bool { return c == 0 })
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func like(v interface{}, pattern interface{}) (bool, error) {
+	s, ok := v.(string)
+	if !ok {
+		return false, fmt.Errorf("cannot match %T by LIKE", v)
+	}
+	p, ok := valueOf(pattern).(string)
+	if !ok {
+		return false, fmt.Errorf("pattern of LIKE must be string, but got %T", pattern)
+	}
+
+	b := strings.Builder{}
+	b.WriteString("^")
+	for _, r := range p {
+		switch r {
+		case '%':
+			b.WriteString(".*")
+		case '_':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString("$")
+
+	return regexp.MustCompile(b.String()).MatchString(s), nil
+}
+
+// flatten expands slices in values like Column.In does, except bytes
+func flatten(values []interface{}) []interface{} {
+	list := make([]interface{}, 0, len(values))
+
+	for _, v := range values {
+		if _, ok := v.(driver.Valuer); !ok {
+			if rv := reflect.ValueOf(v); rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() != reflect.Uint8 {
+				for i := 0; i < rv.Len(); i++ {
+					list = append(list, rv.Index(i).Interface())
+				}
+				continue
+			}
+		}
+		list = append(list, v)
+	}
+
+	return list
+}
+
+// valueOf normalizes v into nil, int64, uint64, float64, bool, string or time.Time when could
+func valueOf(v interface{}) interface{} {
+	if valuer, ok := v.(driver.Valuer); ok {
+		rv := reflect.ValueOf(v)
+		if rv.Kind() == reflect.Ptr && rv.IsNil() {
+			return nil
+		}
+		dv, err := valuer.Value()
+		if err != nil {
+			return v
+		}
+		v = dv
+	}
+
+	rv := reflect.ValueOf(v)
+
+	switch rv.Kind() {
+	case reflect.Invalid:
+		return nil
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return nil
+		}
+		return valueOf(rv.Elem().Interface())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return rv.Uint()
+	case reflect.Float32, reflect.Float64:
+		return rv.Float()
+	case reflect.Bool:
+		return rv.Bool()
+	case reflect.String:
+		return rv.String()
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return string(rv.Bytes())
+		}
+	}
+
+	return v
+}
+
+// compare returns -1, 0 or 1, and false when values not comparable
+func compare(a interface{}, b interface{}) (int, bool) {
+	switch x := a.(type) {
+	case int64:
+		switch y := b.(type) {
+		case int64:
+			return compareInt64(x, y), true
+		case uint64:
+			if x < 0 {
+				return -1, true
+			}
+			return compareUint64(uint64(x), y), true
+		case float64:
+			return compareFloat64(float64(x), y), true
+		}
+	case uint64:
+		switch y := b.(type) {
+		case int64:
+			if y < 0 {
+				return 1, true
+			}
+			return compareUint64(x, uint64(y)), true
+		case uint64:
+			return compareUint64(x, y), true
+		case float64:
+			return compareFloat64(float64(x), y), true
+		}
+	case float64:
+		switch y := b.(type) {
+		case int64:
+			return compareFloat64(x, float64(y)), true
+		case uint64:
+			return compareFloat64(x, float64(y)), true
+		case float64:
+			return compareFloat64(x, y), true
+		}
+	case string:
+		if y, ok := b.(string); ok {
+			return strings.Compare(x, y), true
+		}
+	case bool:
+		if y, ok := b.(bool); ok {
+			if x == y {
+				return 0, true
+			}
+			if !x {
+				return -1, true
+			}
+			return 1, true
+		}
+	case time.Time:
+		if y, ok := b.(time.Time); ok {
+			switch {
+			case x.Before(y):
+				return -1, true
+			case x.After(y):
+				return 1, true
+			}
+			return 0, true
+		}
+	}
+
+	if reflect.TypeOf(a) == reflect.TypeOf(b) && reflect.TypeOf(a).Comparable() && a == b {
+		return 0, true
+	}
+
+	return 0, false
+}
+
+func compareInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareUint64(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareFloat64(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func unsupported(e builder.SqlExpr) error {
+	if ex := builder.ResolveExprContext(context.Background(), e); ex != nil {
+		return fmt.Errorf("unsupported condition in fake: %s", ex.Query())
+	}
+	return fmt.Errorf("unsupported condition in fake: %T", e)
+}
@@ -0,0 +1,396 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/go-courier/sqlx/v2"
+	"github.com/go-courier/sqlx/v2/builder"
+	reflectx "github.com/go-courier/x/reflect"
+)
+
+// NewTable creates in-memory table of model, for faking repositories in unit tests
+func NewTable(model builder.Model) *Table {
+	return &Table{
+		table:     builder.TableFromModel(model),
+		modelType: reflectx.Deref(reflect.TypeOf(model)),
+	}
+}
+
+// Table in-memory rows of model.
+// unique indexes will be honored when inserting or updating, and conditions will be evaluated by Match.
+type Table struct {
+	table     *builder.Table
+	modelType reflect.Type
+
+	mu            sync.RWMutex
+	rows          []reflect.Value
+	autoIncrement uint64
+}
+
+// T returns table definition of model
+func (t *Table) T() *builder.Table {
+	return t.table
+}
+
+// Insert copies m as new row, auto increment field will be set when zero
+func (t *Table) Insert(m builder.Model) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	rv := t.indirect(m)
+
+	row := reflect.New(t.modelType).Elem()
+	row.Set(rv)
+
+	if col := t.table.AutoIncrement(); col != nil {
+		fv := row.FieldByName(col.FieldName)
+
+		switch fv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if fv.Int() == 0 {
+				t.autoIncrement++
+				fv.SetInt(int64(t.autoIncrement))
+			} else if uint64(fv.Int()) > t.autoIncrement {
+				t.autoIncrement = uint64(fv.Int())
+			}
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if fv.Uint() == 0 {
+				t.autoIncrement++
+				fv.SetUint(t.autoIncrement)
+			} else if fv.Uint() > t.autoIncrement {
+				t.autoIncrement = fv.Uint()
+			}
+		}
+	}
+
+	if err := t.checkConflict(row, -1); err != nil {
+		return err
+	}
+
+	t.rows = append(t.rows, row)
+
+	rv.Set(row)
+
+	return nil
+}
+
+// FetchBy fills m with row which has same values of fields named by fieldNames
+func (t *Table) FetchBy(m builder.Model, fieldNames ...string) error {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	rv := t.indirect(m)
+
+	i := t.indexBy(rv, fieldNames)
+	if i == -1 {
+		return sqlx.NewNotFoundError("record is not found")
+	}
+
+	rv.Set(t.rows[i])
+
+	return nil
+}
+
+// UpdateBy sets fieldValues to row which has same values of fields named by fieldNames
+func (t *Table) UpdateBy(m builder.Model, fieldValues builder.FieldValues, fieldNames ...string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	i := t.indexBy(t.indirect(m), fieldNames)
+	if i == -1 {
+		return sqlx.NewNotFoundError("record is not found")
+	}
+
+	row := reflect.New(t.modelType).Elem()
+	row.Set(t.rows[i])
+
+	for fieldName, v := range fieldValues {
+		if err := setField(row, fieldName, v); err != nil {
+			return err
+		}
+	}
+
+	if err := t.checkConflict(row, i); err != nil {
+		return err
+	}
+
+	t.rows[i] = row
+
+	return nil
+}
+
+// DeleteBy removes rows which have same values of fields named by fieldNames
+func (t *Table) DeleteBy(m builder.Model, fieldNames ...string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	rv := t.indirect(m)
+
+	rows := make([]reflect.Value, 0, len(t.rows))
+
+	for _, row := range t.rows {
+		if !fieldsEqual(row, rv, fieldNames) {
+			rows = append(rows, row)
+		}
+	}
+
+	t.rows = rows
+
+	return nil
+}
+
+// List appends rows matched condition into list, list should be ptr of slice of model.
+//
+// only additions created by OrderBy with columns, Limit and Comment are supported
+func (t *Table) List(condition builder.SqlCondition, list interface{}, additions ...builder.Addition) error {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	rv := reflect.ValueOf(list)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("list must be ptr of slice, but got %T", list)
+	}
+
+	rows := make([]reflect.Value, 0)
+
+	for _, row := range t.rows {
+		ok, err := Match(row, condition)
+		if err != nil {
+			return err
+		}
+		if ok {
+			rows = append(rows, row)
+		}
+	}
+
+	rows, err := applyAdditions(rows, additions)
+	if err != nil {
+		return err
+	}
+
+	s := rv.Elem()
+	for _, row := range rows {
+		s = reflect.Append(s, row)
+	}
+	rv.Elem().Set(s)
+
+	return nil
+}
+
+// Count returns count of rows matched condition.
+//
+// only additions created by OrderBy and Comment are supported, which not change the count
+func (t *Table) Count(condition builder.SqlCondition, additions ...builder.Addition) (int, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	for _, addition := range additions {
+		if builder.IsNilExpr(addition) {
+			continue
+		}
+		switch addition.AdditionType() {
+		case builder.AdditionOrderBy, builder.AdditionComment:
+		default:
+			return -1, unsupportedAddition(addition)
+		}
+	}
+
+	count := 0
+
+	for _, row := range t.rows {
+		ok, err := Match(row, condition)
+		if err != nil {
+			return -1, err
+		}
+		if ok {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// applyAdditions sorts rows by OrderBy and picks rows by Limit
+func applyAdditions(rows []reflect.Value, additions []builder.Addition) ([]reflect.Value, error) {
+	var orders []*builder.Order
+	var limit interface {
+		RowCount() int64
+		OffsetCount() int64
+	}
+
+	for _, addition := range additions {
+		if builder.IsNilExpr(addition) {
+			continue
+		}
+
+		switch addition.AdditionType() {
+		case builder.AdditionComment:
+		case builder.AdditionOrderBy:
+			orderBy, ok := addition.(interface{ Orders() []*builder.Order })
+			if !ok {
+				return nil, unsupportedAddition(addition)
+			}
+			orders = append(orders, orderBy.Orders()...)
+		case builder.AdditionLimit:
+			l, ok := addition.(interface {
+				RowCount() int64
+				OffsetCount() int64
+			})
+			if !ok {
+				return nil, unsupportedAddition(addition)
+			}
+			limit = l
+		default:
+			return nil, unsupportedAddition(addition)
+		}
+	}
+
+	if len(orders) > 0 {
+		if err := sortRows(rows, orders); err != nil {
+			return nil, err
+		}
+	}
+
+	if limit != nil {
+		offset := int(limit.OffsetCount())
+		if offset > len(rows) {
+			offset = len(rows)
+		}
+		rows = rows[offset:]
+
+		if n := int(limit.RowCount()); n < len(rows) {
+			rows = rows[0:n]
+		}
+	}
+
+	return rows, nil
+}
+
+// sortRows sorts rows by columns of orders, NULL is less than any values like mysql does
+func sortRows(rows []reflect.Value, orders []*builder.Order) (err error) {
+	fieldNames := make([]string, len(orders))
+	for i, order := range orders {
+		col, ok := order.Target().(*builder.Column)
+		if !ok {
+			return unsupported(order)
+		}
+		fieldNames[i] = col.FieldName
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		for k, fieldName := range fieldNames {
+			a, b := valueOf(rows[i].FieldByName(fieldName).Interface()), valueOf(rows[j].FieldByName(fieldName).Interface())
+
+			c := 0
+			switch {
+			case a == nil && b == nil:
+			case a == nil:
+				c = -1
+			case b == nil:
+				c = 1
+			default:
+				v, ok := compare(a, b)
+				if !ok && err == nil {
+					err = fmt.Errorf("cannot compare %T with %T", a, b)
+				}
+				c = v
+			}
+
+			if c != 0 {
+				if orders[k].Type() == "DESC" {
+					return c > 0
+				}
+				return c < 0
+			}
+		}
+		return false
+	})
+
+	return
+}
+
+func unsupportedAddition(addition builder.Addition) error {
+	if ex := builder.ResolveExprContext(context.Background(), addition); ex != nil {
+		return fmt.Errorf("unsupported addition in fake: %s", ex.Query())
+	}
+	return fmt.Errorf("unsupported addition in fake: %T", addition)
+}
+
+func (t *Table) indirect(m builder.Model) reflect.Value {
+	rv := reflectx.Indirect(reflect.ValueOf(m))
+	if rv.Type() != t.modelType {
+		panic(fmt.Errorf("%T is not model of table %s", m, t.table.Name))
+	}
+	return rv
+}
+
+func (t *Table) indexBy(rv reflect.Value, fieldNames []string) int {
+	for i, row := range t.rows {
+		if fieldsEqual(row, rv, fieldNames) {
+			return i
+		}
+	}
+	return -1
+}
+
+// checkConflict returns Conflict error when any row except the one at skip has same values of fields of unique index
+func (t *Table) checkConflict(row reflect.Value, skip int) (err error) {
+	t.table.Keys.Range(func(key *builder.Key, idx int) {
+		if err != nil || !key.IsUnique || len(key.Def.FieldNames) == 0 {
+			return
+		}
+
+		for i := range t.rows {
+			if i != skip && fieldsEqual(t.rows[i], row, key.Def.FieldNames) {
+				err = sqlx.NewConflictError(fmt.Sprintf("duplicate entry for key %s of %s", key.Name, t.table.Name))
+				return
+			}
+		}
+	})
+	return
+}
+
+func fieldsEqual(a reflect.Value, b reflect.Value, fieldNames []string) bool {
+	for _, fieldName := range fieldNames {
+		va, vb := valueOf(a.FieldByName(fieldName).Interface()), valueOf(b.FieldByName(fieldName).Interface())
+
+		// NULL never equals to others
+		if va == nil || vb == nil {
+			return false
+		}
+
+		if c, ok := compare(va, vb); !ok || c != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func setField(row reflect.Value, fieldName string, v interface{}) error {
+	fv := row.FieldByName(fieldName)
+	if !fv.IsValid() {
+		return fmt.Errorf("missing field %s of %s", fieldName, row.Type())
+	}
+
+	if v == nil {
+		fv.Set(reflect.Zero(fv.Type()))
+		return nil
+	}
+
+	rv := reflect.ValueOf(v)
+
+	switch {
+	case rv.Type().AssignableTo(fv.Type()):
+		fv.Set(rv)
+	case rv.Type().ConvertibleTo(fv.Type()):
+		fv.Set(rv.Convert(fv.Type()))
+	default:
+		return fmt.Errorf("cannot set %T to field %s of %s", v, fieldName, row.Type())
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package fake
+
+import (
+	"testing"
+
+	"github.com/go-courier/sqlx/v2"
+	"github.com/go-courier/sqlx/v2/builder"
+	"github.com/onsi/gomega"
+)
+
+type User struct {
+	ID        uint64 `db:"f_id,autoincrement"`
+	Name      string `db:"f_name"`
+	Age       int    `db:"f_age,null"`
+	DeletedAt int64  `db:"f_deleted_at,default='0'"`
+}
+
+func (User) TableName() string {
+	return "t_user"
+}
+
+func (User) PrimaryKey() []string {
+	return []string{"ID"}
+}
+
+func (User) UniqueIndexes() builder.Indexes {
+	return builder.Indexes{"i_name": {"Name", "DeletedAt"}}
+}
+
+func TestTable(t *testing.T) {
+	table := NewTable(&User{})
+
+	for _, name := range []string{"a", "b", "c"} {
+		u := &User{Name: name, Age: len(name) * 10}
+		gomega.NewWithT(t).Expect(table.Insert(u)).To(gomega.Succeed())
+		gomega.NewWithT(t).Expect(u.ID).NotTo(gomega.BeZero())
+	}
+
+	t.Run("conflict", func(t *testing.T) {
+		err := table.Insert(&User{Name: "a"})
+		gomega.NewWithT(t).Expect(sqlx.DBErr(err).IsConflict()).To(gomega.BeTrue())
+	})
+
+	t.Run("fetch", func(t *testing.T) {
+		u := &User{Name: "b"}
+		gomega.NewWithT(t).Expect(table.FetchBy(u, "Name", "DeletedAt")).To(gomega.Succeed())
+		gomega.NewWithT(t).Expect(u.ID).To(gomega.Equal(uint64(2)))
+
+		err := table.FetchBy(&User{ID: 100}, "ID")
+		gomega.NewWithT(t).Expect(sqlx.DBErr(err).IsNotFound()).To(gomega.BeTrue())
+	})
+
+	t.Run("update", func(t *testing.T) {
+		err := table.UpdateBy(&User{ID: 2}, builder.FieldValues{"Name": "a"}, "ID")
+		gomega.NewWithT(t).Expect(sqlx.DBErr(err).IsConflict()).To(gomega.BeTrue())
+
+		gomega.NewWithT(t).Expect(table.UpdateBy(&User{ID: 2}, builder.FieldValues{"Age": 30}, "ID")).To(gomega.Succeed())
+
+		u := &User{ID: 2}
+		gomega.NewWithT(t).Expect(table.FetchBy(u, "ID")).To(gomega.Succeed())
+		gomega.NewWithT(t).Expect(u.Age).To(gomega.Equal(30))
+	})
+
+	t.Run("list and count", func(t *testing.T) {
+		tbl := table.T()
+
+		list := make([]User, 0)
+		gomega.NewWithT(t).Expect(table.List(
+			builder.Or(
+				tbl.F("Age").Gte(20),
+				tbl.F("Name").In([]string{"a"}),
+			),
+			&list,
+		)).To(gomega.Succeed())
+		gomega.NewWithT(t).Expect(list).To(gomega.HaveLen(2))
+		gomega.NewWithT(t).Expect(list[0].Name).To(gomega.Equal("a"))
+		gomega.NewWithT(t).Expect(list[1].Name).To(gomega.Equal("b"))
+
+		count, err := table.Count(builder.And(
+			tbl.F("Name").Like("c"),
+			tbl.F("Age").Between(5, 10),
+			tbl.F("DeletedAt").Eq(0),
+		))
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(count).To(gomega.Equal(1))
+
+		_, err = table.Count(tbl.F("Name").ContainsAll([]string{"a"}))
+		gomega.NewWithT(t).Expect(err).NotTo(gomega.BeNil())
+	})
+
+	t.Run("list with additions", func(t *testing.T) {
+		tbl := table.T()
+
+		list := make([]User, 0)
+		gomega.NewWithT(t).Expect(table.List(
+			nil,
+			&list,
+			builder.OrderBy(builder.DescOrder(tbl.F("Age")), builder.AscOrder(tbl.F("Name"))),
+			builder.Limit(2).Offset(1),
+			builder.Comment("User.List"),
+		)).To(gomega.Succeed())
+		gomega.NewWithT(t).Expect(list).To(gomega.HaveLen(2))
+		gomega.NewWithT(t).Expect(list[0].Name).To(gomega.Equal("a"))
+		gomega.NewWithT(t).Expect(list[1].Name).To(gomega.Equal("c"))
+
+		err := table.List(nil, &list, builder.GroupBy(tbl.F("Age")))
+		gomega.NewWithT(t).Expect(err).NotTo(gomega.BeNil())
+
+		_, err = table.Count(nil, builder.Limit(1))
+		gomega.NewWithT(t).Expect(err).NotTo(gomega.BeNil())
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(table.DeleteBy(&User{ID: 1}, "ID")).To(gomega.Succeed())
+		gomega.NewWithT(t).Expect(table.Insert(&User{Name: "a"})).To(gomega.Succeed())
+
+		count, _ := table.Count(nil)
+		gomega.NewWithT(t).Expect(count).To(gomega.Equal(3))
+	})
+}
@@ -1,6 +1,13 @@
 package database
 
+import (
+	"fmt"
+
+	"github.com/go-courier/sqlx/v2"
+)
+
 // @def primary ID
+// @def audit
 // organization
 type Org struct {
 	ID   uint64 `db:"f_id,autoincrement"`
@@ -10,3 +17,11 @@ type Org struct {
 	// xxxxx
 	UserID string `db:"user_id"`
 }
+
+// BeforeCreate validates org before inserting
+func (m *Org) BeforeCreate(db sqlx.DBExecutor) error {
+	if m.Name == "" {
+		return fmt.Errorf("name of org is required")
+	}
+	return nil
+}
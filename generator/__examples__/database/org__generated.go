@@ -5,6 +5,7 @@ import (
 
 	github_com_go_courier_sqlx_v2 "github.com/go-courier/sqlx/v2"
 	github_com_go_courier_sqlx_v2_builder "github.com/go-courier/sqlx/v2/builder"
+	github_com_go_courier_sqlx_v2_fake "github.com/go-courier/sqlx/v2/fake"
 )
 
 func (Org) PrimaryKey() []string {
@@ -13,6 +14,10 @@ func (Org) PrimaryKey() []string {
 	}
 }
 
+func (Org) HistoryTableName() string {
+	return "t_org_history"
+}
+
 func (Org) Comments() map[string]string {
 	return map[string]string{
 		"UserID": "关联用户",
@@ -94,6 +99,23 @@ func (m *Org) IndexFieldNames() []string {
 	}
 }
 
+func (m *Org) ScanRow(columns []string) []interface{} {
+	dest := make([]interface{}, len(columns))
+
+	for i := range columns {
+		switch columns[i] {
+		case "f_id", "t_org__f_id":
+			dest[i] = &m.ID
+		case "f_name", "t_org__f_name":
+			dest[i] = &m.Name
+		case "user_id", "t_org__user_id":
+			dest[i] = &m.UserID
+		}
+	}
+
+	return dest
+}
+
 func (m *Org) ConditionByStruct(db github_com_go_courier_sqlx_v2.DBExecutor) github_com_go_courier_sqlx_v2_builder.SqlCondition {
 	table := db.T(m)
 	fieldValues := github_com_go_courier_sqlx_v2_builder.FieldValuesFromStructByNonZero(m)
@@ -122,23 +144,42 @@ func (m *Org) ConditionByStruct(db github_com_go_courier_sqlx_v2.DBExecutor) git
 
 func (m *Org) Create(db github_com_go_courier_sqlx_v2.DBExecutor) error {
 
-	_, err := db.ExecExpr(github_com_go_courier_sqlx_v2.InsertToDB(db, m, nil))
-	return err
+	return github_com_go_courier_sqlx_v2.NewTasks(db).With(
+		func(db github_com_go_courier_sqlx_v2.DBExecutor) error {
 
-}
+			if err := m.BeforeCreate(db); err != nil {
+				return err
+			}
 
-func (m *Org) DeleteByStruct(db github_com_go_courier_sqlx_v2.DBExecutor) error {
+			_, err := db.ExecExpr(github_com_go_courier_sqlx_v2.InsertToDB(db, m, nil))
+			return err
 
-	_, err := db.ExecExpr(
-		github_com_go_courier_sqlx_v2_builder.Delete().
-			From(
-				db.T(m),
-				github_com_go_courier_sqlx_v2_builder.Where(m.ConditionByStruct(db)),
-				github_com_go_courier_sqlx_v2_builder.Comment("Org.DeleteByStruct"),
-			),
-	)
+		},
+		func(db github_com_go_courier_sqlx_v2.DBExecutor) error {
+			return github_com_go_courier_sqlx_v2.WriteHistoryOfCreated(db, m)
+		},
+	).Do()
+}
 
-	return err
+func (m *Org) DeleteByStruct(db github_com_go_courier_sqlx_v2.DBExecutor) error {
+	return github_com_go_courier_sqlx_v2.NewTasks(db).With(
+		func(db github_com_go_courier_sqlx_v2.DBExecutor) error {
+			return github_com_go_courier_sqlx_v2.WriteHistory(db, m, github_com_go_courier_sqlx_v2.AuditOperationDelete, m.ConditionByStruct(db))
+		},
+		func(db github_com_go_courier_sqlx_v2.DBExecutor) error {
+
+			_, err := db.ExecExpr(
+				github_com_go_courier_sqlx_v2_builder.Delete().
+					From(
+						db.T(m),
+						github_com_go_courier_sqlx_v2_builder.Where(m.ConditionByStruct(db)),
+						github_com_go_courier_sqlx_v2_builder.Comment("Org.DeleteByStruct"),
+					),
+			)
+
+			return err
+		},
+	).Do()
 }
 
 func (m *Org) FetchByID(db github_com_go_courier_sqlx_v2.DBExecutor) error {
@@ -161,31 +202,38 @@ func (m *Org) FetchByID(db github_com_go_courier_sqlx_v2.DBExecutor) error {
 }
 
 func (m *Org) UpdateByIDWithMap(db github_com_go_courier_sqlx_v2.DBExecutor, fieldValues github_com_go_courier_sqlx_v2_builder.FieldValues) error {
+	return github_com_go_courier_sqlx_v2.NewTasks(db).With(
+		func(db github_com_go_courier_sqlx_v2.DBExecutor) error {
 
-	table := db.T(m)
+			table := db.T(m)
 
-	result, err := db.ExecExpr(
-		github_com_go_courier_sqlx_v2_builder.Update(db.T(m)).
-			Where(
-				github_com_go_courier_sqlx_v2_builder.And(
-					table.F("ID").Eq(m.ID),
-				),
-				github_com_go_courier_sqlx_v2_builder.Comment("Org.UpdateByIDWithMap"),
-			).
-			Set(table.AssignmentsByFieldValues(fieldValues)...),
-	)
+			result, err := db.ExecExpr(
+				github_com_go_courier_sqlx_v2_builder.Update(db.T(m)).
+					Where(
+						github_com_go_courier_sqlx_v2_builder.And(
+							table.F("ID").Eq(m.ID),
+						),
+						github_com_go_courier_sqlx_v2_builder.Comment("Org.UpdateByIDWithMap"),
+					).
+					Set(table.AssignmentsByFieldValues(fieldValues)...),
+			)
 
-	if err != nil {
-		return err
-	}
+			if err != nil {
+				return err
+			}
 
-	rowsAffected, _ := result.RowsAffected()
-	if rowsAffected == 0 {
-		return m.FetchByID(db)
-	}
+			rowsAffected, _ := result.RowsAffected()
+			if rowsAffected == 0 {
+				return m.FetchByID(db)
+			}
 
-	return nil
+			return nil
 
+		},
+		func(db github_com_go_courier_sqlx_v2.DBExecutor) error {
+			return github_com_go_courier_sqlx_v2.WriteHistoryOfUpdated(db, m, github_com_go_courier_sqlx_v2.AuditOperationUpdate, fieldValues, "ID")
+		},
+	).Do()
 }
 
 func (m *Org) UpdateByIDWithStruct(db github_com_go_courier_sqlx_v2.DBExecutor, zeroFields ...string) error {
@@ -216,19 +264,32 @@ func (m *Org) FetchByIDForUpdate(db github_com_go_courier_sqlx_v2.DBExecutor) er
 }
 
 func (m *Org) DeleteByID(db github_com_go_courier_sqlx_v2.DBExecutor) error {
+	return github_com_go_courier_sqlx_v2.NewTasks(db).With(
+		func(db github_com_go_courier_sqlx_v2.DBExecutor) error {
 
-	table := db.T(m)
+			table := db.T(m)
 
-	_, err := db.ExecExpr(
-		github_com_go_courier_sqlx_v2_builder.Delete().
-			From(db.T(m),
-				github_com_go_courier_sqlx_v2_builder.Where(github_com_go_courier_sqlx_v2_builder.And(
-					table.F("ID").Eq(m.ID),
-				)),
-				github_com_go_courier_sqlx_v2_builder.Comment("Org.DeleteByID"),
+			return github_com_go_courier_sqlx_v2.WriteHistory(db, m, github_com_go_courier_sqlx_v2.AuditOperationDelete, github_com_go_courier_sqlx_v2_builder.And(
+				table.F("ID").Eq(m.ID),
 			))
 
-	return err
+		},
+		func(db github_com_go_courier_sqlx_v2.DBExecutor) error {
+
+			table := db.T(m)
+
+			_, err := db.ExecExpr(
+				github_com_go_courier_sqlx_v2_builder.Delete().
+					From(db.T(m),
+						github_com_go_courier_sqlx_v2_builder.Where(github_com_go_courier_sqlx_v2_builder.And(
+							table.F("ID").Eq(m.ID),
+						)),
+						github_com_go_courier_sqlx_v2_builder.Comment("Org.DeleteByID"),
+					))
+
+			return err
+		},
+	).Do()
 }
 
 func (m *Org) List(db github_com_go_courier_sqlx_v2.DBExecutor, condition github_com_go_courier_sqlx_v2_builder.SqlCondition, additions ...github_com_go_courier_sqlx_v2_builder.Addition) ([]Org, error) {
@@ -298,3 +359,84 @@ func (m *Org) BatchFetchByIDList(db github_com_go_courier_sqlx_v2.DBExecutor, va
 	return m.List(db, condition)
 
 }
+
+type OrgRepository interface {
+	Create(m *Org) error
+	FetchByID(m *Org) error
+	UpdateByIDWithStruct(m *Org, zeroFields ...string) error
+	DeleteByID(m *Org) error
+	List(condition github_com_go_courier_sqlx_v2_builder.SqlCondition, additions ...github_com_go_courier_sqlx_v2_builder.Addition) ([]Org, error)
+	Count(condition github_com_go_courier_sqlx_v2_builder.SqlCondition, additions ...github_com_go_courier_sqlx_v2_builder.Addition) (int, error)
+}
+
+func NewOrgRepository(db github_com_go_courier_sqlx_v2.DBExecutor) OrgRepository {
+	return &orgRepository{db: db}
+}
+
+type orgRepository struct {
+	db github_com_go_courier_sqlx_v2.DBExecutor
+}
+
+func (r *orgRepository) Create(m *Org) error {
+	return m.Create(r.db)
+}
+
+func (r *orgRepository) FetchByID(m *Org) error {
+	return m.FetchByID(r.db)
+}
+
+func (r *orgRepository) UpdateByIDWithStruct(m *Org, zeroFields ...string) error {
+	return m.UpdateByIDWithStruct(r.db, zeroFields...)
+}
+
+func (r *orgRepository) DeleteByID(m *Org) error {
+	return m.DeleteByID(r.db)
+}
+
+func (r *orgRepository) List(condition github_com_go_courier_sqlx_v2_builder.SqlCondition, additions ...github_com_go_courier_sqlx_v2_builder.Addition) ([]Org, error) {
+	return (&Org{}).List(r.db, condition, additions...)
+}
+
+func (r *orgRepository) Count(condition github_com_go_courier_sqlx_v2_builder.SqlCondition, additions ...github_com_go_courier_sqlx_v2_builder.Addition) (int, error) {
+	return (&Org{}).Count(r.db, condition, additions...)
+}
+
+func NewOrgRepositoryFake() OrgRepository {
+	return &orgRepositoryFake{table: github_com_go_courier_sqlx_v2_fake.NewTable(&Org{})}
+}
+
+type orgRepositoryFake struct {
+	table *github_com_go_courier_sqlx_v2_fake.Table
+}
+
+func (r *orgRepositoryFake) Create(m *Org) error {
+
+	if err := m.BeforeCreate(r.table.Executor()); err != nil {
+		return err
+	}
+
+	return r.table.Insert(m)
+}
+
+func (r *orgRepositoryFake) FetchByID(m *Org) error {
+	return r.table.FetchBy(m, "ID")
+}
+
+func (r *orgRepositoryFake) UpdateByIDWithStruct(m *Org, zeroFields ...string) error {
+	fieldValues := github_com_go_courier_sqlx_v2_builder.FieldValuesFromStructByNonZero(m, zeroFields...)
+	return r.table.UpdateBy(m, fieldValues, "ID")
+}
+
+func (r *orgRepositoryFake) DeleteByID(m *Org) error {
+	return r.table.DeleteBy(m, "ID")
+}
+
+func (r *orgRepositoryFake) List(condition github_com_go_courier_sqlx_v2_builder.SqlCondition, additions ...github_com_go_courier_sqlx_v2_builder.Addition) ([]Org, error) {
+	list := make([]Org, 0)
+	err := r.table.List(condition, &list, additions...)
+	return list, err
+}
+
+func (r *orgRepositoryFake) Count(condition github_com_go_courier_sqlx_v2_builder.SqlCondition, additions ...github_com_go_courier_sqlx_v2_builder.Addition) (int, error) {
+	return r.table.Count(condition, additions...)
+}
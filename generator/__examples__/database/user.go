@@ -11,8 +11,10 @@ import (
 // @def index I_username Username
 // @def index I_geom/SPATIAL (#Geom)
 // @def unique_index I_name Name
+// @def unique_index I_uuid UUID
 type User struct {
-	ID uint64 `db:"f_id,autoincrement"`
+	ID   uint64         `db:"f_id,autoincrement"`
+	UUID datatypes.UUID `db:"f_uuid,default=uuidv7"`
 	// 姓名
 	Name      string              `db:"f_name,default=''"`
 	Username  string              `db:"f_username,default=''"`
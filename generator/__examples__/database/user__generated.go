@@ -7,6 +7,7 @@ import (
 	github_com_go_courier_sqlx_v2 "github.com/go-courier/sqlx/v2"
 	github_com_go_courier_sqlx_v2_builder "github.com/go-courier/sqlx/v2/builder"
 	github_com_go_courier_sqlx_v2_datatypes "github.com/go-courier/sqlx/v2/datatypes"
+	github_com_go_courier_sqlx_v2_fake "github.com/go-courier/sqlx/v2/fake"
 )
 
 func (User) PrimaryKey() []string {
@@ -33,12 +34,20 @@ func (User) UniqueIndexIName() string {
 	return "i_name"
 }
 
+func (User) UniqueIndexIUUID() string {
+	return "i_uuid"
+}
+
 func (User) UniqueIndexes() github_com_go_courier_sqlx_v2_builder.Indexes {
 	return github_com_go_courier_sqlx_v2_builder.Indexes{
 		"i_name": []string{
 			"Name",
 			"DeletedAt",
 		},
+		"i_uuid": []string{
+			"UUID",
+			"DeletedAt",
+		},
 	}
 }
 
@@ -85,6 +94,14 @@ func (m *User) FieldID() *github_com_go_courier_sqlx_v2_builder.Column {
 	return UserTable.F(m.FieldKeyID())
 }
 
+func (User) FieldKeyUUID() string {
+	return "UUID"
+}
+
+func (m *User) FieldUUID() *github_com_go_courier_sqlx_v2_builder.Column {
+	return UserTable.F(m.FieldKeyUUID())
+}
+
 func (User) FieldKeyName() string {
 	return "Name"
 }
@@ -166,10 +183,44 @@ func (m *User) IndexFieldNames() []string {
 		"ID",
 		"Name",
 		"Nickname",
+		"UUID",
 		"Username",
 	}
 }
 
+func (m *User) ScanRow(columns []string) []interface{} {
+	dest := make([]interface{}, len(columns))
+
+	for i := range columns {
+		switch columns[i] {
+		case "f_id", "t_user__f_id":
+			dest[i] = &m.ID
+		case "f_uuid", "t_user__f_uuid":
+			dest[i] = &m.UUID
+		case "f_name", "t_user__f_name":
+			dest[i] = &m.Name
+		case "f_username", "t_user__f_username":
+			dest[i] = &m.Username
+		case "f_nickname", "t_user__f_nickname":
+			dest[i] = &m.Nickname
+		case "f_gender", "t_user__f_gender":
+			dest[i] = &m.Gender
+		case "f_boolean", "t_user__f_boolean":
+			dest[i] = &m.Boolean
+		case "f_geom", "t_user__f_geom":
+			dest[i] = &m.Geom
+		case "f_created_at", "t_user__f_created_at":
+			dest[i] = &m.CreatedAt
+		case "f_updated_at", "t_user__f_updated_at":
+			dest[i] = &m.UpdatedAt
+		case "f_deleted_at", "t_user__f_deleted_at":
+			dest[i] = &m.DeletedAt
+		}
+	}
+
+	return dest
+}
+
 func (m *User) ConditionByStruct(db github_com_go_courier_sqlx_v2.DBExecutor) github_com_go_courier_sqlx_v2_builder.SqlCondition {
 	table := db.T(m)
 	fieldValues := github_com_go_courier_sqlx_v2_builder.FieldValuesFromStructByNonZero(m)
@@ -199,6 +250,10 @@ func (m *User) ConditionByStruct(db github_com_go_courier_sqlx_v2.DBExecutor) gi
 
 func (m *User) Create(db github_com_go_courier_sqlx_v2.DBExecutor) error {
 
+	if m.UUID.IsZero() {
+		m.UUID = github_com_go_courier_sqlx_v2_datatypes.UUID(github_com_go_courier_sqlx_v2_datatypes.NewUUIDv7())
+	}
+
 	if m.CreatedAt.IsZero() {
 		m.CreatedAt = github_com_go_courier_sqlx_v2_datatypes.Timestamp(time.Now())
 	}
@@ -218,6 +273,10 @@ func (m *User) CreateOnDuplicateWithUpdateFields(db github_com_go_courier_sqlx_v
 		panic(fmt.Errorf("must have update fields"))
 	}
 
+	if m.UUID.IsZero() {
+		m.UUID = github_com_go_courier_sqlx_v2_datatypes.UUID(github_com_go_courier_sqlx_v2_datatypes.NewUUIDv7())
+	}
+
 	if m.CreatedAt.IsZero() {
 		m.CreatedAt = github_com_go_courier_sqlx_v2_datatypes.Timestamp(time.Now())
 	}
@@ -483,6 +542,71 @@ func (m *User) UpdateByNameWithStruct(db github_com_go_courier_sqlx_v2.DBExecuto
 
 }
 
+func (m *User) UpsertByName(db github_com_go_courier_sqlx_v2.DBExecutor, updateFields []string) error {
+
+	if len(updateFields) == 0 {
+		panic(fmt.Errorf("must have update fields"))
+	}
+
+	if m.UUID.IsZero() {
+		m.UUID = github_com_go_courier_sqlx_v2_datatypes.UUID(github_com_go_courier_sqlx_v2_datatypes.NewUUIDv7())
+	}
+
+	if m.CreatedAt.IsZero() {
+		m.CreatedAt = github_com_go_courier_sqlx_v2_datatypes.Timestamp(time.Now())
+	}
+
+	if m.UpdatedAt.IsZero() {
+		m.UpdatedAt = github_com_go_courier_sqlx_v2_datatypes.Timestamp(time.Now())
+	}
+
+	fieldValues := github_com_go_courier_sqlx_v2_builder.FieldValuesFromStructByNonZero(m, updateFields...)
+
+	delete(fieldValues, "ID")
+
+	table := db.T(m)
+
+	cols, vals := table.ColumnsAndValuesByFieldValues(fieldValues)
+
+	fields := make(map[string]bool, len(updateFields))
+	for _, field := range updateFields {
+		fields[field] = true
+	}
+
+	indexFields, _ := table.Fields("Name", "DeletedAt")
+
+	for _, field := range indexFields.FieldNames() {
+		delete(fields, field)
+	}
+
+	if len(fields) == 0 {
+		panic(fmt.Errorf("no fields for updates"))
+	}
+
+	for field := range fieldValues {
+		if !fields[field] {
+			delete(fieldValues, field)
+		}
+	}
+
+	additions := github_com_go_courier_sqlx_v2_builder.Additions{}
+
+	switch db.Dialect().DriverName() {
+	case "mysql":
+		additions = append(additions, github_com_go_courier_sqlx_v2_builder.OnDuplicateKeyUpdate(table.AssignmentsByFieldValues(fieldValues)...))
+	case "postgres":
+		additions = append(additions,
+			github_com_go_courier_sqlx_v2_builder.OnConflict(indexFields).
+				DoUpdateSet(table.AssignmentsByFieldValues(fieldValues)...))
+	}
+
+	additions = append(additions, github_com_go_courier_sqlx_v2_builder.Comment("User.UpsertByName"))
+
+	_, err := db.ExecExpr(github_com_go_courier_sqlx_v2_builder.Insert().Into(table, additions...).Values(cols, vals...))
+	return err
+
+}
+
 func (m *User) FetchByNameForUpdate(db github_com_go_courier_sqlx_v2.DBExecutor) error {
 
 	table := db.T(m)
@@ -550,6 +674,198 @@ func (m *User) SoftDeleteByName(db github_com_go_courier_sqlx_v2.DBExecutor) err
 
 }
 
+func (m *User) FetchByUUID(db github_com_go_courier_sqlx_v2.DBExecutor) error {
+
+	table := db.T(m)
+
+	err := db.QueryExprAndScan(
+		github_com_go_courier_sqlx_v2_builder.Select(nil).
+			From(
+				db.T(m),
+				github_com_go_courier_sqlx_v2_builder.Where(github_com_go_courier_sqlx_v2_builder.And(
+					table.F("UUID").Eq(m.UUID),
+					table.F("DeletedAt").Eq(m.DeletedAt),
+				)),
+				github_com_go_courier_sqlx_v2_builder.Comment("User.FetchByUUID"),
+			),
+		m,
+	)
+
+	return err
+}
+
+func (m *User) UpdateByUUIDWithMap(db github_com_go_courier_sqlx_v2.DBExecutor, fieldValues github_com_go_courier_sqlx_v2_builder.FieldValues) error {
+
+	if _, ok := fieldValues["UpdatedAt"]; !ok {
+		fieldValues["UpdatedAt"] = github_com_go_courier_sqlx_v2_datatypes.Timestamp(time.Now())
+	}
+
+	table := db.T(m)
+
+	result, err := db.ExecExpr(
+		github_com_go_courier_sqlx_v2_builder.Update(db.T(m)).
+			Where(
+				github_com_go_courier_sqlx_v2_builder.And(
+					table.F("UUID").Eq(m.UUID),
+					table.F("DeletedAt").Eq(m.DeletedAt),
+				),
+				github_com_go_courier_sqlx_v2_builder.Comment("User.UpdateByUUIDWithMap"),
+			).
+			Set(table.AssignmentsByFieldValues(fieldValues)...),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return m.FetchByUUID(db)
+	}
+
+	return nil
+
+}
+
+func (m *User) UpdateByUUIDWithStruct(db github_com_go_courier_sqlx_v2.DBExecutor, zeroFields ...string) error {
+
+	fieldValues := github_com_go_courier_sqlx_v2_builder.FieldValuesFromStructByNonZero(m, zeroFields...)
+	return m.UpdateByUUIDWithMap(db, fieldValues)
+
+}
+
+func (m *User) UpsertByUUID(db github_com_go_courier_sqlx_v2.DBExecutor, updateFields []string) error {
+
+	if len(updateFields) == 0 {
+		panic(fmt.Errorf("must have update fields"))
+	}
+
+	if m.UUID.IsZero() {
+		m.UUID = github_com_go_courier_sqlx_v2_datatypes.UUID(github_com_go_courier_sqlx_v2_datatypes.NewUUIDv7())
+	}
+
+	if m.CreatedAt.IsZero() {
+		m.CreatedAt = github_com_go_courier_sqlx_v2_datatypes.Timestamp(time.Now())
+	}
+
+	if m.UpdatedAt.IsZero() {
+		m.UpdatedAt = github_com_go_courier_sqlx_v2_datatypes.Timestamp(time.Now())
+	}
+
+	fieldValues := github_com_go_courier_sqlx_v2_builder.FieldValuesFromStructByNonZero(m, updateFields...)
+
+	delete(fieldValues, "ID")
+
+	table := db.T(m)
+
+	cols, vals := table.ColumnsAndValuesByFieldValues(fieldValues)
+
+	fields := make(map[string]bool, len(updateFields))
+	for _, field := range updateFields {
+		fields[field] = true
+	}
+
+	indexFields, _ := table.Fields("UUID", "DeletedAt")
+
+	for _, field := range indexFields.FieldNames() {
+		delete(fields, field)
+	}
+
+	if len(fields) == 0 {
+		panic(fmt.Errorf("no fields for updates"))
+	}
+
+	for field := range fieldValues {
+		if !fields[field] {
+			delete(fieldValues, field)
+		}
+	}
+
+	additions := github_com_go_courier_sqlx_v2_builder.Additions{}
+
+	switch db.Dialect().DriverName() {
+	case "mysql":
+		additions = append(additions, github_com_go_courier_sqlx_v2_builder.OnDuplicateKeyUpdate(table.AssignmentsByFieldValues(fieldValues)...))
+	case "postgres":
+		additions = append(additions,
+			github_com_go_courier_sqlx_v2_builder.OnConflict(indexFields).
+				DoUpdateSet(table.AssignmentsByFieldValues(fieldValues)...))
+	}
+
+	additions = append(additions, github_com_go_courier_sqlx_v2_builder.Comment("User.UpsertByUUID"))
+
+	_, err := db.ExecExpr(github_com_go_courier_sqlx_v2_builder.Insert().Into(table, additions...).Values(cols, vals...))
+	return err
+
+}
+
+func (m *User) FetchByUUIDForUpdate(db github_com_go_courier_sqlx_v2.DBExecutor) error {
+
+	table := db.T(m)
+
+	err := db.QueryExprAndScan(
+		github_com_go_courier_sqlx_v2_builder.Select(nil).
+			From(
+				db.T(m),
+				github_com_go_courier_sqlx_v2_builder.Where(github_com_go_courier_sqlx_v2_builder.And(
+					table.F("UUID").Eq(m.UUID),
+					table.F("DeletedAt").Eq(m.DeletedAt),
+				)),
+				github_com_go_courier_sqlx_v2_builder.ForUpdate(),
+				github_com_go_courier_sqlx_v2_builder.Comment("User.FetchByUUIDForUpdate"),
+			),
+		m,
+	)
+
+	return err
+}
+
+func (m *User) DeleteByUUID(db github_com_go_courier_sqlx_v2.DBExecutor) error {
+
+	table := db.T(m)
+
+	_, err := db.ExecExpr(
+		github_com_go_courier_sqlx_v2_builder.Delete().
+			From(db.T(m),
+				github_com_go_courier_sqlx_v2_builder.Where(github_com_go_courier_sqlx_v2_builder.And(
+					table.F("UUID").Eq(m.UUID),
+					table.F("DeletedAt").Eq(m.DeletedAt),
+				)),
+				github_com_go_courier_sqlx_v2_builder.Comment("User.DeleteByUUID"),
+			))
+
+	return err
+}
+
+func (m *User) SoftDeleteByUUID(db github_com_go_courier_sqlx_v2.DBExecutor) error {
+
+	table := db.T(m)
+
+	fieldValues := github_com_go_courier_sqlx_v2_builder.FieldValues{}
+	if _, ok := fieldValues["DeletedAt"]; !ok {
+		fieldValues["DeletedAt"] = github_com_go_courier_sqlx_v2_datatypes.Timestamp(time.Now())
+	}
+
+	if _, ok := fieldValues["UpdatedAt"]; !ok {
+		fieldValues["UpdatedAt"] = github_com_go_courier_sqlx_v2_datatypes.Timestamp(time.Now())
+	}
+
+	_, err := db.ExecExpr(
+		github_com_go_courier_sqlx_v2_builder.Update(db.T(m)).
+			Where(
+				github_com_go_courier_sqlx_v2_builder.And(
+					table.F("UUID").Eq(m.UUID),
+					table.F("DeletedAt").Eq(m.DeletedAt),
+				),
+				github_com_go_courier_sqlx_v2_builder.Comment("User.SoftDeleteByUUID"),
+			).
+			Set(table.AssignmentsByFieldValues(fieldValues)...),
+	)
+
+	return err
+
+}
+
 func (m *User) List(db github_com_go_courier_sqlx_v2.DBExecutor, condition github_com_go_courier_sqlx_v2_builder.SqlCondition, additions ...github_com_go_courier_sqlx_v2_builder.Addition) ([]User, error) {
 
 	list := make([]User, 0)
@@ -650,6 +966,20 @@ func (m *User) BatchFetchByNicknameList(db github_com_go_courier_sqlx_v2.DBExecu
 
 }
 
+func (m *User) BatchFetchByUUIDList(db github_com_go_courier_sqlx_v2.DBExecutor, values []github_com_go_courier_sqlx_v2_datatypes.UUID) ([]User, error) {
+
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	table := db.T(m)
+
+	condition := table.F("UUID").In(values)
+
+	return m.List(db, condition)
+
+}
+
 func (m *User) BatchFetchByUsernameList(db github_com_go_courier_sqlx_v2.DBExecutor, values []string) ([]User, error) {
 
 	if len(values) == 0 {
@@ -663,3 +993,165 @@ func (m *User) BatchFetchByUsernameList(db github_com_go_courier_sqlx_v2.DBExecu
 	return m.List(db, condition)
 
 }
+
+type UserRepository interface {
+	Create(m *User) error
+	FetchByID(m *User) error
+	UpdateByIDWithStruct(m *User, zeroFields ...string) error
+	DeleteByID(m *User) error
+	FetchByName(m *User) error
+	UpdateByNameWithStruct(m *User, zeroFields ...string) error
+	DeleteByName(m *User) error
+	FetchByUUID(m *User) error
+	UpdateByUUIDWithStruct(m *User, zeroFields ...string) error
+	DeleteByUUID(m *User) error
+	List(condition github_com_go_courier_sqlx_v2_builder.SqlCondition, additions ...github_com_go_courier_sqlx_v2_builder.Addition) ([]User, error)
+	Count(condition github_com_go_courier_sqlx_v2_builder.SqlCondition, additions ...github_com_go_courier_sqlx_v2_builder.Addition) (int, error)
+}
+
+func NewUserRepository(db github_com_go_courier_sqlx_v2.DBExecutor) UserRepository {
+	return &userRepository{db: db}
+}
+
+type userRepository struct {
+	db github_com_go_courier_sqlx_v2.DBExecutor
+}
+
+func (r *userRepository) Create(m *User) error {
+	return m.Create(r.db)
+}
+
+func (r *userRepository) FetchByID(m *User) error {
+	return m.FetchByID(r.db)
+}
+
+func (r *userRepository) UpdateByIDWithStruct(m *User, zeroFields ...string) error {
+	return m.UpdateByIDWithStruct(r.db, zeroFields...)
+}
+
+func (r *userRepository) DeleteByID(m *User) error {
+	return m.DeleteByID(r.db)
+}
+
+func (r *userRepository) FetchByName(m *User) error {
+	return m.FetchByName(r.db)
+}
+
+func (r *userRepository) UpdateByNameWithStruct(m *User, zeroFields ...string) error {
+	return m.UpdateByNameWithStruct(r.db, zeroFields...)
+}
+
+func (r *userRepository) DeleteByName(m *User) error {
+	return m.DeleteByName(r.db)
+}
+
+func (r *userRepository) FetchByUUID(m *User) error {
+	return m.FetchByUUID(r.db)
+}
+
+func (r *userRepository) UpdateByUUIDWithStruct(m *User, zeroFields ...string) error {
+	return m.UpdateByUUIDWithStruct(r.db, zeroFields...)
+}
+
+func (r *userRepository) DeleteByUUID(m *User) error {
+	return m.DeleteByUUID(r.db)
+}
+
+func (r *userRepository) List(condition github_com_go_courier_sqlx_v2_builder.SqlCondition, additions ...github_com_go_courier_sqlx_v2_builder.Addition) ([]User, error) {
+	return (&User{}).List(r.db, condition, additions...)
+}
+
+func (r *userRepository) Count(condition github_com_go_courier_sqlx_v2_builder.SqlCondition, additions ...github_com_go_courier_sqlx_v2_builder.Addition) (int, error) {
+	return (&User{}).Count(r.db, condition, additions...)
+}
+
+func NewUserRepositoryFake() UserRepository {
+	return &userRepositoryFake{table: github_com_go_courier_sqlx_v2_fake.NewTable(&User{})}
+}
+
+type userRepositoryFake struct {
+	table *github_com_go_courier_sqlx_v2_fake.Table
+}
+
+func (r *userRepositoryFake) Create(m *User) error {
+
+	if m.UUID.IsZero() {
+		m.UUID = github_com_go_courier_sqlx_v2_datatypes.UUID(github_com_go_courier_sqlx_v2_datatypes.NewUUIDv7())
+	}
+
+	if m.CreatedAt.IsZero() {
+		m.CreatedAt = github_com_go_courier_sqlx_v2_datatypes.Timestamp(time.Now())
+	}
+
+	if m.UpdatedAt.IsZero() {
+		m.UpdatedAt = github_com_go_courier_sqlx_v2_datatypes.Timestamp(time.Now())
+	}
+
+	return r.table.Insert(m)
+}
+
+func (r *userRepositoryFake) FetchByID(m *User) error {
+	return r.table.FetchBy(m, "ID", "DeletedAt")
+}
+
+func (r *userRepositoryFake) UpdateByIDWithStruct(m *User, zeroFields ...string) error {
+	fieldValues := github_com_go_courier_sqlx_v2_builder.FieldValuesFromStructByNonZero(m, zeroFields...)
+
+	if _, ok := fieldValues["UpdatedAt"]; !ok {
+		fieldValues["UpdatedAt"] = github_com_go_courier_sqlx_v2_datatypes.Timestamp(time.Now())
+	}
+
+	return r.table.UpdateBy(m, fieldValues, "ID", "DeletedAt")
+}
+
+func (r *userRepositoryFake) DeleteByID(m *User) error {
+	return r.table.DeleteBy(m, "ID", "DeletedAt")
+}
+
+func (r *userRepositoryFake) FetchByName(m *User) error {
+	return r.table.FetchBy(m, "Name", "DeletedAt")
+}
+
+func (r *userRepositoryFake) UpdateByNameWithStruct(m *User, zeroFields ...string) error {
+	fieldValues := github_com_go_courier_sqlx_v2_builder.FieldValuesFromStructByNonZero(m, zeroFields...)
+
+	if _, ok := fieldValues["UpdatedAt"]; !ok {
+		fieldValues["UpdatedAt"] = github_com_go_courier_sqlx_v2_datatypes.Timestamp(time.Now())
+	}
+
+	return r.table.UpdateBy(m, fieldValues, "Name", "DeletedAt")
+}
+
+func (r *userRepositoryFake) DeleteByName(m *User) error {
+	return r.table.DeleteBy(m, "Name", "DeletedAt")
+}
+
+func (r *userRepositoryFake) FetchByUUID(m *User) error {
+	return r.table.FetchBy(m, "UUID", "DeletedAt")
+}
+
+func (r *userRepositoryFake) UpdateByUUIDWithStruct(m *User, zeroFields ...string) error {
+	fieldValues := github_com_go_courier_sqlx_v2_builder.FieldValuesFromStructByNonZero(m, zeroFields...)
+
+	if _, ok := fieldValues["UpdatedAt"]; !ok {
+		fieldValues["UpdatedAt"] = github_com_go_courier_sqlx_v2_datatypes.Timestamp(time.Now())
+	}
+
+	return r.table.UpdateBy(m, fieldValues, "UUID", "DeletedAt")
+}
+
+func (r *userRepositoryFake) DeleteByUUID(m *User) error {
+	return r.table.DeleteBy(m, "UUID", "DeletedAt")
+}
+
+func (r *userRepositoryFake) List(condition github_com_go_courier_sqlx_v2_builder.SqlCondition, additions ...github_com_go_courier_sqlx_v2_builder.Addition) ([]User, error) {
+	condition = github_com_go_courier_sqlx_v2_builder.And(condition, r.table.T().F("DeletedAt").Eq(0))
+	list := make([]User, 0)
+	err := r.table.List(condition, &list, additions...)
+	return list, err
+}
+
+func (r *userRepositoryFake) Count(condition github_com_go_courier_sqlx_v2_builder.SqlCondition, additions ...github_com_go_courier_sqlx_v2_builder.Addition) (int, error) {
+	condition = github_com_go_courier_sqlx_v2_builder.And(condition, r.table.T().F("DeletedAt").Eq(0))
+	return r.table.Count(condition, additions...)
+}
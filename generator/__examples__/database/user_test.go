@@ -4,6 +4,7 @@ import (
 	"database/sql/driver"
 	"testing"
 
+	"github.com/go-courier/sqlx/v2"
 	"github.com/go-courier/sqlx/v2/builder"
 	"github.com/go-courier/sqlx/v2/migration"
 	"github.com/go-courier/sqlx/v2/mysqlconnector"
@@ -52,6 +53,7 @@ func TestUser(t *testing.T) {
 				errForCreate := user.Create(db)
 				gomega.NewWithT(t).Expect(errForCreate).To(gomega.BeNil())
 				gomega.NewWithT(t).Expect(user.ID, uint64(0))
+				gomega.NewWithT(t).Expect(user.UUID.IsZero()).To(gomega.BeFalse())
 
 				user.Gender = GenderMale
 				{
@@ -154,3 +156,44 @@ func TestUser(t *testing.T) {
 		}
 	})
 }
+
+func TestUserRepositoryFake(t *testing.T) {
+	repo := NewUserRepositoryFake()
+
+	user := &User{Name: "a"}
+	gomega.NewWithT(t).Expect(repo.Create(user)).To(gomega.Succeed())
+	gomega.NewWithT(t).Expect(user.ID).NotTo(gomega.BeZero())
+	gomega.NewWithT(t).Expect(user.UUID.IsZero()).To(gomega.BeFalse())
+
+	err := repo.Create(&User{Name: "a"})
+	gomega.NewWithT(t).Expect(sqlx.DBErr(err).IsConflict()).To(gomega.BeTrue())
+
+	gomega.NewWithT(t).Expect(repo.Create(&User{Name: "b", Gender: GenderFemale})).To(gomega.Succeed())
+
+	{
+		userForUpdate := &User{Name: "a", Gender: GenderMale}
+		gomega.NewWithT(t).Expect(repo.UpdateByNameWithStruct(userForUpdate)).To(gomega.Succeed())
+
+		userForFetch := &User{ID: user.ID}
+		gomega.NewWithT(t).Expect(repo.FetchByID(userForFetch)).To(gomega.Succeed())
+		gomega.NewWithT(t).Expect(userForFetch.Gender).To(gomega.Equal(GenderMale))
+	}
+
+	{
+		list, err := repo.List(user.FieldGender().Eq(GenderFemale))
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(list).To(gomega.HaveLen(1))
+		gomega.NewWithT(t).Expect(list[0].Name).To(gomega.Equal("b"))
+	}
+
+	gomega.NewWithT(t).Expect(repo.DeleteByName(&User{Name: "b"})).To(gomega.Succeed())
+
+	{
+		err := repo.FetchByName(&User{Name: "b"})
+		gomega.NewWithT(t).Expect(sqlx.DBErr(err).IsNotFound()).To(gomega.BeTrue())
+
+		count, err := repo.Count(nil)
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(count).To(gomega.Equal(1))
+	}
+}
@@ -11,6 +11,12 @@ import (
 
 func NewModel(pkg *packagesx.Package, typeName *types.TypeName, comments string, cfg *Config) *Model {
 	m := Model{}
+
+	tableName, comments := parseTableNameFromDoc(comments)
+	if cfg.TableName == "" {
+		cfg.TableName = tableName
+	}
+
 	m.Config = cfg
 	m.Config.SetDefaults()
 
@@ -70,6 +76,14 @@ func NewModel(pkg *packagesx.Package, typeName *types.TypeName, comments string,
 		m.FieldKeyAutoIncrement = autoIncrementCol.FieldName
 	}
 
+	m.Table.Columns.Range(func(col *builder.Column, idx int) {
+		if col.AutoUUID != "" {
+			m.HasAutoUUID = true
+			m.FieldKeyAutoUUID = col.FieldName
+			m.AutoUUIDVersion = col.AutoUUID
+		}
+	})
+
 	return &m
 }
 
@@ -80,10 +94,13 @@ type Model struct {
 	*builder.Table
 	Fields                map[string]*types.Var
 	FieldKeyAutoIncrement string
+	FieldKeyAutoUUID      string
+	AutoUUIDVersion       string
 	HasDeletedAt          bool
 	HasCreatedAt          bool
 	HasUpdatedAt          bool
 	HasAutoIncrement      bool
+	HasAutoUUID           bool
 }
 
 func (m *Model) addColumn(col *builder.Column, tpe *types.Var) {
@@ -110,6 +127,10 @@ func (m *Model) WriteTo(file *codegen.File) {
 		m.WriteList(file)
 		m.WriteCount(file)
 		m.WriteBatchList(file)
+
+		if m.WithRepository {
+			m.WriteRepository(file)
+		}
 	}
 }
 
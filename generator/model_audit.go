@@ -0,0 +1,111 @@
+package generator
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-courier/codegen"
+)
+
+// HistoryTableName returns name of history table of audited model
+func (m *Model) HistoryTableName() string {
+	return m.Table.Name + "_history"
+}
+
+// snippetsInTasksIfNeed joins prepare and body for model not audited and without any of hooks.
+// for model with hooks, body will run as task in transaction, so changes will be rolled back when hook failed.
+// for audited model, body and writing history will run as tasks in same transaction,
+// history will be written before body when historyFirst, like rows should be copied before deleted.
+func (m *Model) snippetsInTasksIfNeed(file *codegen.File, hooks []string, history codegen.Snippet, historyFirst bool, prepare []codegen.Snippet, body ...codegen.Snippet) []codegen.Snippet {
+	task := func(snippets ...codegen.Snippet) codegen.Snippet {
+		return codegen.Func(
+			codegen.Var(codegen.Type(file.Use("github.com/go-courier/sqlx/v2", "DBExecutor")), "db"),
+		).
+			Return(codegen.Var(codegen.Error)).
+			Do(snippets...)
+	}
+
+	if !m.Keys.Audit {
+		for _, hook := range hooks {
+			if m.HasHook(hook) {
+				return append(prepare, codegen.Expr(`return ?(db).With(
+?,
+).Do()`,
+					codegen.Id(file.Use("github.com/go-courier/sqlx/v2", "NewTasks")),
+					task(body...),
+				))
+			}
+		}
+		return append(prepare, body...)
+	}
+
+	tasks := []codegen.Snippet{task(body...), task(history)}
+	if historyFirst {
+		tasks[0], tasks[1] = tasks[1], tasks[0]
+	}
+
+	return append(prepare, codegen.Expr(`return ?(db).With(
+?,
+?,
+).Do()`,
+		codegen.Id(file.Use("github.com/go-courier/sqlx/v2", "NewTasks")),
+		tasks[0],
+		tasks[1],
+	))
+}
+
+// snippetWriteHistoryOfUpdated writes history of row matched by fieldNames with changes of fieldValues
+func (m *Model) snippetWriteHistoryOfUpdated(file *codegen.File, operation string, fieldNames ...string) codegen.Snippet {
+	quotedFieldNames := make([]string, len(fieldNames))
+	for i := range fieldNames {
+		quotedFieldNames[i] = strconv.Quote(fieldNames[i])
+	}
+
+	return codegen.Return(codegen.Expr(
+		`?(db, m, ?, fieldValues, `+strings.Join(quotedFieldNames, ", ")+`)`,
+		codegen.Id(file.Use("github.com/go-courier/sqlx/v2", "WriteHistoryOfUpdated")),
+		codegen.Id(file.Use("github.com/go-courier/sqlx/v2", operation)),
+	))
+}
+
+// snippetWriteHistoryByCondition writes history of rows matched condition
+func (m *Model) snippetWriteHistoryByCondition(file *codegen.File, operation string, condition string) codegen.Snippet {
+	if strings.Contains(condition, "table.") {
+		return codegen.Expr(`
+table := db.T(m)
+
+return ?(db, m, ?, `+condition+`)
+`,
+			codegen.Id(file.Use("github.com/go-courier/sqlx/v2", "WriteHistory")),
+			codegen.Id(file.Use("github.com/go-courier/sqlx/v2", operation)),
+		)
+	}
+
+	return codegen.Return(codegen.Expr(
+		"?(db, m, ?, "+condition+")",
+		codegen.Id(file.Use("github.com/go-courier/sqlx/v2", "WriteHistory")),
+		codegen.Id(file.Use("github.com/go-courier/sqlx/v2", operation)),
+	))
+}
+
+// snippetWriteHistoryOfUpsertedOnDuplicate writes history of row matched any unique index,
+// which could be conflicted by inserting
+func (m *Model) snippetWriteHistoryOfUpsertedOnDuplicate(file *codegen.File) codegen.Snippet {
+	names := make([]string, 0, len(m.Keys.UniqueIndexes))
+	for name := range m.Keys.UniqueIndexes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	conditions := make([]string, len(names))
+	for i, name := range names {
+		conditions[i] = toExactlyConditionFrom(file, m.Keys.UniqueIndexes[name]...)
+	}
+
+	return m.snippetWriteHistoryByCondition(
+		file,
+		"AuditOperationUpsert",
+		file.Use("github.com/go-courier/sqlx/v2/builder", "Or")+"(\n"+strings.Join(conditions, ",\n")+",\n)",
+	)
+}
@@ -0,0 +1,83 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/go-courier/codegen"
+	"github.com/onsi/gomega"
+)
+
+func TestModel_WriteCRUDWithAudit(t *testing.T) {
+	t.Run("not audited", func(t *testing.T) {
+		m := modelWithHooks()
+
+		file := codegen.NewFile("database", "org__generated.go")
+		m.WriteTableKeyInterfaces(file)
+		m.WriteCRUD(file)
+
+		code := string(file.Bytes())
+
+		gomega.NewWithT(t).Expect(code).NotTo(gomega.ContainSubstring("HistoryTableName"))
+		gomega.NewWithT(t).Expect(code).NotTo(gomega.ContainSubstring("NewTasks"))
+	})
+
+	m := modelWithHooks("AfterDelete")
+	m.Keys.Primary = []string{"ID"}
+	m.Keys.Audit = true
+
+	file := codegen.NewFile("database", "org__generated.go")
+	m.WriteTableKeyInterfaces(file)
+	m.WriteCRUD(file)
+
+	code := string(file.Bytes())
+
+	gomega.NewWithT(t).Expect(code).To(gomega.ContainSubstring(`
+func (Org) HistoryTableName() string {
+	return "t_org_history"
+}
+`))
+
+	gomega.NewWithT(t).Expect(code).To(gomega.ContainSubstring(`
+	return github_com_go_courier_sqlx_v2.NewTasks(db).With(
+		func(db github_com_go_courier_sqlx_v2.DBExecutor) error {
+
+			_, err := db.ExecExpr(github_com_go_courier_sqlx_v2.InsertToDB(db, m, nil))
+			return err
+
+		},
+		func(db github_com_go_courier_sqlx_v2.DBExecutor) error {
+			return github_com_go_courier_sqlx_v2.WriteHistoryOfCreated(db, m)
+		},
+	).Do()
+`))
+
+	gomega.NewWithT(t).Expect(code).To(gomega.ContainSubstring(`
+		func(db github_com_go_courier_sqlx_v2.DBExecutor) error {
+			return github_com_go_courier_sqlx_v2.WriteHistoryOfUpdated(db, m, github_com_go_courier_sqlx_v2.AuditOperationUpdate, fieldValues, "ID")
+		},
+`))
+
+	t.Run("history should be written before deleting", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(code).To(gomega.ContainSubstring(`
+func (m *Org) DeleteByID(db github_com_go_courier_sqlx_v2.DBExecutor) error {
+	return github_com_go_courier_sqlx_v2.NewTasks(db).With(
+		func(db github_com_go_courier_sqlx_v2.DBExecutor) error {
+
+			table := db.T(m)
+
+			return github_com_go_courier_sqlx_v2.WriteHistory(db, m, github_com_go_courier_sqlx_v2.AuditOperationDelete, github_com_go_courier_sqlx_v2_builder.And(
+				table.F("ID").Eq(m.ID),
+			))
+
+		},
+`))
+
+		gomega.NewWithT(t).Expect(code).To(gomega.ContainSubstring(`
+			if err != nil {
+				return err
+			}
+
+			return m.AfterDelete(db)
+`))
+	})
+}
@@ -3,6 +3,8 @@ package generator
 import (
 	"bytes"
 	"fmt"
+	"go/types"
+	"strconv"
 	"strings"
 
 	"github.com/go-courier/codegen"
@@ -35,6 +37,69 @@ if m.`+m.FieldKeyCreatedAt+`.IsZero() {
 	return nil
 }
 
+// snippetSetAutoUUIDIfNeed sets uuid when field is zero,
+// field could be datatypes.UUID, or type of string or [16]byte.
+func (m *Model) snippetSetAutoUUIDIfNeed(file *codegen.File) codegen.Snippet {
+	if m.HasAutoUUID {
+		newUUID := "NewUUID"
+		if m.AutoUUIDVersion == "v7" {
+			newUUID = "NewUUIDv7"
+		}
+
+		fieldName := m.FieldKeyAutoUUID
+		typ := m.FieldType(file, fieldName)
+		newUUIDCall := file.Use("github.com/go-courier/sqlx/v2/datatypes", newUUID) + "()"
+
+		zeroCheck := ""
+
+		switch kindOfUUIDField(m.Fields[fieldName]) {
+		case "uuid":
+			zeroCheck = "m." + fieldName + ".IsZero()"
+		case "bytes":
+			zeroCheck = "m." + fieldName + " == (" + string(typ.Bytes()) + "{})"
+		case "string":
+			zeroCheck = "m." + fieldName + ` == ""`
+			newUUIDCall += ".String()"
+		default:
+			panic(fmt.Errorf("field %s of %s with default=uuid should be datatypes.UUID, or type of string or [16]byte", fieldName, m.StructName))
+		}
+
+		return codegen.Expr(`
+if `+zeroCheck+` {
+	m.`+fieldName+` = ?(`+newUUIDCall+`)
+}
+`,
+			typ,
+		)
+	}
+
+	return nil
+}
+
+// kindOfUUIDField returns uuid for type of [16]byte with method IsZero like datatypes.UUID,
+// bytes for other type of [16]byte, string for type of string
+func kindOfUUIDField(field *types.Var) string {
+	if field == nil {
+		return ""
+	}
+
+	switch u := field.Type().Underlying().(type) {
+	case *types.Basic:
+		if u.Kind() == types.String {
+			return "string"
+		}
+	case *types.Array:
+		if b, ok := u.Elem().Underlying().(*types.Basic); ok && u.Len() == 16 && b.Kind() == types.Byte {
+			if obj, _, _ := types.LookupFieldOrMethod(field.Type(), true, field.Pkg(), "IsZero"); obj != nil {
+				return "uuid"
+			}
+			return "bytes"
+		}
+	}
+
+	return ""
+}
+
 func (m *Model) snippetSetUpdatedAtIfNeed(file *codegen.File) codegen.Snippet {
 	if m.HasUpdatedAt {
 		return codegen.Expr(`
@@ -72,15 +137,27 @@ func (m *Model) WriteCreate(file *codegen.File) {
 			MethodOf(codegen.Var(m.PtrType(), "m")).
 			Return(codegen.Var(codegen.Error)).
 			Do(
-				m.snippetSetCreatedAtIfNeed(file),
-				m.snippetSetUpdatedAtIfNeed(file),
+				m.snippetsInTasksIfNeed(
+					file,
+					[]string{"BeforeCreate", "AfterCreate"},
+					codegen.Return(codegen.Expr(
+						"?(db, m)",
+						codegen.Id(file.Use("github.com/go-courier/sqlx/v2", "WriteHistoryOfCreated")),
+					)),
+					false,
+					[]codegen.Snippet{
+						m.snippetSetAutoUUIDIfNeed(file),
+						m.snippetSetCreatedAtIfNeed(file),
+						m.snippetSetUpdatedAtIfNeed(file),
+					},
+					m.snippetCallHookIfNeed("BeforeCreate", "db"),
 
-				codegen.Expr(`
+					codegen.Expr(`
 _, err := db.ExecExpr(?(db, m, nil))
-return err
-`,
-					codegen.Id(file.Use("github.com/go-courier/sqlx/v2", "InsertToDB")),
-				),
+`+m.returnErrWithHookIfNeed("AfterCreate", "db"),
+						codegen.Id(file.Use("github.com/go-courier/sqlx/v2", "InsertToDB")),
+					),
+				)...,
 			),
 	)
 
@@ -94,15 +171,23 @@ return err
 				Named("CreateOnDuplicateWithUpdateFields").
 				MethodOf(codegen.Var(m.PtrType(), "m")).
 				Return(codegen.Var(codegen.Error)).
-				Do(
-					codegen.Expr(`
+				Do(m.snippetsInTasksIfNeed(
+					file,
+					[]string{"BeforeCreate", "AfterCreate"},
+					m.snippetWriteHistoryOfUpsertedOnDuplicate(file),
+					false,
+					[]codegen.Snippet{
+						codegen.Expr(`
 if len(updateFields) == 0 {
-	panic(`+file.Use("fmt", "Errorf")+`("must have update fields"))
+	panic(` + file.Use("fmt", "Errorf") + `("must have update fields"))
 }
 `),
 
-					m.snippetSetCreatedAtIfNeed(file),
-					m.snippetSetUpdatedAtIfNeed(file),
+						m.snippetSetAutoUUIDIfNeed(file),
+						m.snippetSetCreatedAtIfNeed(file),
+						m.snippetSetUpdatedAtIfNeed(file),
+					},
+					m.snippetCallHookIfNeed("BeforeCreate", "db"),
 
 					codegen.Expr(`
 fieldValues := `+file.Use("github.com/go-courier/sqlx/v2/builder", "FieldValuesFromStructByNonZero")+`(m, updateFields...)
@@ -167,12 +252,11 @@ additions = append(additions, `+file.Use("github.com/go-courier/sqlx/v2/builder"
 expr := `+file.Use("github.com/go-courier/sqlx/v2/builder", "Insert")+`().Into(table, additions...).Values(cols, vals...)
 
 _, err := db.ExecExpr(expr)
-return err
-`,
+`+m.returnErrWithHookIfNeed("AfterCreate", "db"),
 						file.Val(m.StructName+".CreateOnDuplicateWithUpdateFields"),
 						file.Val(m.StructName+".CreateOnDuplicateWithUpdateFields"),
 					),
-				),
+				)...),
 		)
 	}
 }
@@ -185,7 +269,15 @@ func (m *Model) WriteDelete(file *codegen.File) {
 			MethodOf(codegen.Var(m.PtrType(), "m")).
 			Return(codegen.Var(codegen.Error)).
 			Do(
-				codegen.Expr(`
+				m.snippetsInTasksIfNeed(
+					file,
+					[]string{"BeforeDelete", "AfterDelete"},
+					m.snippetWriteHistoryByCondition(file, "AuditOperationDelete", "m.ConditionByStruct(db)"),
+					true,
+					nil,
+					m.snippetCallHookIfNeed("BeforeDelete", "db"),
+
+					codegen.Expr(`
 _, err := db.ExecExpr(
 `+file.Use("github.com/go-courier/sqlx/v2/builder", "Delete")+`().
 From(
@@ -197,7 +289,8 @@ From(
 
 `, file.Val(m.StructName+".DeleteByStruct")),
 
-				codegen.Return(codegen.Expr("err")),
+					m.snippetReturnErrWithHookIfNeed("AfterDelete", "db"),
+				)...,
 			),
 	)
 }
@@ -258,8 +351,15 @@ m,
 						Named(methodForUpdateWithMap).
 						MethodOf(codegen.Var(m.PtrType(), "m")).
 						Return(codegen.Var(codegen.Error)).
-						Do(
-							m.snippetSetUpdatedAtIfNeedForFieldValues(file),
+						Do(m.snippetsInTasksIfNeed(
+							file,
+							[]string{"BeforeUpdate", "AfterUpdate"},
+							m.snippetWriteHistoryOfUpdated(file, "AuditOperationUpdate", fieldNames...),
+							false,
+							[]codegen.Snippet{
+								m.snippetSetUpdatedAtIfNeedForFieldValues(file),
+							},
+							m.snippetCallHookIfNeed("BeforeUpdate", "db", "fieldValues"),
 							codegen.Expr(`
 table := db.T(m)
 
@@ -275,17 +375,32 @@ result, err := db.ExecExpr(
 if err != nil {
 	return err
 }
+`,
+								file.Val(m.StructName+"."+methodForUpdateWithMap),
+							),
+							func() codegen.Snippet {
+								if m.HasHook("AfterUpdate") {
+									return codegen.Expr(`
+rowsAffected, _ := result.RowsAffected()
+if rowsAffected == 0 {
+	if err := m.` + methodForFetch + `(db); err != nil {
+		return err
+	}
+}
 
+return m.AfterUpdate(db, fieldValues)
+`)
+								}
+								return codegen.Expr(`
 rowsAffected, _ := result.RowsAffected()
 if rowsAffected == 0 {
-  return m.`+methodForFetch+`(db)
+  return m.` + methodForFetch + `(db)
 }
 
 return nil
-`,
-								file.Val(m.StructName+"."+methodForUpdateWithMap),
-							),
-						),
+`)
+							}(),
+						)...),
 				)
 
 				methodForUpdateWithStruct := createMethod("UpdateBy%sWithStruct", fieldNamesWithoutEnabled...)
@@ -307,6 +422,10 @@ return m.` + methodForUpdateWithMap + `(db, fieldValues)
 				)
 			}
 
+			if m.upsertable(key) {
+				m.writeUpsertByKey(file, key, fieldNamesWithoutEnabled)
+			}
+
 			{
 
 				method := createMethod("FetchBy%sForUpdate", fieldNamesWithoutEnabled...)
@@ -349,7 +468,13 @@ m,
 						Named(methodForDelete).
 						MethodOf(codegen.Var(m.PtrType(), "m")).
 						Return(codegen.Var(codegen.Error)).
-						Do(
+						Do(m.snippetsInTasksIfNeed(
+							file,
+							[]string{"BeforeDelete", "AfterDelete"},
+							m.snippetWriteHistoryByCondition(file, "AuditOperationDelete", toExactlyConditionFrom(file, fieldNames...)),
+							true,
+							nil,
+							m.snippetCallHookIfNeed("BeforeDelete", "db"),
 							codegen.Expr(`
 table := db.T(m)
 
@@ -363,8 +488,8 @@ _, err := db.ExecExpr(
 								file.Val(m.StructName+"."+methodForDelete),
 							),
 
-							codegen.Return(codegen.Expr("err")),
-						),
+							m.snippetReturnErrWithHookIfNeed("AfterDelete", "db"),
+						)...),
 				)
 
 				if m.HasDeletedAt {
@@ -377,14 +502,21 @@ _, err := db.ExecExpr(
 							Named(methodForSoftDelete).
 							MethodOf(codegen.Var(m.PtrType(), "m")).
 							Return(codegen.Var(codegen.Error)).
-							Do(
-								codegen.Expr(`
+							Do(m.snippetsInTasksIfNeed(
+								file,
+								[]string{"BeforeDelete", "AfterDelete"},
+								m.snippetWriteHistoryOfUpdated(file, "AuditOperationDelete", fieldNames...),
+								false,
+								[]codegen.Snippet{
+									codegen.Expr(`
 table := db.T(m)
 
-fieldValues := `+file.Use("github.com/go-courier/sqlx/v2/builder", "FieldValues")+`{}`),
+fieldValues := ` + file.Use("github.com/go-courier/sqlx/v2/builder", "FieldValues") + `{}`),
 
-								m.snippetSetDeletedAtIfNeedForFieldValues(file),
-								m.snippetSetUpdatedAtIfNeedForFieldValues(file),
+									m.snippetSetDeletedAtIfNeedForFieldValues(file),
+									m.snippetSetUpdatedAtIfNeedForFieldValues(file),
+								},
+								m.snippetCallHookIfNeed("BeforeDelete", "db"),
 
 								codegen.Expr(`
 _, err := db.ExecExpr(
@@ -396,9 +528,8 @@ _, err := db.ExecExpr(
 		Set(table.AssignmentsByFieldValues(fieldValues)...),
 )
 
-return err
-`),
-							),
+`+m.returnErrWithHookIfNeed("AfterDelete", "db")),
+							)...),
 					)
 				}
 			}
@@ -406,6 +537,114 @@ return err
 	})
 }
 
+// upsertable returns false for expression keys or keys with auto increment field,
+// which could never be conflicted by inserting
+func (m *Model) upsertable(key *builder.Key) bool {
+	if key.Def.Expr != "" || len(key.Def.FieldNames) == 0 {
+		return false
+	}
+	for _, fieldName := range key.Def.FieldNames {
+		if m.HasAutoIncrement && fieldName == m.FieldKeyAutoIncrement {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *Model) writeUpsertByKey(file *codegen.File, key *builder.Key, fieldNamesWithoutEnabled []string) {
+	method := createMethod("UpsertBy%s", fieldNamesWithoutEnabled...)
+
+	indexFields := make([]string, len(key.Def.FieldNames))
+	for i, fieldName := range key.Def.FieldNames {
+		indexFields[i] = strconv.Quote(fieldName)
+	}
+
+	file.WriteBlock(
+		codegen.Func(
+			codegen.Var(codegen.Type(file.Use("github.com/go-courier/sqlx/v2", "DBExecutor")), "db"),
+			codegen.Var(codegen.Slice(codegen.String), "updateFields"),
+		).
+			Named(method).
+			MethodOf(codegen.Var(m.PtrType(), "m")).
+			Return(codegen.Var(codegen.Error)).
+			Do(m.snippetsInTasksIfNeed(
+				file,
+				[]string{"BeforeCreate", "AfterCreate"},
+				m.snippetWriteHistoryByCondition(file, "AuditOperationUpsert", toExactlyConditionFrom(file, key.Def.FieldNames...)),
+				false,
+				[]codegen.Snippet{
+					codegen.Expr(`
+if len(updateFields) == 0 {
+	panic(` + file.Use("fmt", "Errorf") + `("must have update fields"))
+}
+`),
+
+					m.snippetSetAutoUUIDIfNeed(file),
+					m.snippetSetCreatedAtIfNeed(file),
+					m.snippetSetUpdatedAtIfNeed(file),
+				},
+				m.snippetCallHookIfNeed("BeforeCreate", "db"),
+
+				codegen.Expr(`
+fieldValues := `+file.Use("github.com/go-courier/sqlx/v2/builder", "FieldValuesFromStructByNonZero")+`(m, updateFields...)
+`),
+				func() codegen.Snippet {
+					if m.HasAutoIncrement {
+						return codegen.Expr(
+							`delete(fieldValues, ?)`,
+							file.Val(m.FieldKeyAutoIncrement),
+						)
+					}
+					return nil
+				}(),
+
+				codegen.Expr(`
+table := db.T(m)
+
+cols, vals := table.ColumnsAndValuesByFieldValues(fieldValues)
+
+fields := make(map[string]bool, len(updateFields))
+for _, field := range updateFields {
+	fields[field] = true
+}
+
+indexFields, _ := table.Fields(`+strings.Join(indexFields, ", ")+`)
+
+for _, field := range indexFields.FieldNames() {
+	delete(fields, field)
+}
+
+if len(fields) == 0 {
+	panic(`+file.Use("fmt", "Errorf")+`("no fields for updates"))
+}
+
+for field := range fieldValues {
+	if !fields[field] {
+		delete(fieldValues, field)
+	}
+}
+
+additions := `+file.Use("github.com/go-courier/sqlx/v2/builder", "Additions")+`{}
+
+switch db.Dialect().DriverName() {
+case "mysql":
+	additions = append(additions, `+file.Use("github.com/go-courier/sqlx/v2/builder", "OnDuplicateKeyUpdate")+`(table.AssignmentsByFieldValues(fieldValues)...))
+case "postgres":
+	additions = append(additions,
+		`+file.Use("github.com/go-courier/sqlx/v2/builder", "OnConflict")+`(indexFields).
+			DoUpdateSet(table.AssignmentsByFieldValues(fieldValues)...))
+}
+
+additions = append(additions, `+file.Use("github.com/go-courier/sqlx/v2/builder", "Comment")+`(?))
+
+_, err := db.ExecExpr(`+file.Use("github.com/go-courier/sqlx/v2/builder", "Insert")+`().Into(table, additions...).Values(cols, vals...))
+`+m.returnErrWithHookIfNeed("AfterCreate", "db"),
+					file.Val(m.StructName+"."+method),
+				),
+			)...),
+	)
+}
+
 func (m *Model) WriteCRUD(file *codegen.File) {
 	m.WriteCreate(file)
 	m.WriteDelete(file)
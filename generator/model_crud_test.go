@@ -0,0 +1,74 @@
+package generator
+
+import (
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/go-courier/codegen"
+	"github.com/go-courier/sqlx/v2/builder"
+	"github.com/onsi/gomega"
+)
+
+func TestModel_upsertable(t *testing.T) {
+	m := &Model{
+		Config: &Config{TableName: "t_user", StructName: "User"},
+		Table: builder.T("t_user",
+			builder.Col("f_id").Field("ID").Type(uint64(0), ",autoincrement"),
+			builder.Col("f_name").Field("Name").Type("", ""),
+		),
+		HasAutoIncrement:      true,
+		FieldKeyAutoIncrement: "ID",
+	}
+
+	gomega.NewWithT(t).Expect(m.upsertable(builder.PrimaryKey(m.Table.MustFields("ID")))).To(gomega.BeFalse())
+	gomega.NewWithT(t).Expect(m.upsertable(builder.UniqueIndex("i_name", m.Table.MustFields("Name")))).To(gomega.BeTrue())
+	gomega.NewWithT(t).Expect(m.upsertable(builder.UniqueIndex("i_lower_name", nil, "(LOWER(#Name))"))).To(gomega.BeFalse())
+}
+
+func TestModel_snippetSetAutoUUIDIfNeed(t *testing.T) {
+	pkg := types.NewPackage("github.com/go-courier/sqlx/v2/generator/__examples__/database", "database")
+	datatypesPkg := types.NewPackage("github.com/go-courier/sqlx/v2/datatypes", "datatypes")
+
+	bytes16 := types.NewArray(types.Typ[types.Byte], 16)
+
+	uuid := types.NewNamed(types.NewTypeName(token.NoPos, datatypesPkg, "UUID", nil), bytes16, nil)
+	uuid.AddMethod(types.NewFunc(token.NoPos, datatypesPkg, "IsZero", types.NewSignature(
+		types.NewVar(token.NoPos, datatypesPkg, "u", uuid),
+		nil,
+		types.NewTuple(types.NewVar(token.NoPos, nil, "", types.Typ[types.Bool])),
+		false,
+	)))
+
+	modelWithUUID := func(typ types.Type) *Model {
+		return &Model{
+			TypeName:         types.NewTypeName(token.NoPos, pkg, "User", nil),
+			Config:           &Config{TableName: "t_user", StructName: "User"},
+			Fields:           map[string]*types.Var{"UUID": types.NewVar(token.NoPos, pkg, "UUID", typ)},
+			HasAutoUUID:      true,
+			FieldKeyAutoUUID: "UUID",
+			AutoUUIDVersion:  "v7",
+		}
+	}
+
+	snippet := func(typ types.Type) string {
+		file := codegen.NewFile("database", "user__generated.go")
+		return string(modelWithUUID(typ).snippetSetAutoUUIDIfNeed(file).Bytes())
+	}
+
+	gomega.NewWithT(t).Expect(snippet(uuid)).To(gomega.ContainSubstring(`if m.UUID.IsZero() {
+	m.UUID = github_com_go_courier_sqlx_v2_datatypes.UUID(github_com_go_courier_sqlx_v2_datatypes.NewUUIDv7())
+}`))
+
+	gomega.NewWithT(t).Expect(snippet(types.Typ[types.String])).To(gomega.ContainSubstring(`if m.UUID == "" {
+	m.UUID = string(github_com_go_courier_sqlx_v2_datatypes.NewUUIDv7().String())
+}`))
+
+	gomega.NewWithT(t).Expect(snippet(bytes16)).To(gomega.ContainSubstring(`if m.UUID == ([16]uint8{}) {
+	m.UUID = [16]uint8(github_com_go_courier_sqlx_v2_datatypes.NewUUIDv7())
+}`))
+
+	gomega.NewWithT(t).Expect(func() {
+		snippet(types.Typ[types.Int])
+	}).To(gomega.Panic())
+}
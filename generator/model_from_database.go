@@ -0,0 +1,371 @@
+package generator
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-courier/codegen"
+	"github.com/go-courier/sqlx/v2"
+	"github.com/go-courier/sqlx/v2/builder"
+	"github.com/go-courier/sqlx/v2/datatypes"
+	typex "github.com/go-courier/x/types"
+)
+
+// NewDatabaseModelGenerator create generator of models from database,
+// which could be introspected from connecting database by DBFromInformationSchema of connectors
+func NewDatabaseModelGenerator(pkgName string, database *sqlx.Database, dialect builder.Dialect) *DatabaseModelGenerator {
+	return &DatabaseModelGenerator{
+		PkgName:  pkgName,
+		database: database,
+		dialect:  dialect,
+	}
+}
+
+type DatabaseModelGenerator struct {
+	PkgName  string
+	database *sqlx.Database
+	dialect  builder.Dialect
+	models   []*DatabaseModel
+}
+
+func (g *DatabaseModelGenerator) Scan() {
+	g.models = make([]*DatabaseModel, 0)
+
+	g.database.Tables.Range(func(table *builder.Table, idx int) {
+		g.models = append(g.models, NewDatabaseModel(table, g.dialect))
+	})
+}
+
+func (g *DatabaseModelGenerator) Models() []*DatabaseModel {
+	return g.models
+}
+
+func (g *DatabaseModelGenerator) Output(dir string) {
+	for _, m := range g.models {
+		file := codegen.NewFile(g.PkgName, filepath.Join(dir, codegen.LowerSnakeCase(m.StructName)+".go"))
+		m.WriteTo(file)
+		_, _ = file.WriteFile()
+	}
+}
+
+// NewDatabaseModel resolve struct of table.
+// go type of each column is picked from supported types by comparing data type with dialect,
+// and fallback to string when no one matched.
+func NewDatabaseModel(table *builder.Table, dialect builder.Dialect) *DatabaseModel {
+	m := &DatabaseModel{
+		StructName: structNameFromTableName(table.Name),
+		TableName:  table.Name,
+		Keys:       &Keys{},
+	}
+
+	fieldNames := map[string]string{}
+
+	table.Columns.Range(func(col *builder.Column, idx int) {
+		f := databaseModelFieldFromColumn(col, dialect)
+		fieldNames[col.Name] = f.FieldName
+		m.Fields = append(m.Fields, f)
+	})
+
+	table.Keys.Range(func(key *builder.Key, idx int) {
+		defs := indexDefsFromKey(key, fieldNames)
+		if len(defs) == 0 {
+			return
+		}
+
+		if key.IsPrimary() {
+			m.Keys.Primary = defs
+			return
+		}
+
+		id := key.Name
+		if method := strings.ToUpper(key.Method); method != "" && method != "BTREE" {
+			id += "/" + method
+		}
+
+		if key.IsUnique {
+			if m.Keys.UniqueIndexes == nil {
+				m.Keys.UniqueIndexes = builder.Indexes{}
+			}
+			m.Keys.UniqueIndexes[id] = defs
+			return
+		}
+
+		if m.Keys.Indexes == nil {
+			m.Keys.Indexes = builder.Indexes{}
+		}
+		m.Keys.Indexes[id] = defs
+	})
+
+	return m
+}
+
+type DatabaseModel struct {
+	StructName string
+	TableName  string
+	Keys       *Keys
+	Fields     []*DatabaseModelField
+}
+
+type DatabaseModelField struct {
+	FieldName  string
+	ColumnName string
+	Type       reflect.Type
+	TagValue   string
+	// Unresolved notes why column could not be presented exactly
+	Unresolved  string
+	Description []string
+}
+
+// Table of model, as same as the table defined by the generated struct
+func (m *DatabaseModel) Table() *builder.Table {
+	t := builder.T(m.TableName)
+
+	for _, f := range m.Fields {
+		t.AddCol(builder.Col(f.ColumnName).Field(f.FieldName).Type(reflect.New(f.Type).Elem().Interface(), f.TagValue))
+	}
+
+	m.Keys.Bind(t)
+
+	return t
+}
+
+func (m *DatabaseModel) WriteTo(file *codegen.File) {
+	lines := []string{m.StructName}
+
+	if toDefaultTableName(m.StructName) != m.TableName {
+		lines = append(lines, "@table "+m.TableName)
+	}
+
+	lines = append(lines, m.defLines()...)
+
+	fields := make([]*codegen.SnippetField, 0, len(m.Fields))
+
+	for _, f := range m.Fields {
+		comments := f.Description
+		if f.Unresolved != "" {
+			comments = append(append([]string{}, comments...), "TODO "+f.Unresolved)
+		}
+
+		fields = append(fields,
+			codegen.Var(file.TypeOf(f.Type), f.FieldName).
+				WithTag(`db:`+strconv.Quote(f.TagValue)).
+				WithComments(comments...),
+		)
+	}
+
+	_, _ = file.Write(codegen.Comments(lines...).Bytes())
+
+	file.WriteBlock(
+		codegen.DeclType(
+			codegen.Var(codegen.Struct(fields...), m.StructName),
+		),
+	)
+}
+
+func (m *DatabaseModel) defLines() []string {
+	lines := make([]string, 0)
+
+	if len(m.Keys.Primary) > 0 {
+		lines = append(lines, "@def primary "+strings.Join(m.Keys.Primary, " "))
+	}
+
+	for _, kind := range []struct {
+		name    string
+		indexes builder.Indexes
+	}{
+		{"unique_index", m.Keys.UniqueIndexes},
+		{"index", m.Keys.Indexes},
+	} {
+		ids := make([]string, 0, len(kind.indexes))
+		for id := range kind.indexes {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+
+		for _, id := range ids {
+			lines = append(lines, "@def "+kind.name+" "+id+" "+strings.Join(kind.indexes[id], " "))
+		}
+	}
+
+	return lines
+}
+
+var reColNameInExpr = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_]*`)
+
+func indexDefsFromKey(key *builder.Key, fieldNames map[string]string) []string {
+	if len(key.Def.ColNames) > 0 {
+		defs := make([]string, 0, len(key.Def.ColNames))
+		for _, colName := range key.Def.ColNames {
+			fieldName, ok := fieldNames[colName]
+			if !ok {
+				return nil
+			}
+			defs = append(defs, fieldName)
+		}
+		return defs
+	}
+
+	if len(key.Def.FieldNames) > 0 {
+		return key.Def.FieldNames
+	}
+
+	expr := strings.TrimSpace(key.Def.Expr)
+	if expr == "" {
+		return nil
+	}
+
+	// (f_a,f_b)
+	if strings.HasPrefix(expr, "(") && strings.HasSuffix(expr, ")") {
+		defs := make([]string, 0)
+		for _, colName := range strings.Split(expr[1:len(expr)-1], ",") {
+			fieldName, ok := fieldNames[strings.TrimSpace(colName)]
+			if !ok {
+				defs = nil
+				break
+			}
+			defs = append(defs, fieldName)
+		}
+		if len(defs) > 0 {
+			return defs
+		}
+	}
+
+	return []string{reColNameInExpr.ReplaceAllStringFunc(expr, func(s string) string {
+		if fieldName, ok := fieldNames[s]; ok {
+			return "#" + fieldName
+		}
+		return s
+	})}
+}
+
+var databaseModelFieldTypes = []reflect.Type{
+	reflect.TypeOf(uint64(0)),
+	reflect.TypeOf(int64(0)),
+	reflect.TypeOf(uint32(0)),
+	reflect.TypeOf(int32(0)),
+	reflect.TypeOf(uint16(0)),
+	reflect.TypeOf(int16(0)),
+	reflect.TypeOf(uint8(0)),
+	reflect.TypeOf(int8(0)),
+	reflect.TypeOf(false),
+	reflect.TypeOf(float64(0)),
+	reflect.TypeOf(float32(0)),
+	reflect.TypeOf(""),
+	reflect.TypeOf([]byte(nil)),
+	reflect.TypeOf(datatypes.Decimal{}),
+	reflect.TypeOf(datatypes.UUID{}),
+	reflect.TypeOf(datatypes.BinaryUUID{}),
+	reflect.TypeOf(datatypes.Datetime{}),
+	reflect.TypeOf(time.Time{}),
+	reflect.TypeOf(datatypes.Point{}),
+	reflect.TypeOf(datatypes.LineString{}),
+	reflect.TypeOf(datatypes.Polygon{}),
+	reflect.TypeOf(datatypes.StringArray{}),
+	reflect.TypeOf(datatypes.Int64Array{}),
+	reflect.TypeOf(datatypes.Int32Array{}),
+	reflect.TypeOf(datatypes.Float64Array{}),
+	reflect.TypeOf(datatypes.Float32Array{}),
+	reflect.TypeOf(datatypes.BoolArray{}),
+}
+
+func databaseModelFieldFromColumn(col *builder.Column, dialect builder.Dialect) *DatabaseModelField {
+	f := &DatabaseModelField{
+		FieldName:   fieldNameFromColumnName(col.Name),
+		ColumnName:  col.Name,
+		Description: col.Description,
+	}
+
+	if len(f.Description) == 0 && col.Comment != "" {
+		f.Description = []string{col.Comment}
+	}
+
+	flags := make([]string, 0)
+
+	if col.AutoIncrement {
+		flags = append(flags, "autoincrement")
+	}
+	if col.Null {
+		flags = append(flags, "null")
+	}
+	if col.Default != nil {
+		if isTagSafeValue(*col.Default) {
+			flags = append(flags, "default="+*col.Default)
+		} else {
+			f.Unresolved = "default " + *col.Default + " could not be defined in tag"
+		}
+	}
+	if col.OnUpdate != nil {
+		if isTagSafeValue(*col.OnUpdate) {
+			flags = append(flags, "onupdate="+*col.OnUpdate)
+		} else {
+			f.Unresolved = "onupdate " + *col.OnUpdate + " could not be defined in tag"
+		}
+	}
+
+	sizeFlagsList := [][]string{nil}
+	if col.Length > 0 {
+		sizeFlags := []string{"size=" + strconv.FormatUint(col.Length, 10)}
+		if col.Decimal > 0 {
+			sizeFlags = append(sizeFlags, "decimal="+strconv.FormatUint(col.Decimal, 10))
+		}
+		sizeFlagsList = append(sizeFlagsList, sizeFlags)
+	}
+	if strings.Contains(strings.ToLower(col.DataType), "text") {
+		sizeFlagsList = append(sizeFlagsList, []string{"size=65535"})
+	}
+
+	dataType := dataTypeOf(dialect, col.ColumnType)
+
+	for _, typ := range databaseModelFieldTypes {
+		for _, sizeFlags := range sizeFlagsList {
+			tagValue := strings.Join(append(append([]string{col.Name}, sizeFlags...), flags...), ",")
+
+			if dataTypeOf(dialect, builder.ColumnTypeFromTypeAndTag(typex.FromRType(typ), tagValue)) == dataType {
+				f.Type = typ
+				f.TagValue = tagValue
+				return f
+			}
+		}
+	}
+
+	f.Type = reflect.TypeOf("")
+	f.TagValue = strings.Join(append([]string{col.Name}, flags...), ",")
+	if f.Unresolved == "" {
+		f.Unresolved = "data type " + col.DataType + " is not supported"
+	}
+
+	return f
+}
+
+func dataTypeOf(dialect builder.Dialect, columnType *builder.ColumnType) (dataType string) {
+	// unsupported types will panic
+	defer func() {
+		if e := recover(); e != nil {
+			dataType = ""
+		}
+	}()
+	return dialect.DataType(columnType).Ex(context.Background()).Query()
+}
+
+// value will be split by , and = when parsing tag
+func isTagSafeValue(v string) bool {
+	return !strings.ContainsAny(v, ",=`")
+}
+
+func structNameFromTableName(tableName string) string {
+	return codegen.UpperCamelCase(strings.TrimPrefix(strings.ToLower(tableName), "t_"))
+}
+
+func fieldNameFromColumnName(colName string) string {
+	name := codegen.UpperCamelCase(strings.TrimPrefix(strings.ToLower(colName), "f_"))
+	if name == "" || ('0' <= name[0] && name[0] <= '9') {
+		return "F" + name
+	}
+	return name
+}
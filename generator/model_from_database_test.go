@@ -0,0 +1,167 @@
+package generator
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-courier/sqlx/v2/builder"
+	"github.com/go-courier/sqlx/v2/datatypes"
+	"github.com/go-courier/sqlx/v2/mysqlconnector"
+	"github.com/go-courier/sqlx/v2/postgresqlconnector"
+	"github.com/onsi/gomega"
+)
+
+func liveCol(name string, dataType string, fn func(col *builder.Column)) *builder.Column {
+	col := builder.Col(name)
+	col.DataType = dataType
+	if fn != nil {
+		fn(col)
+	}
+	return col
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestNewDatabaseModel(t *testing.T) {
+	t.Run("mysql", func(t *testing.T) {
+		dialect := &mysqlconnector.MysqlConnector{}
+
+		table := builder.T("t_user")
+		table.AddCol(liveCol("f_id", "bigint unsigned", func(col *builder.Column) {
+			col.AutoIncrement = true
+			col.Length = 20
+		}))
+		table.AddCol(liveCol("f_uuid", "char", func(col *builder.Column) {
+			col.Length = 36
+		}))
+		table.AddCol(liveCol("f_name", "varchar", func(col *builder.Column) {
+			col.Length = 255
+			col.Default = strPtr("''")
+			col.Comment = "姓名"
+			col.Description = []string{"姓名"}
+		}))
+		table.AddCol(liveCol("f_intro", "text", func(col *builder.Column) {
+			col.Length = 65535
+			col.Null = true
+		}))
+		table.AddCol(liveCol("f_balance", "decimal", func(col *builder.Column) {
+			col.Length = 20
+			col.Decimal = 4
+			col.Default = strPtr("'0'")
+		}))
+		table.AddCol(liveCol("f_location", "point SRID 4326", nil))
+		table.AddCol(liveCol("f_updated_at", "timestamp", func(col *builder.Column) {
+			col.Default = strPtr("CURRENT_TIMESTAMP")
+			col.OnUpdate = strPtr("CURRENT_TIMESTAMP")
+		}))
+
+		table.AddKey(&builder.Key{Name: "primary", IsUnique: true, Method: "BTREE", Def: builder.IndexDef{ColNames: []string{"f_id"}}})
+		table.AddKey(&builder.Key{Name: "i_uuid", IsUnique: true, Method: "BTREE", Def: builder.IndexDef{ColNames: []string{"f_uuid"}}})
+		table.AddKey(&builder.Key{Name: "i_name", Method: "BTREE", Def: builder.IndexDef{ColNames: []string{"f_name", "f_updated_at"}}})
+		table.AddKey(&builder.Key{Name: "i_location", Method: "SPATIAL", Def: builder.IndexDef{ColNames: []string{"f_location"}}})
+
+		m := NewDatabaseModel(table, dialect)
+
+		gomega.NewWithT(t).Expect(m.StructName).To(gomega.Equal("User"))
+		gomega.NewWithT(t).Expect(m.defLines()).To(gomega.Equal([]string{
+			"@def primary ID",
+			"@def unique_index i_uuid UUID",
+			"@def index i_location/SPATIAL Location",
+			"@def index i_name Name UpdatedAt",
+		}))
+
+		fieldTypes := map[string]reflect.Type{}
+		fieldTags := map[string]string{}
+		for _, f := range m.Fields {
+			fieldTypes[f.FieldName] = f.Type
+			fieldTags[f.FieldName] = f.TagValue
+		}
+
+		gomega.NewWithT(t).Expect(fieldTypes).To(gomega.Equal(map[string]reflect.Type{
+			"ID":        reflect.TypeOf(uint64(0)),
+			"UUID":      reflect.TypeOf(datatypes.UUID{}),
+			"Name":      reflect.TypeOf(""),
+			"Intro":     reflect.TypeOf(""),
+			"Balance":   reflect.TypeOf(datatypes.Decimal{}),
+			"Location":  reflect.TypeOf(datatypes.Point{}),
+			"UpdatedAt": reflect.TypeOf(datatypes.Datetime{}),
+		}))
+
+		gomega.NewWithT(t).Expect(fieldTags["Intro"]).To(gomega.Equal("f_intro,size=65535,null"))
+		gomega.NewWithT(t).Expect(fieldTags["Balance"]).To(gomega.Equal("f_balance,size=20,decimal=4,default='0'"))
+		gomega.NewWithT(t).Expect(fieldTags["UpdatedAt"]).To(gomega.Equal("f_updated_at,default=CURRENT_TIMESTAMP,onupdate=CURRENT_TIMESTAMP"))
+
+		gomega.NewWithT(t).Expect(m.Table().Diff(table, dialect)).To(gomega.HaveLen(0))
+	})
+
+	t.Run("postgres", func(t *testing.T) {
+		dialect := &postgresqlconnector.PostgreSQLConnector{}
+
+		table := builder.T("users")
+		table.AddCol(liveCol("f_id", "bigserial", func(col *builder.Column) {
+			col.AutoIncrement = true
+		}))
+		table.AddCol(liveCol("f_name", "character varying", func(col *builder.Column) {
+			col.Length = 255
+			col.Default = strPtr("''::character varying")
+		}))
+		table.AddCol(liveCol("f_tags", "character varying[]", func(col *builder.Column) {
+			col.Null = true
+		}))
+		table.AddCol(liveCol("f_amount", "numeric", func(col *builder.Column) {
+			col.Length = 20
+			col.Decimal = 4
+		}))
+		table.AddCol(liveCol("f_location", "geometry(Point,4326)", nil))
+		table.AddCol(liveCol("f_created_at", "timestamp with time zone", nil))
+		table.AddCol(liveCol("f_extra", "jsonb", nil))
+
+		table.AddKey(&builder.Key{Name: "pkey", IsUnique: true, Method: "BTREE", Def: builder.IndexDef{Expr: "(f_id)"}})
+		table.AddKey(&builder.Key{Name: "i_name", IsUnique: true, Method: "BTREE", Def: builder.IndexDef{Expr: "(f_name,f_id)"}})
+		table.AddKey(&builder.Key{Name: "i_lower_name", Method: "BTREE", Def: builder.IndexDef{Expr: "(lower((f_name)::text))"}})
+		table.AddKey(&builder.Key{Name: "i_location", Method: "GIST", Def: builder.IndexDef{Expr: "(f_location)"}})
+
+		m := NewDatabaseModel(table, dialect)
+
+		gomega.NewWithT(t).Expect(m.StructName).To(gomega.Equal("Users"))
+		gomega.NewWithT(t).Expect(m.defLines()).To(gomega.Equal([]string{
+			"@def primary ID",
+			"@def unique_index i_name Name ID",
+			"@def index i_location/GIST Location",
+			"@def index i_lower_name (lower((#Name)::text))",
+		}))
+
+		fieldTypes := map[string]reflect.Type{}
+		for _, f := range m.Fields {
+			fieldTypes[f.FieldName] = f.Type
+		}
+
+		gomega.NewWithT(t).Expect(fieldTypes).To(gomega.Equal(map[string]reflect.Type{
+			"ID":        reflect.TypeOf(uint64(0)),
+			"Name":      reflect.TypeOf(""),
+			"Tags":      reflect.TypeOf(datatypes.StringArray{}),
+			"Amount":    reflect.TypeOf(datatypes.Decimal{}),
+			"Location":  reflect.TypeOf(datatypes.Point{}),
+			"CreatedAt": reflect.TypeOf(time.Time{}),
+			"Extra":     reflect.TypeOf(""),
+		}))
+
+		extra := m.Fields[len(m.Fields)-1]
+		gomega.NewWithT(t).Expect(extra.Unresolved).To(gomega.Equal("data type jsonb is not supported"))
+
+		// only unsupported column should be fixed by hand
+		gomega.NewWithT(t).Expect(m.Table().Diff(table, dialect)).To(gomega.HaveLen(1))
+	})
+}
+
+func TestParseTableNameFromDoc(t *testing.T) {
+	tableName, others := parseTableNameFromDoc(`User
+@table users
+@def primary ID`)
+
+	gomega.NewWithT(t).Expect(tableName).To(gomega.Equal("users"))
+	gomega.NewWithT(t).Expect(others).To(gomega.Equal("User\n@def primary ID"))
+}
@@ -0,0 +1,97 @@
+package generator
+
+import (
+	"go/types"
+	"strings"
+
+	"github.com/go-courier/codegen"
+)
+
+// HasHook returns true when ptr of model implements interface of hook, like sqlx.WithBeforeCreate for BeforeCreate
+func (m *Model) HasHook(hook string) bool {
+	if m.TypeName == nil {
+		return false
+	}
+
+	iface := hookInterface(m.TypeName.Pkg(), hook)
+	if iface == nil {
+		return false
+	}
+
+	return types.Implements(types.NewPointer(m.TypeName.Type()), iface)
+}
+
+// hookInterface finds interface of hook from sqlx imported by pkg,
+// nil means no sqlx imported, and model could not implement any hook
+func hookInterface(pkg *types.Package, hook string) *types.Interface {
+	sqlxPkg := importedPackage(pkg, "github.com/go-courier/sqlx/v2", map[*types.Package]bool{})
+	if sqlxPkg == nil {
+		return nil
+	}
+
+	obj := sqlxPkg.Scope().Lookup("With" + hook)
+	if obj == nil {
+		return nil
+	}
+
+	iface, _ := obj.Type().Underlying().(*types.Interface)
+	return iface
+}
+
+func importedPackage(pkg *types.Package, path string, visited map[*types.Package]bool) *types.Package {
+	if pkg == nil || visited[pkg] {
+		return nil
+	}
+	visited[pkg] = true
+
+	if pkg.Path() == path {
+		return pkg
+	}
+
+	for _, imported := range pkg.Imports() {
+		if found := importedPackage(imported, path, visited); found != nil {
+			return found
+		}
+	}
+
+	return nil
+}
+
+// snippetCallHookIfNeed calls hook and returns its error
+func (m *Model) snippetCallHookIfNeed(hook string, args ...string) codegen.Snippet {
+	if m.HasHook(hook) {
+		return codegen.Expr(`
+if err := m.` + hook + `(` + strings.Join(args, ", ") + `); err != nil {
+	return err
+}
+`)
+	}
+	return nil
+}
+
+// snippetReturnErrWithHookIfNeed returns err, or result of hook when err is nil
+func (m *Model) snippetReturnErrWithHookIfNeed(hook string, args ...string) codegen.Snippet {
+	if m.HasHook(hook) {
+		return codegen.Expr(`
+if err != nil {
+	return err
+}
+
+return m.` + hook + `(` + strings.Join(args, ", ") + `)
+`)
+	}
+	return codegen.Return(codegen.Expr("err"))
+}
+
+// returnErrWithHookIfNeed same as snippetReturnErrWithHookIfNeed, but as code for tail of expr
+func (m *Model) returnErrWithHookIfNeed(hook string, args ...string) string {
+	if m.HasHook(hook) {
+		return `if err != nil {
+	return err
+}
+
+return m.` + hook + `(` + strings.Join(args, ", ") + `)
+`
+	}
+	return "return err\n"
+}
@@ -0,0 +1,157 @@
+package generator
+
+import (
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+
+	"github.com/go-courier/codegen"
+	"github.com/go-courier/sqlx/v2/builder"
+	"github.com/onsi/gomega"
+)
+
+func TestModel_HasHook(t *testing.T) {
+	m := modelWithHooks("BeforeCreate", "AfterUpdate")
+
+	gomega.NewWithT(t).Expect(m.HasHook("BeforeCreate")).To(gomega.BeTrue())
+	gomega.NewWithT(t).Expect(m.HasHook("AfterUpdate")).To(gomega.BeTrue())
+	gomega.NewWithT(t).Expect(m.HasHook("AfterCreate")).To(gomega.BeFalse())
+
+	gomega.NewWithT(t).Expect((&Model{}).HasHook("BeforeCreate")).To(gomega.BeFalse())
+
+	t.Run("method with wrong signature", func(t *testing.T) {
+		m := modelWithHooks()
+
+		named := m.TypeName.Type().(*types.Named)
+		recv := types.NewVar(token.NoPos, m.TypeName.Pkg(), "m", types.NewPointer(named))
+		named.AddMethod(types.NewFunc(token.NoPos, m.TypeName.Pkg(), "BeforeUpdate", types.NewSignature(recv, nil, nil, false)))
+
+		gomega.NewWithT(t).Expect(m.HasHook("BeforeUpdate")).To(gomega.BeFalse())
+	})
+}
+
+func TestModel_WriteCRUDWithHooks(t *testing.T) {
+	m := modelWithHooks("BeforeCreate", "AfterCreate", "BeforeUpdate", "AfterUpdate", "BeforeDelete", "AfterDelete")
+
+	file := codegen.NewFile("database", "org__generated.go")
+	m.WriteCRUD(file)
+
+	code := string(file.Bytes())
+
+	gomega.NewWithT(t).Expect(code).To(gomega.ContainSubstring(`
+	return github_com_go_courier_sqlx_v2.NewTasks(db).With(
+		func(db github_com_go_courier_sqlx_v2.DBExecutor) error {
+
+			if err := m.BeforeCreate(db); err != nil {
+				return err
+			}
+
+			_, err := db.ExecExpr(github_com_go_courier_sqlx_v2.InsertToDB(db, m, nil))
+			if err != nil {
+				return err
+			}
+
+			return m.AfterCreate(db)
+`))
+
+	gomega.NewWithT(t).Expect(code).To(gomega.ContainSubstring(`
+			if err := m.BeforeUpdate(db, fieldValues); err != nil {
+				return err
+			}
+`))
+
+	gomega.NewWithT(t).Expect(code).To(gomega.ContainSubstring(`
+			rowsAffected, _ := result.RowsAffected()
+			if rowsAffected == 0 {
+				if err := m.FetchByID(db); err != nil {
+					return err
+				}
+			}
+
+			return m.AfterUpdate(db, fieldValues)
+`))
+
+	gomega.NewWithT(t).Expect(code).To(gomega.ContainSubstring(`
+			if err := m.BeforeDelete(db); err != nil {
+				return err
+			}
+`))
+
+	gomega.NewWithT(t).Expect(code).To(gomega.ContainSubstring(`
+			return m.AfterDelete(db)
+`))
+}
+
+func modelWithHooks(hooks ...string) *Model {
+	sqlxPkg, dbExecutor, fieldValues := fakeSqlxPackage()
+
+	pkg := types.NewPackage("github.com/go-courier/sqlx/v2/generator/__examples__/database", "database")
+	pkg.SetImports([]*types.Package{sqlxPkg})
+
+	typeName := types.NewTypeName(token.NoPos, pkg, "Org", nil)
+	named := types.NewNamed(typeName, types.NewStruct(nil, nil), nil)
+
+	for _, hook := range hooks {
+		recv := types.NewVar(token.NoPos, pkg, "m", types.NewPointer(named))
+		named.AddMethod(types.NewFunc(token.NoPos, pkg, hook, hookSignature(recv, hook, dbExecutor, fieldValues)))
+	}
+
+	m := &Model{
+		TypeName: typeName,
+		Config:   &Config{TableName: "t_org", StructName: "Org"},
+		Keys:     &Keys{},
+		Table: builder.T("t_org",
+			builder.Col("f_id").Field("ID").Type(uint64(0), ",autoincrement"),
+		),
+		HasAutoIncrement:      true,
+		FieldKeyAutoIncrement: "ID",
+	}
+
+	m.Table.AddKey(builder.PrimaryKey(m.Table.MustFields("ID")))
+
+	return m
+}
+
+// fakeSqlxPackage declares hook interfaces like sqlx does
+func fakeSqlxPackage() (*types.Package, types.Type, types.Type) {
+	sqlxPkg := types.NewPackage("github.com/go-courier/sqlx/v2", "sqlx")
+	builderPkg := types.NewPackage("github.com/go-courier/sqlx/v2/builder", "builder")
+	sqlxPkg.SetImports([]*types.Package{builderPkg})
+
+	dbExecutor := types.NewNamed(
+		types.NewTypeName(token.NoPos, sqlxPkg, "DBExecutor", nil),
+		types.NewInterfaceType(nil, nil).Complete(),
+		nil,
+	)
+
+	fieldValues := types.NewNamed(
+		types.NewTypeName(token.NoPos, builderPkg, "FieldValues", nil),
+		types.NewMap(types.Typ[types.String], types.NewInterfaceType(nil, nil).Complete()),
+		nil,
+	)
+
+	for _, hook := range []string{"BeforeCreate", "AfterCreate", "BeforeUpdate", "AfterUpdate", "BeforeDelete", "AfterDelete"} {
+		iface := types.NewInterfaceType([]*types.Func{
+			types.NewFunc(token.NoPos, sqlxPkg, hook, hookSignature(nil, hook, dbExecutor, fieldValues)),
+		}, nil).Complete()
+
+		sqlxPkg.Scope().Insert(types.NewTypeName(token.NoPos, sqlxPkg, "With"+hook, iface))
+	}
+
+	return sqlxPkg, dbExecutor, fieldValues
+}
+
+func hookSignature(recv *types.Var, hook string, dbExecutor types.Type, fieldValues types.Type) *types.Signature {
+	params := []*types.Var{types.NewVar(token.NoPos, nil, "db", dbExecutor)}
+	if strings.HasSuffix(hook, "Update") {
+		params = append(params, types.NewVar(token.NoPos, nil, "fieldValues", fieldValues))
+	}
+
+	return types.NewSignature(
+		recv,
+		types.NewTuple(params...),
+		types.NewTuple(types.NewVar(token.NoPos, nil, "", types.Universe.Lookup("error").Type())),
+		false,
+	)
+}
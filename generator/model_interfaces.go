@@ -1,6 +1,8 @@
 package generator
 
 import (
+	"bytes"
+	"fmt"
 	"sort"
 
 	"github.com/go-courier/codegen"
@@ -147,6 +149,8 @@ func (m *Model) WriteTableInterfaces(file *codegen.File) {
 			),
 	)
 
+	m.WriteScanRow(file)
+
 	if !m.Config.WithMethods {
 		return
 	}
@@ -197,6 +201,36 @@ condition := `+file.Use("github.com/go-courier/sqlx/v2/builder", "And")+`(condit
 	)
 }
 
+// WriteScanRow writes ScanRow for scanning by positional receivers without reflection
+func (m *Model) WriteScanRow(file *codegen.File) {
+	cases := bytes.NewBuffer(nil)
+
+	m.Columns.Range(func(col *builder.Column, idx int) {
+		if col.DeprecatedActions != nil {
+			return
+		}
+
+		_, _ = fmt.Fprintf(cases, "case %q, %q:\n	dest[i] = &m.%s\n", col.Name, builder.AutoAliasName(m.Config.TableName, col.Name), col.FieldName)
+	})
+
+	file.WriteBlock(
+		codegen.Func(codegen.Var(codegen.Slice(codegen.String), "columns")).
+			Named("ScanRow").
+			MethodOf(codegen.Var(m.PtrType(), "m")).
+			Return(codegen.Var(codegen.Slice(codegen.Interface()))).
+			Do(
+				codegen.Expr(`dest := make([]interface{}, len(columns))
+
+for i := range columns {
+	switch columns[i] {
+	`+cases.String()+`}
+}
+`),
+				codegen.Return(codegen.Id("dest")),
+			),
+	)
+}
+
 func (m *Model) WriteTableKeyInterfaces(file *codegen.File) {
 	if len(m.Keys.Primary) > 0 {
 		file.WriteBlock(
@@ -210,6 +244,30 @@ func (m *Model) WriteTableKeyInterfaces(file *codegen.File) {
 		)
 	}
 
+	if len(m.Keys.Partition) > 0 {
+		file.WriteBlock(
+			codegen.Func().
+				Named("PartitionBy").
+				MethodOf(codegen.Var(m.Type())).
+				Return(codegen.Var(codegen.Slice(codegen.String))).
+				Do(
+					codegen.Return(file.Val(m.Keys.Partition)),
+				),
+		)
+	}
+
+	if m.Keys.Audit {
+		file.WriteBlock(
+			codegen.Func().
+				Named("HistoryTableName").
+				MethodOf(codegen.Var(m.Type())).
+				Return(codegen.Var(codegen.String)).
+				Do(
+					codegen.Return(file.Val(m.HistoryTableName())),
+				),
+		)
+	}
+
 	if len(m.Keys.Indexes) > 0 {
 
 		file.WriteBlock(
@@ -0,0 +1,41 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/go-courier/codegen"
+	"github.com/go-courier/sqlx/v2/builder"
+	"github.com/onsi/gomega"
+)
+
+func TestModel_WriteScanRow(t *testing.T) {
+	m := &Model{
+		Config: &Config{TableName: "t_user", StructName: "User"},
+		Table: builder.T("t_user",
+			builder.Col("f_id").Field("ID").Type(uint64(0), ""),
+			builder.Col("f_old_name").Field("OldName").Type("", ",deprecated=f_name"),
+			builder.Col("f_name").Field("Name").Type("", ""),
+		),
+	}
+
+	file := codegen.NewFile("database", "user__generated.go")
+	m.WriteScanRow(file)
+
+	gomega.NewWithT(t).Expect(string(file.Bytes())).To(gomega.Equal(`package database
+
+func (m *User) ScanRow(columns []string) []interface{} {
+	dest := make([]interface{}, len(columns))
+
+	for i := range columns {
+		switch columns[i] {
+		case "f_id", "t_user__f_id":
+			dest[i] = &m.ID
+		case "f_name", "t_user__f_name":
+			dest[i] = &m.Name
+		}
+	}
+
+	return dest
+}
+`))
+}
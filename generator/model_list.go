@@ -2,8 +2,11 @@ package generator
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/go-courier/codegen"
+	"github.com/go-courier/sqlx/v2/builder"
 )
 
 func (m *Model) WriteCount(file *codegen.File) {
@@ -154,4 +157,82 @@ return m.List(db, condition)
 				),
 		)
 	}
+
+	for _, fieldNames := range m.CompositeIndexFieldNames() {
+		m.writeBatchListByCompositeIndex(file, fieldNames)
+	}
+}
+
+// CompositeIndexFieldNames returns field names of keys with more than one field, soft delete field excluded
+func (m *Model) CompositeIndexFieldNames() (list [][]string) {
+	methods := map[string]bool{}
+
+	m.Table.Keys.Range(func(key *builder.Key, idx int) {
+		if key.Def.Expr != "" {
+			return
+		}
+
+		fieldNames := stringFilter(key.Def.FieldNames, func(item string, i int) bool {
+			if m.HasDeletedAt {
+				return item != m.FieldKeyDeletedAt
+			}
+			return true
+		})
+
+		if len(fieldNames) < 2 {
+			return
+		}
+
+		if method := createMethod("%s", fieldNames...); !methods[method] {
+			methods[method] = true
+			list = append(list, fieldNames)
+		}
+	})
+
+	return
+}
+
+func (m *Model) writeBatchListByCompositeIndex(file *codegen.File, fieldNames []string) {
+	method := createMethod("BatchFetchBy%sList", fieldNames...)
+
+	fields := make([]*codegen.SnippetField, len(fieldNames))
+	values := make([]string, len(fieldNames))
+	quotedFieldNames := make([]string, len(fieldNames))
+
+	for i, fieldName := range fieldNames {
+		fields[i] = codegen.Var(m.FieldType(file, fieldName), fieldName)
+		values[i] = "values[i]." + fieldName
+		quotedFieldNames[i] = strconv.Quote(fieldName)
+	}
+
+	file.WriteBlock(
+		codegen.Func(
+			codegen.Var(codegen.Type(file.Use("github.com/go-courier/sqlx/v2", "DBExecutor")), "db"),
+			codegen.Var(codegen.Slice(codegen.Struct(fields...)), "values"),
+		).
+			Named(method).
+			MethodOf(codegen.Var(m.PtrType(), "m")).
+			Return(
+				codegen.Var(codegen.Slice(codegen.Type(m.StructName))),
+				codegen.Var(codegen.Error),
+			).
+			Do(
+				codegen.Expr(`
+if len(values) == 0 {
+	return nil, nil
+}
+
+table := db.T(m)
+
+rowValues := make([][]interface{}, len(values))
+for i := range values {
+	rowValues[i] = []interface{}{` + strings.Join(values, ", ") + `}
+}
+
+condition := ` + file.Use("github.com/go-courier/sqlx/v2/builder", "ColumnsIn") + `(db.Dialect(), table.MustFields(` + strings.Join(quotedFieldNames, ", ") + `), rowValues...)
+
+return m.List(db, condition)
+`),
+			),
+	)
 }
@@ -0,0 +1,65 @@
+package generator
+
+import (
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/go-courier/codegen"
+	"github.com/go-courier/sqlx/v2/builder"
+	"github.com/onsi/gomega"
+)
+
+func TestModel_WriteBatchListByCompositeIndex(t *testing.T) {
+	m := &Model{
+		Config: &Config{TableName: "t_member", StructName: "Member", FieldKeyDeletedAt: "DeletedAt"},
+		Table: builder.T("t_member",
+			builder.Col("f_org_id").Field("OrgID").Type(uint64(0), ""),
+			builder.Col("f_name").Field("Name").Type("", ""),
+			builder.Col("f_deleted_at").Field("DeletedAt").Type(uint64(0), ""),
+		),
+		Fields: map[string]*types.Var{
+			"OrgID": types.NewVar(token.NoPos, nil, "OrgID", types.Typ[types.Uint64]),
+			"Name":  types.NewVar(token.NoPos, nil, "Name", types.Typ[types.String]),
+		},
+		HasDeletedAt: true,
+	}
+
+	m.Table.AddKey(builder.UniqueIndex("i_name", m.Table.MustFields("OrgID", "Name", "DeletedAt")))
+	m.Table.AddKey(builder.Index("i_org_name", m.Table.MustFields("OrgID", "Name")))
+
+	gomega.NewWithT(t).Expect(m.CompositeIndexFieldNames()).To(gomega.Equal([][]string{{"OrgID", "Name"}}))
+
+	file := codegen.NewFile("database", "member__generated.go")
+	m.writeBatchListByCompositeIndex(file, []string{"OrgID", "Name"})
+
+	gomega.NewWithT(t).Expect(string(file.Bytes())).To(gomega.Equal(`package database
+
+import (
+	github_com_go_courier_sqlx_v2 "github.com/go-courier/sqlx/v2"
+	github_com_go_courier_sqlx_v2_builder "github.com/go-courier/sqlx/v2/builder"
+)
+
+func (m *Member) BatchFetchByOrgIDAndNameList(db github_com_go_courier_sqlx_v2.DBExecutor, values []struct {
+	OrgID uint64
+	Name  string
+}) ([]Member, error) {
+
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	table := db.T(m)
+
+	rowValues := make([][]interface{}, len(values))
+	for i := range values {
+		rowValues[i] = []interface{}{values[i].OrgID, values[i].Name}
+	}
+
+	condition := github_com_go_courier_sqlx_v2_builder.ColumnsIn(db.Dialect(), table.MustFields("OrgID", "Name"), rowValues...)
+
+	return m.List(db, condition)
+
+}
+`))
+}
@@ -0,0 +1,247 @@
+package generator
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/go-courier/codegen"
+	"github.com/go-courier/sqlx/v2/builder"
+)
+
+func (m *Model) RepositoryType() codegen.SnippetType {
+	return codegen.Type(m.StructName + "Repository")
+}
+
+type repositoryMethod struct {
+	name    string
+	params  []*codegen.SnippetField
+	results []*codegen.SnippetField
+	// body of db-backed implementation
+	db []codegen.Snippet
+	// body of in-memory fake
+	fake []codegen.Snippet
+}
+
+// WriteRepository writes repository interface of model with db-backed implementation and in-memory fake,
+// hooks of model will be called by fake with executor of fake table, which fails on queries as there is no database
+func (m *Model) WriteRepository(file *codegen.File) {
+	methods := m.repositoryMethods(file)
+
+	interfaceMethods := make([]codegen.SnippetCanBeInterfaceMethod, len(methods))
+	for i, method := range methods {
+		interfaceMethods[i] = codegen.Func(method.params...).Named(method.name).Return(method.results...)
+	}
+
+	file.WriteBlock(
+		codegen.DeclType(
+			codegen.Var(codegen.Interface(interfaceMethods...), string(m.RepositoryType().Bytes())),
+		),
+	)
+
+	dbType := codegen.Type(codegen.LowerCamelCase(m.StructName + "Repository"))
+	fakeType := codegen.Type(codegen.LowerCamelCase(m.StructName + "RepositoryFake"))
+
+	file.WriteBlock(
+		codegen.Func(
+			codegen.Var(codegen.Type(file.Use("github.com/go-courier/sqlx/v2", "DBExecutor")), "db"),
+		).
+			Named("New"+m.StructName+"Repository").
+			Return(codegen.Var(m.RepositoryType())).
+			Do(
+				codegen.Return(codegen.Expr("&?{db: db}", dbType)),
+			),
+		codegen.DeclType(
+			codegen.Var(codegen.Struct(
+				codegen.Var(codegen.Type(file.Use("github.com/go-courier/sqlx/v2", "DBExecutor")), "db"),
+			), string(dbType.Bytes())),
+		),
+	)
+
+	for _, method := range methods {
+		file.WriteBlock(
+			codegen.Func(method.params...).
+				Named(method.name).
+				MethodOf(codegen.Var(codegen.Star(dbType), "r")).
+				Return(method.results...).
+				Do(method.db...),
+		)
+	}
+
+	file.WriteBlock(
+		codegen.Func().
+			Named("New"+m.StructName+"RepositoryFake").
+			Return(codegen.Var(m.RepositoryType())).
+			Do(
+				codegen.Return(codegen.Expr(
+					"&?{table: ?(&?{})}",
+					fakeType,
+					codegen.Id(file.Use("github.com/go-courier/sqlx/v2/fake", "NewTable")),
+					m.Type(),
+				)),
+			),
+		codegen.DeclType(
+			codegen.Var(codegen.Struct(
+				codegen.Var(codegen.Star(codegen.Type(file.Use("github.com/go-courier/sqlx/v2/fake", "Table"))), "table"),
+			), string(fakeType.Bytes())),
+		),
+	)
+
+	for _, method := range methods {
+		file.WriteBlock(
+			codegen.Func(method.params...).
+				Named(method.name).
+				MethodOf(codegen.Var(codegen.Star(fakeType), "r")).
+				Return(method.results...).
+				Do(method.fake...),
+		)
+	}
+}
+
+func (m *Model) repositoryMethods(file *codegen.File) (methods []*repositoryMethod) {
+	varModel := codegen.Var(m.PtrType(), "m")
+	varError := codegen.Var(codegen.Error)
+
+	// returns result of call, or result of hook when call succeeded
+	snippetReturnWithHookIfNeed := func(call string, hook string, args ...string) codegen.Snippet {
+		if m.HasHook(hook) {
+			return codegen.Expr("err := " + call + "\n" + m.returnErrWithHookIfNeed(hook, args...))
+		}
+		return codegen.Return(codegen.Expr(call))
+	}
+
+	methods = append(methods, &repositoryMethod{
+		name:    "Create",
+		params:  []*codegen.SnippetField{varModel},
+		results: []*codegen.SnippetField{varError},
+		db: []codegen.Snippet{
+			codegen.Return(codegen.Expr("m.Create(r.db)")),
+		},
+		fake: []codegen.Snippet{
+			m.snippetSetAutoUUIDIfNeed(file),
+			m.snippetSetCreatedAtIfNeed(file),
+			m.snippetSetUpdatedAtIfNeed(file),
+			m.snippetCallHookIfNeed("BeforeCreate", "r.table.Executor()"),
+			snippetReturnWithHookIfNeed("r.table.Insert(m)", "AfterCreate", "r.table.Executor()"),
+		},
+	})
+
+	m.Table.Keys.Range(func(key *builder.Key, idx int) {
+		if !key.IsUnique {
+			return
+		}
+
+		fieldNames := key.Def.FieldNames
+
+		fieldNamesWithoutEnabled := stringFilter(fieldNames, func(item string, i int) bool {
+			if m.HasDeletedAt {
+				return item != m.FieldKeyDeletedAt
+			}
+			return true
+		})
+
+		if m.HasDeletedAt && key.IsPrimary() {
+			fieldNames = append(fieldNames, m.FieldKeyDeletedAt)
+		}
+
+		quotedFieldNames := make([]string, len(fieldNames))
+		for i := range fieldNames {
+			quotedFieldNames[i] = strconv.Quote(fieldNames[i])
+		}
+		args := strings.Join(quotedFieldNames, ", ")
+
+		methodForFetch := createMethod("FetchBy%s", fieldNamesWithoutEnabled...)
+
+		methods = append(methods, &repositoryMethod{
+			name:    methodForFetch,
+			params:  []*codegen.SnippetField{varModel},
+			results: []*codegen.SnippetField{varError},
+			db: []codegen.Snippet{
+				codegen.Return(codegen.Expr("m." + methodForFetch + "(r.db)")),
+			},
+			fake: []codegen.Snippet{
+				codegen.Return(codegen.Expr("r.table.FetchBy(m, " + args + ")")),
+			},
+		})
+
+		methodForUpdateWithStruct := createMethod("UpdateBy%sWithStruct", fieldNamesWithoutEnabled...)
+
+		methods = append(methods, &repositoryMethod{
+			name: methodForUpdateWithStruct,
+			params: []*codegen.SnippetField{
+				varModel,
+				codegen.Var(codegen.Ellipsis(codegen.String), "zeroFields"),
+			},
+			results: []*codegen.SnippetField{varError},
+			db: []codegen.Snippet{
+				codegen.Return(codegen.Expr("m." + methodForUpdateWithStruct + "(r.db, zeroFields...)")),
+			},
+			fake: []codegen.Snippet{
+				codegen.Expr(`fieldValues := ` + file.Use("github.com/go-courier/sqlx/v2/builder", "FieldValuesFromStructByNonZero") + `(m, zeroFields...)`),
+				m.snippetSetUpdatedAtIfNeedForFieldValues(file),
+				m.snippetCallHookIfNeed("BeforeUpdate", "r.table.Executor()", "fieldValues"),
+				snippetReturnWithHookIfNeed("r.table.UpdateBy(m, fieldValues, "+args+")", "AfterUpdate", "r.table.Executor()", "fieldValues"),
+			},
+		})
+
+		methodForDelete := createMethod("DeleteBy%s", fieldNamesWithoutEnabled...)
+
+		methods = append(methods, &repositoryMethod{
+			name:    methodForDelete,
+			params:  []*codegen.SnippetField{varModel},
+			results: []*codegen.SnippetField{varError},
+			db: []codegen.Snippet{
+				codegen.Return(codegen.Expr("m." + methodForDelete + "(r.db)")),
+			},
+			fake: []codegen.Snippet{
+				m.snippetCallHookIfNeed("BeforeDelete", "r.table.Executor()"),
+				snippetReturnWithHookIfNeed("r.table.DeleteBy(m, "+args+")", "AfterDelete", "r.table.Executor()"),
+			},
+		})
+	})
+
+	snippetSoftDeleteCondition := func() codegen.Snippet {
+		if m.HasDeletedAt {
+			return codegen.Expr(
+				`condition = ?(condition, r.table.T().F(?).Eq(0))`,
+				codegen.Id(file.Use("github.com/go-courier/sqlx/v2/builder", "And")),
+				file.Val(m.FieldKeyDeletedAt),
+			)
+		}
+		return nil
+	}
+
+	listParams := []*codegen.SnippetField{
+		codegen.Var(codegen.Type(file.Use("github.com/go-courier/sqlx/v2/builder", "SqlCondition")), "condition"),
+		codegen.Var(codegen.Ellipsis(codegen.Type(file.Use("github.com/go-courier/sqlx/v2/builder", "Addition"))), "additions"),
+	}
+
+	methods = append(methods, &repositoryMethod{
+		name:    "List",
+		params:  listParams,
+		results: []*codegen.SnippetField{codegen.Var(codegen.Slice(m.Type())), varError},
+		db: []codegen.Snippet{
+			codegen.Return(codegen.Expr("(&?{}).List(r.db, condition, additions...)", m.Type())),
+		},
+		fake: []codegen.Snippet{
+			snippetSoftDeleteCondition(),
+			codegen.Expr(`list := make([]` + m.StructName + `, 0)
+err := r.table.List(condition, &list, additions...)`),
+			codegen.Return(codegen.Expr("list"), codegen.Expr("err")),
+		},
+	})
+
+	methods = append(methods, &repositoryMethod{
+		name:    "Count",
+		params:  listParams,
+		results: []*codegen.SnippetField{codegen.Var(codegen.Int), varError},
+		db: []codegen.Snippet{
+			codegen.Return(codegen.Expr("(&?{}).Count(r.db, condition, additions...)", m.Type())),
+		},
+		fake: []codegen.Snippet{
+			snippetSoftDeleteCondition(),
+			codegen.Return(codegen.Expr("r.table.Count(condition, additions...)")),
+		},
+	})
+
+	return
+}
@@ -0,0 +1,72 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/go-courier/codegen"
+	"github.com/go-courier/sqlx/v2/builder"
+	"github.com/onsi/gomega"
+)
+
+func TestModel_WriteRepository(t *testing.T) {
+	m := &Model{
+		Config: &Config{TableName: "t_org", StructName: "Org"},
+		Table: builder.T("t_org",
+			builder.Col("f_id").Field("ID").Type(uint64(0), ",autoincrement"),
+			builder.Col("f_name").Field("Name").Type("", ""),
+		),
+	}
+
+	m.Table.AddKey(builder.PrimaryKey(m.Table.MustFields("ID")))
+
+	file := codegen.NewFile("database", "org__generated.go")
+	m.WriteRepository(file)
+
+	code := string(file.Bytes())
+
+	gomega.NewWithT(t).Expect(code).To(gomega.ContainSubstring(`type OrgRepository interface {
+	Create(m *Org) error
+	FetchByID(m *Org) error
+	UpdateByIDWithStruct(m *Org, zeroFields ...string) error
+	DeleteByID(m *Org) error
+	List(condition github_com_go_courier_sqlx_v2_builder.SqlCondition, additions ...github_com_go_courier_sqlx_v2_builder.Addition) ([]Org, error)
+	Count(condition github_com_go_courier_sqlx_v2_builder.SqlCondition, additions ...github_com_go_courier_sqlx_v2_builder.Addition) (int, error)
+}`))
+
+	gomega.NewWithT(t).Expect(code).To(gomega.ContainSubstring(`func (r *orgRepository) FetchByID(m *Org) error {
+	return m.FetchByID(r.db)
+}`))
+
+	gomega.NewWithT(t).Expect(code).To(gomega.ContainSubstring(`func (r *orgRepositoryFake) FetchByID(m *Org) error {
+	return r.table.FetchBy(m, "ID")
+}`))
+
+	gomega.NewWithT(t).Expect(code).To(gomega.ContainSubstring(`	err := r.table.List(condition, &list, additions...)`))
+	gomega.NewWithT(t).Expect(code).To(gomega.ContainSubstring(`	return r.table.Count(condition, additions...)`))
+}
+
+func TestModel_WriteRepositoryWithHooks(t *testing.T) {
+	m := modelWithHooks("BeforeCreate", "AfterUpdate")
+
+	file := codegen.NewFile("database", "org__generated.go")
+	m.WriteRepository(file)
+
+	code := string(file.Bytes())
+
+	gomega.NewWithT(t).Expect(code).To(gomega.ContainSubstring(`
+	if err := m.BeforeCreate(r.table.Executor()); err != nil {
+		return err
+	}
+
+	return r.table.Insert(m)
+`))
+
+	gomega.NewWithT(t).Expect(code).To(gomega.ContainSubstring(`
+	err := r.table.UpdateBy(m, fieldValues, "ID")
+	if err != nil {
+		return err
+	}
+
+	return m.AfterUpdate(r.table.Executor(), fieldValues)
+`))
+}
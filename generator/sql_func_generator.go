@@ -30,6 +30,9 @@ type Config struct {
 	WithTableName       bool
 	WithTableInterfaces bool
 	WithMethods         bool
+	// WithRepository generates repository interface with db-backed implementation and in-memory fake,
+	// only works with WithMethods
+	WithRepository bool
 
 	FieldPrimaryKey   string
 	FieldKeyDeletedAt string
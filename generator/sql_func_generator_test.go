@@ -18,6 +18,7 @@ func TestSqlFuncGenerator(t *testing.T) {
 		g.WithTableName = true
 		g.WithTableInterfaces = true
 		g.WithMethods = true
+		g.WithRepository = true
 		g.Database = "DBTest"
 		g.StructName = name
 
@@ -11,14 +11,18 @@ import (
 )
 
 var (
-	defRegexp = regexp.MustCompile(`@def ([^\n]+)`)
-	relRegexp = regexp.MustCompile(`@rel ([^\n]+)`)
+	defRegexp   = regexp.MustCompile(`@def ([^\n]+)`)
+	relRegexp   = regexp.MustCompile(`@rel ([^\n]+)`)
+	tableRegexp = regexp.MustCompile(`@table ([^\n]+)`)
 )
 
 type Keys struct {
 	Primary       []string
+	Partition     []string
 	Indexes       builder.Indexes
 	UniqueIndexes builder.Indexes
+	// Audit declared by `@def audit`, changes will be written into history table
+	Audit bool
 }
 
 func (ks *Keys) PatchUniqueIndexesWithSoftDelete(softDeleteField string) {
@@ -30,6 +34,10 @@ func (ks *Keys) PatchUniqueIndexesWithSoftDelete(softDeleteField string) {
 }
 
 func (ks *Keys) Bind(table *builder.Table) {
+	if len(ks.Partition) > 0 {
+		table.Partition = builder.ParsePartitionDef(ks.Partition...)
+	}
+
 	if len(ks.Primary) > 0 {
 		key := &builder.Key{
 			Name:     "primary",
@@ -101,6 +109,25 @@ func parseColRelFromComment(doc string) (string, []string) {
 	return rel, others
 }
 
+func parseTableNameFromDoc(doc string) (string, string) {
+	others := make([]string, 0)
+
+	tableName := ""
+
+	for _, line := range strings.Split(doc, "\n") {
+		matches := tableRegexp.FindStringSubmatch(line)
+
+		if matches == nil {
+			others = append(others, line)
+			continue
+		}
+
+		tableName = strings.TrimSpace(matches[1])
+	}
+
+	return tableName, strings.Join(others, "\n")
+}
+
 func parseKeysFromDoc(doc string) (*Keys, []string) {
 	ks := &Keys{}
 
@@ -120,11 +147,18 @@ func parseKeysFromDoc(doc string) (*Keys, []string) {
 
 		for _, subMatch := range matches {
 			if len(subMatch) == 2 {
+				if strings.TrimSpace(subMatch[1]) == "audit" {
+					ks.Audit = true
+					continue
+				}
+
 				def := builder.ParseIndexDefine(subMatch[1])
 
 				switch def.Kind {
 				case "primary":
 					ks.Primary = def.ToDefs()
+				case "partition":
+					ks.Partition = builder.ParsePartitionDef(append([]string{def.Name}, def.ToDefs()...)...).ToDefs()
 				case "unique_index":
 					if ks.UniqueIndexes == nil {
 						ks.UniqueIndexes = builder.Indexes{}
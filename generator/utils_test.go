@@ -40,6 +40,31 @@ desc
 			},
 		}))
 	})
+	t.Run("parse partition", func(t *testing.T) {
+		keys, _ := parseKeysFromDoc(`
+@def primary ID CreatedAt
+@def partition RANGE CreatedAt
+`)
+		gomega.NewWithT(t).Expect(keys).To(gomega.Equal(&Keys{
+			Primary:   []string{"ID", "CreatedAt"},
+			Partition: []string{"RANGE", "CreatedAt"},
+		}))
+	})
+	t.Run("parse audit", func(t *testing.T) {
+		keys, other := parseKeysFromDoc(`
+@def primary ID
+@def audit
+summary
+`)
+		gomega.NewWithT(t).Expect(keys).To(gomega.Equal(&Keys{
+			Primary: []string{"ID"},
+			Audit:   true,
+		}))
+
+		gomega.NewWithT(t).Expect(other).To(gomega.Equal([]string{
+			"summary",
+		}))
+	})
 	t.Run("parse all", func(t *testing.T) {
 		keys, _ := parseKeysFromDoc(`
 @def primary ID
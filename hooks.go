@@ -0,0 +1,39 @@
+package sqlx
+
+import (
+	"github.com/go-courier/sqlx/v2/builder"
+)
+
+// lifecycle hooks of model, called by generated methods with the same DBExecutor,
+// any error returned by hook will stop the method and be returned.
+
+// WithBeforeCreate called by Create, CreateOnDuplicateWithUpdateFields and UpsertBy<Key> before inserting
+type WithBeforeCreate interface {
+	BeforeCreate(db DBExecutor) error
+}
+
+// WithAfterCreate called by Create, CreateOnDuplicateWithUpdateFields and UpsertBy<Key> after inserted
+type WithAfterCreate interface {
+	AfterCreate(db DBExecutor) error
+}
+
+// WithBeforeUpdate called by UpdateBy<Key>WithMap and UpdateBy<Key>WithStruct before updating,
+// fieldValues could be changed for updating
+type WithBeforeUpdate interface {
+	BeforeUpdate(db DBExecutor, fieldValues builder.FieldValues) error
+}
+
+// WithAfterUpdate called by UpdateBy<Key>WithMap and UpdateBy<Key>WithStruct after updated
+type WithAfterUpdate interface {
+	AfterUpdate(db DBExecutor, fieldValues builder.FieldValues) error
+}
+
+// WithBeforeDelete called by DeleteByStruct, DeleteBy<Key> and SoftDeleteBy<Key> before deleting
+type WithBeforeDelete interface {
+	BeforeDelete(db DBExecutor) error
+}
+
+// WithAfterDelete called by DeleteByStruct, DeleteBy<Key> and SoftDeleteBy<Key> after deleted
+type WithAfterDelete interface {
+	AfterDelete(db DBExecutor) error
+}
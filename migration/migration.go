@@ -33,10 +33,8 @@ func Migrate(db sqlx.DBExecutor, output io.Writer) error {
 	if err := db.(sqlx.Migrator).Migrate(ctx, db); err != nil {
 		return err
 	}
-	if output == nil {
-		if err := enummeta.SyncEnum(db); err != nil {
-			return err
-		}
+	if err := enummeta.SyncEnumWithOutput(db, output); err != nil {
+		return err
 	}
 	return nil
 }
@@ -0,0 +1,79 @@
+package outbox
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-courier/sqlx/v2"
+	"github.com/go-courier/sqlx/v2/builder"
+	"github.com/go-courier/sqlx/v2/datatypes"
+)
+
+// Register registers table of Message to database, for migrating
+func Register(database *sqlx.Database) *builder.Table {
+	return database.Register(&Message{})
+}
+
+// Message event waiting for publishing, written in same transaction with domain rows
+type Message struct {
+	ID    uint64 `db:"f_id,autoincrement"`
+	Topic string `db:"f_topic,size=255"`
+	// messages with same key will be published by id order, key is topic when not set
+	Key     string `db:"f_key,size=255"`
+	Payload []byte `db:"f_payload"`
+	// count of failed publishing
+	Attempts  int    `db:"f_attempts,default='0'"`
+	LastError string `db:"f_last_error,size=1024,default=''"`
+	// message will not be published before next attempt at
+	NextAttemptAt datatypes.Timestamp `db:"f_next_attempt_at,default='0'"`
+	CreatedAt     datatypes.Timestamp `db:"f_created_at,default='0'"`
+}
+
+func (Message) TableName() string {
+	return "t_outbox"
+}
+
+func (Message) PrimaryKey() []string {
+	return []string{"ID"}
+}
+
+func (Message) Indexes() builder.Indexes {
+	return builder.Indexes{
+		"i_key":             {"Key", "ID"},
+		"i_next_attempt_at": {"NextAttemptAt"},
+	}
+}
+
+// Enqueue writes message of topic to outbox, key of message will be topic.
+// db should be in transaction, like running in sqlx.Tasks, so message will be written with domain rows atomically.
+func Enqueue(db sqlx.DBExecutor, topic string, payload []byte) error {
+	return EnqueueWithKey(db, topic, topic, payload)
+}
+
+// EnqueueWithKey same as Enqueue, messages with same key will be published in order
+func EnqueueWithKey(db sqlx.DBExecutor, topic string, key string, payload []byte) error {
+	if maybeTx, ok := db.(sqlx.MaybeTxExecutor); !ok || !maybeTx.IsTx() {
+		return sqlx.ErrNotTx
+	}
+
+	m := &Message{
+		Topic:   topic,
+		Key:     key,
+		Payload: payload,
+	}
+
+	if m.Key == "" {
+		m.Key = topic
+	}
+
+	if db.T(m) == nil {
+		return fmt.Errorf("table %s of outbox is not registered", m.TableName())
+	}
+
+	now := datatypes.Timestamp(time.Now())
+	m.CreatedAt = now
+	m.NextAttemptAt = now
+
+	_, err := db.ExecExpr(sqlx.InsertToDB(db, m, nil))
+	return err
+}
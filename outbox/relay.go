@@ -0,0 +1,272 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-courier/logr"
+	"github.com/pkg/errors"
+
+	"github.com/go-courier/sqlx/v2"
+	"github.com/go-courier/sqlx/v2/builder"
+	"github.com/go-courier/sqlx/v2/datatypes"
+)
+
+// Publisher publishes messages to broker, messages are in order of id.
+// when error returned, all messages will be retried later.
+type Publisher interface {
+	Publish(ctx context.Context, messages []*Message) error
+}
+
+type PublisherFunc func(ctx context.Context, messages []*Message) error
+
+func (fn PublisherFunc) Publish(ctx context.Context, messages []*Message) error {
+	return fn(ctx, messages)
+}
+
+// Relay polls messages of outbox and hands them to Publisher.
+//
+// messages are locked by `SELECT ... FOR UPDATE SKIP LOCKED` until published,
+// so relays could run in multiple instances, but mysql 8.0+ or postgres 9.5+ is required.
+// messages are deleted after published in the same transaction, which means at least once delivery.
+type Relay struct {
+	DB        sqlx.DBExecutor
+	Publisher Publisher
+	// max count of messages for each publishing, default 100
+	BatchSize int
+	// interval of polling when no message published, default 1s
+	Interval time.Duration
+	// messages failed MaxAttempts times will be kept in outbox without publishing, and not block messages after them.
+	// 0 means retry until published
+	MaxAttempts int
+	// delay of next attempt by count of failed attempts, default ExponentialBackoff
+	Backoff func(attempts int) time.Duration
+}
+
+func (r *Relay) SetDefaults() {
+	if r.BatchSize == 0 {
+		r.BatchSize = 100
+	}
+
+	if r.Interval == 0 {
+		r.Interval = time.Second
+	}
+
+	if r.Backoff == nil {
+		r.Backoff = ExponentialBackoff
+	}
+}
+
+// ExponentialBackoff doubles delay from 1s for each failed attempt, up to 5min
+func ExponentialBackoff(attempts int) time.Duration {
+	max := 5 * time.Minute
+	if attempts < 1 {
+		return time.Second
+	}
+	if attempts > 10 {
+		return max
+	}
+	if d := time.Second << uint(attempts-1); d < max {
+		return d
+	}
+	return max
+}
+
+// Run relays messages until ctx done
+func (r *Relay) Run(ctx context.Context) error {
+	r.SetDefaults()
+
+	log := logr.FromContext(ctx)
+
+	for {
+		n, err := r.RelayOnce(ctx)
+		if err != nil {
+			log.Warn(errors.Wrap(err, "OUTBOX RELAY FAILED"))
+		}
+
+		// more messages may be waiting
+		if err == nil && n > 0 && ctx.Err() == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(r.Interval):
+		}
+	}
+}
+
+// RelayOnce publishes one batch of messages, returns count of published messages and error of publishing
+func (r *Relay) RelayOnce(ctx context.Context) (int, error) {
+	r.SetDefaults()
+
+	published := 0
+	var publishErr error
+
+	err := sqlx.NewTasks(r.DB.WithContext(ctx)).With(func(db sqlx.DBExecutor) error {
+		table := db.T(&Message{})
+		if table == nil {
+			return fmt.Errorf("table %s of outbox is not registered", (&Message{}).TableName())
+		}
+
+		messages, err := r.lock(db, table)
+		if err != nil || len(messages) == 0 {
+			return err
+		}
+
+		if publishErr = r.Publisher.Publish(ctx, messages); publishErr != nil {
+			return r.retry(db, table, messages, publishErr)
+		}
+
+		published = len(messages)
+
+		ids := make([]uint64, len(messages))
+		for i := range messages {
+			ids[i] = messages[i].ID
+		}
+
+		_, err = db.ExecExpr(
+			builder.Delete().From(
+				table,
+				builder.Where(table.F("ID").In(ids)),
+				builder.Comment("outbox.Relay.Delete"),
+			),
+		)
+		return err
+	}).Do()
+
+	if err != nil {
+		return 0, err
+	}
+
+	return published, publishErr
+}
+
+// lock selects ready messages for update, and picks them in order of keys
+func (r *Relay) lock(db sqlx.DBExecutor, table *builder.Table) ([]*Message, error) {
+	candidates := make([]Message, 0)
+
+	err := db.QueryExprAndScan(
+		builder.Select(nil).From(
+			table,
+			builder.Where(builder.And(
+				table.F("NextAttemptAt").Lte(datatypes.Timestamp(time.Now())),
+				r.alive(table),
+			)),
+			builder.OrderBy(builder.AscOrder(table.F("ID"))),
+			builder.Limit(int64(r.BatchSize)),
+			builder.ForUpdate(builder.SkipLocked),
+			builder.Comment("outbox.Relay.Lock"),
+		),
+		&candidates,
+	)
+	if err != nil || len(candidates) == 0 {
+		return nil, err
+	}
+
+	keys := make([]string, 0)
+	added := map[string]bool{}
+	for _, m := range candidates {
+		if !added[m.Key] {
+			added[m.Key] = true
+			keys = append(keys, m.Key)
+		}
+	}
+
+	// pending messages with same keys, includes ones locked by other relays or waiting for next attempt
+	pending := make([]pendingMessage, 0)
+
+	err = db.QueryExprAndScan(
+		builder.Select(table.MustFields("ID", "Key")).From(
+			table,
+			builder.Where(builder.And(
+				table.F("Key").In(keys),
+				table.F("ID").Lte(candidates[len(candidates)-1].ID),
+				r.alive(table),
+			)),
+			builder.OrderBy(builder.AscOrder(table.F("ID"))),
+			builder.Comment("outbox.Relay.Pending"),
+		),
+		&pending,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return inOrderOfKeys(candidates, pending), nil
+}
+
+func (r *Relay) alive(table *builder.Table) builder.SqlCondition {
+	if r.MaxAttempts > 0 {
+		return table.F("Attempts").Lt(r.MaxAttempts)
+	}
+	return nil
+}
+
+func (r *Relay) retry(db sqlx.DBExecutor, table *builder.Table, messages []*Message, publishErr error) error {
+	lastError := []rune(publishErr.Error())
+	if len(lastError) > 1024 {
+		lastError = lastError[0:1024]
+	}
+
+	for _, m := range messages {
+		m.Attempts++
+		m.LastError = string(lastError)
+		m.NextAttemptAt = datatypes.Timestamp(time.Now().Add(r.Backoff(m.Attempts)))
+
+		_, err := db.ExecExpr(
+			builder.Update(table).
+				Set(table.AssignmentsByFieldValues(builder.FieldValues{
+					"Attempts":      m.Attempts,
+					"LastError":     m.LastError,
+					"NextAttemptAt": m.NextAttemptAt,
+				})...).
+				Where(
+					table.F("ID").Eq(m.ID),
+					builder.Comment("outbox.Relay.Retry"),
+				),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type pendingMessage struct {
+	ID  uint64 `db:"f_id"`
+	Key string `db:"f_key"`
+}
+
+// inOrderOfKeys picks candidates, which all pending messages before them with same key are picked too.
+// both candidates and pending should be in order of id
+func inOrderOfKeys(candidates []Message, pending []pendingMessage) []*Message {
+	picked := map[uint64]bool{}
+	for i := range candidates {
+		picked[candidates[i].ID] = true
+	}
+
+	blocked := map[string]bool{}
+
+	for _, m := range pending {
+		if !picked[m.ID] {
+			blocked[m.Key] = true
+			continue
+		}
+		if blocked[m.Key] {
+			picked[m.ID] = false
+		}
+	}
+
+	messages := make([]*Message, 0, len(candidates))
+	for i := range candidates {
+		if picked[candidates[i].ID] {
+			messages = append(messages, &candidates[i])
+		}
+	}
+
+	return messages
+}
@@ -0,0 +1,128 @@
+package outbox
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-courier/sqlx/v2"
+	"github.com/go-courier/sqlx/v2/builder"
+	"github.com/go-courier/sqlx/v2/migration"
+	"github.com/go-courier/sqlx/v2/postgresqlconnector"
+	"github.com/onsi/gomega"
+)
+
+func TestExponentialBackoff(t *testing.T) {
+	gomega.NewWithT(t).Expect(ExponentialBackoff(0)).To(gomega.Equal(time.Second))
+	gomega.NewWithT(t).Expect(ExponentialBackoff(1)).To(gomega.Equal(time.Second))
+	gomega.NewWithT(t).Expect(ExponentialBackoff(3)).To(gomega.Equal(4 * time.Second))
+	gomega.NewWithT(t).Expect(ExponentialBackoff(9)).To(gomega.Equal(256 * time.Second))
+	gomega.NewWithT(t).Expect(ExponentialBackoff(10)).To(gomega.Equal(5 * time.Minute))
+	gomega.NewWithT(t).Expect(ExponentialBackoff(100)).To(gomega.Equal(5 * time.Minute))
+}
+
+func TestInOrderOfKeys(t *testing.T) {
+	candidates := []Message{
+		{ID: 2, Key: "a"},
+		{ID: 3, Key: "b"},
+		{ID: 4, Key: "a"},
+		{ID: 6, Key: "b"},
+		{ID: 7, Key: "c"},
+	}
+
+	pending := []pendingMessage{
+		// locked by other relay, or waiting for next attempt
+		{ID: 1, Key: "b"},
+		{ID: 2, Key: "a"},
+		{ID: 3, Key: "b"},
+		{ID: 4, Key: "a"},
+		{ID: 5, Key: "c"},
+		{ID: 6, Key: "b"},
+		{ID: 7, Key: "c"},
+	}
+
+	messages := inOrderOfKeys(candidates, pending)
+
+	ids := make([]uint64, len(messages))
+	for i := range messages {
+		ids[i] = messages[i].ID
+	}
+
+	gomega.NewWithT(t).Expect(ids).To(gomega.Equal([]uint64{2, 4}))
+}
+
+func TestRelay(t *testing.T) {
+	dbTest := sqlx.NewDatabase("test_outbox")
+	Register(dbTest)
+
+	// SKIP LOCKED is not supported by mysql 5.7 of testing
+	for _, connector := range []driver.Connector{
+		&postgresqlconnector.PostgreSQLConnector{
+			Host:  "postgres://postgres@0.0.0.0:5432",
+			Extra: "sslmode=disable",
+		},
+	} {
+		t.Run("", func(t *testing.T) {
+			db := dbTest.OpenDB(connector)
+
+			err := migration.Migrate(db, nil)
+			gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+
+			t.Run("enqueue should be in transaction", func(t *testing.T) {
+				err := Enqueue(db, "topic", []byte("{}"))
+				gomega.NewWithT(t).Expect(err).To(gomega.Equal(sqlx.ErrNotTx))
+			})
+
+			err = sqlx.NewTasks(db).With(func(db sqlx.DBExecutor) error {
+				for i := 0; i < 3; i++ {
+					if err := EnqueueWithKey(db, "topic", "a", []byte(fmt.Sprintf("a%d", i))); err != nil {
+						return err
+					}
+					if err := EnqueueWithKey(db, "topic", "b", []byte(fmt.Sprintf("b%d", i))); err != nil {
+						return err
+					}
+				}
+				return nil
+			}).Do()
+			gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+
+			published := make([]string, 0)
+
+			relay := &Relay{
+				DB:        db,
+				BatchSize: 4,
+				Publisher: PublisherFunc(func(ctx context.Context, messages []*Message) error {
+					if len(published) == 0 {
+						published = append(published, "")
+						return fmt.Errorf("broker unavailable")
+					}
+					for _, m := range messages {
+						published = append(published, string(m.Payload))
+					}
+					return nil
+				}),
+				Backoff: func(attempts int) time.Duration {
+					return 0
+				},
+			}
+
+			n, err := relay.RelayOnce(context.Background())
+			gomega.NewWithT(t).Expect(err).NotTo(gomega.BeNil())
+			gomega.NewWithT(t).Expect(n).To(gomega.Equal(0))
+
+			for i := 0; i < 2; i++ {
+				_, err := relay.RelayOnce(context.Background())
+				gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+			}
+
+			gomega.NewWithT(t).Expect(published[1:]).To(gomega.Equal([]string{"a0", "b0", "a1", "b1", "a2", "b2"}))
+
+			db.Tables.Range(func(table *builder.Table, idx int) {
+				_, err := db.ExecExpr(db.Dialect().DropTable(table))
+				gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+			})
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package sqlx
+
+import (
+	"time"
+
+	"github.com/go-courier/sqlx/v2/builder"
+)
+
+// CreatePartitions creates partitions of t which not exist.
+// for mysql, RANGE partitions should be in ascending order and after existed ones.
+func CreatePartitions(db DBExecutor, t *builder.Table, partitions ...*builder.Partition) error {
+	dialect, partitionNames, err := partitionNamesOf(db, t)
+	if err != nil {
+		return err
+	}
+
+	names := map[string]bool{}
+	for _, name := range partitionNames {
+		names[name] = true
+	}
+
+	for _, p := range partitions {
+		if names[p.Name] {
+			continue
+		}
+		if _, err := db.ExecExpr(dialect.AddPartition(t, p)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DropExpiredPartitions drops partitions of t named by period.PartitionName,
+// which period ended before or at expiredAt
+func DropExpiredPartitions(db DBExecutor, t *builder.Table, period builder.PartitionPeriod, expiredAt time.Time) error {
+	dialect, names, err := partitionNamesOf(db, t)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		start, ok := period.ParsePartitionName(t, name, expiredAt.Location())
+		if !ok || period.Next(start).After(expiredAt) {
+			continue
+		}
+		if _, err := db.ExecExpr(dialect.DropPartition(t, &builder.Partition{Name: name})); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func partitionNamesOf(db DBExecutor, t *builder.Table) (builder.PartitionDialect, []string, error) {
+	dialect, ok := db.Dialect().(builder.PartitionDialect)
+	if !ok {
+		return nil, nil, ErrPartitionUnsupported
+	}
+
+	introspector, ok := db.Dialect().(PartitionIntrospector)
+	if !ok {
+		return nil, nil, ErrPartitionUnsupported
+	}
+
+	names, err := introspector.PartitionNames(db, t)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return dialect, names, nil
+}
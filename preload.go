@@ -0,0 +1,216 @@
+package sqlx
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/go-courier/sqlx/v2/builder"
+	reflectx "github.com/go-courier/x/reflect"
+)
+
+// PreloadChunkSize max count of values in IN condition of each query of Preload
+var PreloadChunkSize = 1000
+
+// WithSoftDelete model with soft delete field not named DeletedAt
+type WithSoftDelete interface {
+	SoftDeleteField() string
+}
+
+// Preload loads related models into fields of v, v should be ptr of struct or slice of struct of model.
+// each field will be loaded by IN queries chunked by PreloadChunkSize, and relations resolved by Column.Relation:
+//
+// field of model or ptr of model, which referenced by column of model of v (belongs to),
+// like `Org *Org` with `OrgID` of `@rel Org.ID`;
+//
+// field of slice of model, or model without belongs to, whose column references column of model of v (has many or has one),
+// like `Orders []Order` with `Order.UserID` of `@rel User.ID`.
+//
+// related rows soft deleted will be skipped, when related model has field DeletedAt or implements WithSoftDelete.
+func Preload(db DBExecutor, v interface{}, fieldNames ...string) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr {
+		return fmt.Errorf("preload target must be a ptr value, but got %T", v)
+	}
+
+	rv = reflectx.Indirect(rv)
+
+	items := make([]reflect.Value, 0)
+
+	switch rv.Kind() {
+	case reflect.Slice:
+		for i := 0; i < rv.Len(); i++ {
+			item := rv.Index(i)
+			if item.Kind() == reflect.Ptr && item.IsNil() {
+				continue
+			}
+			items = append(items, reflectx.Indirect(item))
+		}
+	case reflect.Struct:
+		items = append(items, rv)
+	default:
+		return fmt.Errorf("preload target must be struct or slice of struct, but got %T", v)
+	}
+
+	if len(items) == 0 {
+		return nil
+	}
+
+	model, ok := reflect.New(items[0].Type()).Interface().(builder.Model)
+	if !ok {
+		return fmt.Errorf("preload target %s is not a model", items[0].Type())
+	}
+
+	table := db.D().T(model)
+	if table == nil {
+		return fmt.Errorf("model %s is not registered", items[0].Type())
+	}
+
+	for _, fieldName := range fieldNames {
+		if err := preload(db, table, items, fieldName); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func preload(db DBExecutor, table *builder.Table, items []reflect.Value, fieldName string) error {
+	sf, ok := items[0].Type().FieldByName(fieldName)
+	if !ok {
+		return fmt.Errorf("missing field %s of %s", fieldName, items[0].Type())
+	}
+
+	many := sf.Type.Kind() == reflect.Slice
+
+	targetType := sf.Type
+	if many {
+		targetType = targetType.Elem()
+	}
+
+	modelType := reflectx.Deref(targetType)
+
+	relModel, ok := reflect.New(modelType).Interface().(builder.Model)
+	if !ok {
+		return fmt.Errorf("field %s of %s is not a model or slice of model", fieldName, items[0].Type())
+	}
+
+	relTable := db.D().T(relModel)
+	if relTable == nil {
+		return fmt.Errorf("model %s of field %s is not registered", modelType, fieldName)
+	}
+
+	// from column of table, to column of relTable
+	var from, to *builder.Column
+
+	if !many {
+		from, to = relationOf(db.D(), table, relTable)
+	}
+
+	if from == nil {
+		to, from = relationOf(db.D(), relTable, table)
+	}
+
+	if from == nil {
+		return fmt.Errorf("missing relation between %s and %s for preloading %s", table.Name, relTable.Name, fieldName)
+	}
+
+	keys := make([]interface{}, 0, len(items))
+	keySet := map[string]bool{}
+
+	for _, item := range items {
+		fv := item.FieldByName(from.FieldName)
+		if fv.IsZero() {
+			continue
+		}
+
+		if k := fmt.Sprint(fv.Interface()); !keySet[k] {
+			keySet[k] = true
+			keys = append(keys, fv.Interface())
+		}
+	}
+
+	related := map[string][]reflect.Value{}
+
+	for i := 0; i < len(keys); i += PreloadChunkSize {
+		end := i + PreloadChunkSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+
+		list := reflect.New(reflect.SliceOf(modelType))
+
+		if err := db.QueryExprAndScan(
+			builder.Select(nil).From(relTable, builder.Where(builder.And(
+				to.In(keys[i:end]...),
+				softDeleteCondition(relModel, relTable),
+			))),
+			list.Interface(),
+		); err != nil {
+			return err
+		}
+
+		for j := 0; j < list.Elem().Len(); j++ {
+			relItem := list.Elem().Index(j)
+			k := fmt.Sprint(relItem.FieldByName(to.FieldName).Interface())
+			related[k] = append(related[k], relItem)
+		}
+	}
+
+	for _, item := range items {
+		values := related[fmt.Sprint(item.FieldByName(from.FieldName).Interface())]
+
+		fv := item.FieldByIndex(sf.Index)
+
+		if many {
+			s := reflect.MakeSlice(sf.Type, 0, len(values))
+			for _, value := range values {
+				s = reflect.Append(s, valueAs(value, targetType))
+			}
+			fv.Set(s)
+			continue
+		}
+
+		if len(values) > 0 {
+			fv.Set(valueAs(values[0], targetType))
+		}
+	}
+
+	return nil
+}
+
+// relationOf returns column of table which references column of relTable
+func relationOf(d *Database, table *builder.Table, relTable *builder.Table) (col *builder.Column, relCol *builder.Column) {
+	table.Columns.Range(func(c *builder.Column, idx int) {
+		if col != nil || len(c.Relation) != 2 {
+			return
+		}
+
+		if t := d.Tables.Model(c.Relation[0]); t != nil && t.Name == relTable.Name {
+			if rc := relTable.F(c.Relation[1]); rc != nil {
+				col, relCol = c, rc
+			}
+		}
+	})
+	return
+}
+
+func valueAs(rv reflect.Value, tpe reflect.Type) reflect.Value {
+	if tpe.Kind() == reflect.Ptr {
+		return rv.Addr()
+	}
+	return rv
+}
+
+// softDeleteCondition matches rows not soft deleted, nil when model without soft delete field
+func softDeleteCondition(model builder.Model, table *builder.Table) builder.SqlCondition {
+	fieldName := "DeletedAt"
+	if withSoftDelete, ok := model.(WithSoftDelete); ok {
+		fieldName = withSoftDelete.SoftDeleteField()
+	}
+
+	if col := table.F(fieldName); col != nil {
+		return col.Eq(0)
+	}
+
+	return nil
+}
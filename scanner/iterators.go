@@ -37,7 +37,7 @@ type SliceScanIterator struct {
 }
 
 func (s *SliceScanIterator) New() interface{} {
-	return reflectx.New(s.elemType).Addr().Interface()
+	return reflectx.New(reflect.PtrTo(s.elemType)).Interface()
 }
 
 func (s *SliceScanIterator) Next(v interface{}) error {
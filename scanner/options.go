@@ -0,0 +1,58 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	contextx "github.com/go-courier/x/context"
+)
+
+// Options of scanning
+type Options struct {
+	// Strict makes scanning to struct failed,
+	// when any column of result not matched to field,
+	// or any db-tagged field of model of TableName not filled by columns.
+	Strict bool
+	// TableName of query, which fields of its model should be filled in strict mode
+	TableName string
+}
+
+type contextKeyOptions struct{}
+
+func ContextWithOptions(ctx context.Context, opts Options) context.Context {
+	return contextx.WithValue(ctx, contextKeyOptions{}, opts)
+}
+
+func OptionsFromContext(ctx context.Context) Options {
+	if ctx == nil {
+		return Options{}
+	}
+	if opts, ok := ctx.Value(contextKeyOptions{}).(Options); ok {
+		return opts
+	}
+	return Options{}
+}
+
+// StrictScanError reports columns and fields mismatched in strict mode
+type StrictScanError struct {
+	Type             string
+	UnmatchedColumns []string
+	UnfilledFields   []string
+}
+
+func (e *StrictScanError) Error() string {
+	b := strings.Builder{}
+
+	_, _ = fmt.Fprintf(&b, "strict scan to %s failed", e.Type)
+
+	if len(e.UnmatchedColumns) > 0 {
+		_, _ = fmt.Fprintf(&b, ", unmatched columns: %s", strings.Join(e.UnmatchedColumns, ", "))
+	}
+
+	if len(e.UnfilledFields) > 0 {
+		_, _ = fmt.Fprintf(&b, ", unfilled fields: %s", strings.Join(e.UnfilledFields, ", "))
+	}
+
+	return b.String()
+}
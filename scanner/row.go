@@ -0,0 +1,137 @@
+package scanner
+
+import (
+	"database/sql"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	typeRow = reflect.TypeOf(Row{})
+	typeMap = reflect.TypeOf(map[string]interface{}{})
+)
+
+// Row dynamic row, values converted by database type name of columns
+type Row struct {
+	columnTypes []*sql.ColumnType
+	values      []interface{}
+}
+
+func (r *Row) ColumnTypes() []*sql.ColumnType {
+	return r.columnTypes
+}
+
+func (r *Row) Columns() []string {
+	columns := make([]string, len(r.columnTypes))
+	for i := range r.columnTypes {
+		columns[i] = r.columnTypes[i].Name()
+	}
+	return columns
+}
+
+func (r *Row) Values() []interface{} {
+	return r.values
+}
+
+// Get value of column, nil when column not exists
+func (r *Row) Get(column string) interface{} {
+	for i := range r.columnTypes {
+		if r.columnTypes[i].Name() == column {
+			return r.values[i]
+		}
+	}
+	return nil
+}
+
+// Map values by column names, value of latter one will be kept for same name
+func (r *Row) Map() map[string]interface{} {
+	m := make(map[string]interface{}, len(r.columnTypes))
+	for i := range r.columnTypes {
+		m[r.columnTypes[i].Name()] = r.values[i]
+	}
+	return m
+}
+
+func scanToRow(rows *sql.Rows, r *Row) error {
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return err
+	}
+
+	values := make([]interface{}, len(columnTypes))
+	dest := make([]interface{}, len(columnTypes))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+
+	if err := rows.Scan(dest...); err != nil {
+		return err
+	}
+
+	for i := range values {
+		values[i] = convertValue(columnTypes[i], values[i])
+	}
+
+	r.columnTypes = columnTypes
+	r.values = values
+
+	return nil
+}
+
+func scanToMap(rows *sql.Rows, rv reflect.Value) error {
+	r := &Row{}
+
+	if err := scanToRow(rows, r); err != nil {
+		return err
+	}
+
+	if rv.IsNil() {
+		rv.Set(reflect.MakeMapWithSize(rv.Type(), len(r.values)))
+	}
+
+	m := rv.Interface().(map[string]interface{})
+
+	for i := range r.columnTypes {
+		m[r.columnTypes[i].Name()] = r.values[i]
+	}
+
+	return nil
+}
+
+// convertValue converts raw bytes by database type name into int64, uint64, float64, bool, json.RawMessage or string,
+// bytes of binary types and decimals (as string) will be kept for precision.
+func convertValue(columnType *sql.ColumnType, v interface{}) interface{} {
+	b, ok := v.([]byte)
+	if !ok {
+		return v
+	}
+
+	typeName := strings.ToUpper(columnType.DatabaseTypeName())
+
+	switch typeName {
+	case "TINYINT", "SMALLINT", "MEDIUMINT", "INT", "INTEGER", "BIGINT", "INT2", "INT4", "INT8", "YEAR":
+		if i, err := strconv.ParseInt(string(b), 10, 64); err == nil {
+			return i
+		}
+	case "UNSIGNED TINYINT", "UNSIGNED SMALLINT", "UNSIGNED MEDIUMINT", "UNSIGNED INT", "UNSIGNED BIGINT":
+		if i, err := strconv.ParseUint(string(b), 10, 64); err == nil {
+			return i
+		}
+	case "FLOAT", "DOUBLE", "REAL", "FLOAT4", "FLOAT8":
+		if f, err := strconv.ParseFloat(string(b), 64); err == nil {
+			return f
+		}
+	case "BOOL", "BOOLEAN":
+		if v, err := strconv.ParseBool(string(b)); err == nil {
+			return v
+		}
+	case "JSON", "JSONB":
+		return json.RawMessage(b)
+	case "BINARY", "VARBINARY", "TINYBLOB", "BLOB", "MEDIUMBLOB", "LONGBLOB", "BYTEA", "BIT", "GEOMETRY":
+		return b
+	}
+
+	return string(b)
+}
@@ -3,7 +3,9 @@ package scanner
 import (
 	"context"
 	"database/sql"
+	"reflect"
 
+	reflectx "github.com/go-courier/x/reflect"
 	"github.com/pkg/errors"
 )
 
@@ -20,18 +22,37 @@ func Scan(ctx context.Context, rows *sql.Rows, v interface{}) error {
 		return err
 	}
 
-	for rows.Next() {
+	var groups *rowGroups
+
+	for i := 0; rows.Next(); i++ {
 		item := si.New()
 
-		if scanErr := scanTo(context.Background(), rows, item); scanErr != nil {
+		if scanErr := scanTo(ctx, rows, item); scanErr != nil {
 			return scanErr
 		}
 
+		if i == 0 {
+			groups = rowGroupsFor(item)
+		}
+
+		if groups != nil {
+			groups.add(item)
+			continue
+		}
+
 		if err := si.Next(item); err != nil {
 			return err
 		}
 	}
 
+	if groups != nil {
+		for _, item := range groups.items {
+			if err := si.Next(item); err != nil {
+				return err
+			}
+		}
+	}
+
 	if mustHasRecord, ok := si.(interface{ MustHasRecord() bool }); ok {
 		if !mustHasRecord.MustHasRecord() {
 			return RecordNotFound
@@ -49,3 +70,49 @@ func Scan(ctx context.Context, rows *sql.Rows, v interface{}) error {
 
 	return nil
 }
+
+// rowGroupsFor returns rowGroups when struct of item has slices of model,
+// rows will be grouped, and items should be received after all rows scanned.
+func rowGroupsFor(item interface{}) *rowGroups {
+	if _, ok := item.(sql.Scanner); ok {
+		return nil
+	}
+
+	tpe := reflectx.Deref(reflect.TypeOf(item))
+	if tpe.Kind() != reflect.Struct {
+		return nil
+	}
+
+	if implementsOwn(tpe, typeWithScanRow) || implementsOwn(tpe, typeWithColumnReceivers) {
+		return nil
+	}
+
+	plan := structPlanFor(tpe)
+	if len(plan.many()) == 0 {
+		return nil
+	}
+
+	return &rowGroups{plan: plan, indexes: map[string]int{}}
+}
+
+type rowGroups struct {
+	plan    *structPlan
+	indexes map[string]int
+	items   []interface{}
+}
+
+func (g *rowGroups) add(item interface{}) {
+	rv := indirect(reflect.ValueOf(item))
+	key := g.plan.key(rv)
+
+	if i, ok := g.indexes[key]; ok {
+		// item may be reused by ScanIterator, like SingleScanIterator
+		if prev := g.items[i]; prev != item {
+			g.plan.merge(indirect(reflect.ValueOf(prev)), rv)
+		}
+		return
+	}
+
+	g.indexes[key] = len(g.items)
+	g.items = append(g.items, item)
+}
@@ -2,6 +2,7 @@ package scanner
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
@@ -38,6 +39,74 @@ func (l *TDataList) Next(v interface{}) error {
 	return nil
 }
 
+type User struct {
+	ID   uint64 `db:"f_id"`
+	Name string `db:"f_name"`
+}
+
+func (User) TableName() string {
+	return "t_user"
+}
+
+func (User) PrimaryKey() []string {
+	return []string{"ID"}
+}
+
+// UserWithScanRow like generated model
+type UserWithScanRow struct {
+	ID   uint64 `db:"f_id"`
+	Name string `db:"f_name"`
+}
+
+func (UserWithScanRow) TableName() string {
+	return "t_user"
+}
+
+func (m *UserWithScanRow) ScanRow(columns []string) []interface{} {
+	dest := make([]interface{}, len(columns))
+
+	for i := range columns {
+		switch columns[i] {
+		case "f_id", "t_user__f_id":
+			dest[i] = &m.ID
+		case "f_name", "t_user__f_name":
+			dest[i] = &m.Name
+		}
+	}
+
+	return dest
+}
+
+type Org struct {
+	ID   uint64 `db:"f_id"`
+	Name string `db:"f_name"`
+}
+
+func (Org) TableName() string {
+	return "t_org"
+}
+
+type Order struct {
+	ID     uint64 `db:"f_id"`
+	UserID uint64 `db:"f_user_id"`
+}
+
+func (Order) TableName() string {
+	return "t_order"
+}
+
+type UserWithOrgAndOrders struct {
+	User
+	Org     *Org
+	Manager *User `alias:"manager"`
+	Orders  []Order
+}
+
+type UserWithOrgName struct {
+	OrgName string `db:"f_name" alias:"t_org"`
+	Name    string `db:"f_name"`
+}
+
 func BenchmarkScan(b *testing.B) {
 	db, mock, _ := sqlmock.New()
 	defer db.Close()
@@ -215,4 +284,201 @@ func TestScan(t *testing.T) {
 			},
 		}))
 	})
+	t.Run("Scan to nested struct", func(t *testing.T) {
+		mockRows := mock.NewRows([]string{"t_user__f_id", "t_user__f_name", "t_org__f_id", "t_org__f_name", "manager__f_id", "manager__f_name", "t_order__f_id", "t_order__f_user_id"})
+		mockRows.AddRow(1, "a", 1, "org", nil, nil, 1, 1)
+		mockRows.AddRow(1, "a", 1, "org", nil, nil, 2, 1)
+		mockRows.AddRow(2, "b", nil, nil, 1, "a", nil, nil)
+
+		_ = mock.ExpectQuery("SELECT .+ from t_user").WillReturnRows(mockRows)
+
+		rows, err := db.Query("SELECT * from t_user")
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+
+		list := make([]UserWithOrgAndOrders, 0)
+
+		err = Scan(context.Background(), rows, &list)
+
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(list).To(gomega.Equal([]UserWithOrgAndOrders{
+			{
+				User:   User{ID: 1, Name: "a"},
+				Org:    &Org{ID: 1, Name: "org"},
+				Orders: []Order{{ID: 1, UserID: 1}, {ID: 2, UserID: 1}},
+			},
+			{
+				User:    User{ID: 2, Name: "b"},
+				Manager: &User{ID: 1, Name: "a"},
+			},
+		}))
+	})
+
+	t.Run("Scan to single nested struct", func(t *testing.T) {
+		mockRows := mock.NewRows([]string{"t_user__f_id", "t_user__f_name", "t_order__f_id", "t_order__f_user_id"})
+		mockRows.AddRow(1, "a", 1, 1)
+		mockRows.AddRow(1, "a", 2, 1)
+
+		_ = mock.ExpectQuery("SELECT .+ from t_user").WillReturnRows(mockRows)
+
+		rows, err := db.Query("SELECT * from t_user")
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+
+		target := &UserWithOrgAndOrders{}
+
+		err = Scan(context.Background(), rows, target)
+
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(target).To(gomega.Equal(&UserWithOrgAndOrders{
+			User:   User{ID: 1, Name: "a"},
+			Orders: []Order{{ID: 1, UserID: 1}, {ID: 2, UserID: 1}},
+		}))
+	})
+
+	t.Run("Scan to struct with aliased field", func(t *testing.T) {
+		mockRows := mock.NewRows([]string{"t_org__f_name", "f_name"})
+		mockRows.AddRow("org", "a")
+
+		_ = mock.ExpectQuery("SELECT .+ from t_user").WillReturnRows(mockRows)
+
+		rows, err := db.Query("SELECT * from t_user")
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+
+		target := &UserWithOrgName{}
+
+		err = Scan(context.Background(), rows, target)
+
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(target).To(gomega.Equal(&UserWithOrgName{OrgName: "org", Name: "a"}))
+	})
+
+	t.Run("Scan strictly", func(t *testing.T) {
+		ctx := ContextWithOptions(context.Background(), Options{Strict: true, TableName: "t_user"})
+
+		t.Run("matched", func(t *testing.T) {
+			mockRows := mock.NewRows([]string{"f_id", "f_name"})
+			mockRows.AddRow(1, "a")
+
+			_ = mock.ExpectQuery("SELECT .+ from t_user").WillReturnRows(mockRows)
+
+			rows, _ := db.Query("SELECT * from t_user")
+
+			target := &User{}
+			err := Scan(ctx, rows, target)
+			gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+			gomega.NewWithT(t).Expect(target).To(gomega.Equal(&User{ID: 1, Name: "a"}))
+		})
+
+		t.Run("mismatched", func(t *testing.T) {
+			mockRows := mock.NewRows([]string{"f_id", "f_nickname"})
+			mockRows.AddRow(1, "a")
+
+			_ = mock.ExpectQuery("SELECT .+ from t_user").WillReturnRows(mockRows)
+
+			rows, _ := db.Query("SELECT * from t_user")
+
+			err := Scan(ctx, rows, &User{})
+			gomega.NewWithT(t).Expect(err).To(gomega.Equal(&StrictScanError{
+				Type:             "scanner.User",
+				UnmatchedColumns: []string{"f_nickname"},
+				UnfilledFields:   []string{"Name"},
+			}))
+			gomega.NewWithT(t).Expect(err.Error()).To(gomega.Equal("strict scan to scanner.User failed, unmatched columns: f_nickname, unfilled fields: Name"))
+		})
+
+		t.Run("unfilled fields of model with ScanRow", func(t *testing.T) {
+			mockRows := mock.NewRows([]string{"f_id"})
+			mockRows.AddRow(1)
+
+			_ = mock.ExpectQuery("SELECT .+ from t_user").WillReturnRows(mockRows)
+
+			rows, _ := db.Query("SELECT f_id from t_user")
+
+			err := Scan(ctx, rows, &UserWithScanRow{})
+			gomega.NewWithT(t).Expect(err).To(gomega.Equal(&StrictScanError{
+				Type:           "scanner.UserWithScanRow",
+				UnfilledFields: []string{"Name"},
+			}))
+		})
+
+		t.Run("model with ScanRow matched", func(t *testing.T) {
+			mockRows := mock.NewRows([]string{"f_id", "f_name"})
+			mockRows.AddRow(1, "a")
+
+			_ = mock.ExpectQuery("SELECT .+ from t_user").WillReturnRows(mockRows)
+
+			rows, _ := db.Query("SELECT * from t_user")
+
+			target := &UserWithScanRow{}
+			err := Scan(ctx, rows, target)
+			gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+			gomega.NewWithT(t).Expect(target).To(gomega.Equal(&UserWithScanRow{ID: 1, Name: "a"}))
+		})
+
+		t.Run("fields of other table not required", func(t *testing.T) {
+			mockRows := mock.NewRows([]string{"f_id"})
+			mockRows.AddRow(1)
+
+			_ = mock.ExpectQuery("SELECT .+ from t_org").WillReturnRows(mockRows)
+
+			rows, _ := db.Query("SELECT * from t_org")
+
+			err := Scan(ContextWithOptions(context.Background(), Options{Strict: true, TableName: "t_org"}), rows, &User{})
+			gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		})
+	})
+	t.Run("Scan to map and Row", func(t *testing.T) {
+		newRows := func() *sqlmock.Rows {
+			return sqlmock.NewRowsWithColumnDefinition(
+				sqlmock.NewColumn("f_id").OfType("UNSIGNED BIGINT", uint64(0)),
+				sqlmock.NewColumn("f_name").OfType("VARCHAR", ""),
+				sqlmock.NewColumn("f_score").OfType("DOUBLE", float64(0)),
+				sqlmock.NewColumn("f_extra").OfType("JSON", []byte{}),
+				sqlmock.NewColumn("f_deleted_at").OfType("BIGINT", int64(0)),
+			).
+				AddRow([]byte("1"), []byte("a"), []byte("1.5"), []byte(`{"a":1}`), nil).
+				AddRow([]byte("2"), []byte("b"), []byte("2"), []byte(`[]`), []byte("-1"))
+		}
+
+		t.Run("map", func(t *testing.T) {
+			_ = mock.ExpectQuery("SELECT .+ from t").WillReturnRows(newRows())
+			rows, _ := db.Query("SELECT * from t")
+
+			m := map[string]interface{}{}
+			err := Scan(context.Background(), rows, &m)
+			gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+			gomega.NewWithT(t).Expect(m).To(gomega.Equal(map[string]interface{}{
+				"f_id":         uint64(2),
+				"f_name":       "b",
+				"f_score":      float64(2),
+				"f_extra":      json.RawMessage(`[]`),
+				"f_deleted_at": int64(-1),
+			}))
+		})
+
+		t.Run("slice of map", func(t *testing.T) {
+			_ = mock.ExpectQuery("SELECT .+ from t").WillReturnRows(newRows())
+			rows, _ := db.Query("SELECT * from t")
+
+			list := make([]map[string]interface{}, 0)
+			err := Scan(context.Background(), rows, &list)
+			gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+			gomega.NewWithT(t).Expect(list).To(gomega.HaveLen(2))
+			gomega.NewWithT(t).Expect(list[0]["f_score"]).To(gomega.Equal(1.5))
+			gomega.NewWithT(t).Expect(list[0]["f_deleted_at"]).To(gomega.BeNil())
+		})
+
+		t.Run("slice of Row", func(t *testing.T) {
+			_ = mock.ExpectQuery("SELECT .+ from t").WillReturnRows(newRows())
+			rows, _ := db.Query("SELECT * from t")
+
+			list := make([]*Row, 0)
+			err := Scan(context.Background(), rows, &list)
+			gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+			gomega.NewWithT(t).Expect(list).To(gomega.HaveLen(2))
+			gomega.NewWithT(t).Expect(list[1].Columns()).To(gomega.Equal([]string{"f_id", "f_name", "f_score", "f_extra", "f_deleted_at"}))
+			gomega.NewWithT(t).Expect(list[1].ColumnTypes()[0].DatabaseTypeName()).To(gomega.Equal("UNSIGNED BIGINT"))
+			gomega.NewWithT(t).Expect(list[1].Values()).To(gomega.Equal([]interface{}{uint64(2), "b", float64(2), json.RawMessage(`[]`), int64(-1)}))
+			gomega.NewWithT(t).Expect(list[1].Get("f_name")).To(gomega.Equal("b"))
+		})
+	})
 }
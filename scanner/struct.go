@@ -6,8 +6,8 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"sync"
 
-	"github.com/go-courier/sqlx/v2/builder"
 	"github.com/go-courier/sqlx/v2/scanner/nullable"
 	reflectx "github.com/go-courier/x/reflect"
 )
@@ -20,6 +20,17 @@ type WithColumnReceivers interface {
 	ColumnReceivers() map[string]interface{}
 }
 
+// WithScanRow returns receivers by index of columns (in lower case) for scanning without reflection,
+// receiver should be nil for unknown column.
+type WithScanRow interface {
+	ScanRow(columns []string) []interface{}
+}
+
+var (
+	typeWithColumnReceivers = reflect.TypeOf((*WithColumnReceivers)(nil)).Elem()
+	typeWithScanRow         = reflect.TypeOf((*WithScanRow)(nil)).Elem()
+)
+
 func scanTo(ctx context.Context, rows *sql.Rows, v interface{}) error {
 	tpe := reflect.TypeOf(v)
 
@@ -27,12 +38,26 @@ func scanTo(ctx context.Context, rows *sql.Rows, v interface{}) error {
 		return fmt.Errorf("scanTo target must be a ptr value, but got %T", v)
 	}
 
+	// **T to *T, like item of []*T
+	if rv := reflect.ValueOf(v); rv.Elem().Kind() == reflect.Ptr {
+		v = indirect(rv).Addr().Interface()
+		tpe = reflect.TypeOf(v)
+	}
+
 	if s, ok := v.(sql.Scanner); ok {
 		return rows.Scan(s)
 	}
 
 	tpe = reflectx.Deref(tpe)
 
+	if tpe == typeRow {
+		return scanToRow(rows, indirect(reflect.ValueOf(v)).Addr().Interface().(*Row))
+	}
+
+	if tpe == typeMap {
+		return scanToMap(rows, indirect(reflect.ValueOf(v)))
+	}
+
 	switch tpe.Kind() {
 	case reflect.Struct:
 		columns, err := rows.Columns()
@@ -48,45 +73,122 @@ func scanTo(ctx context.Context, rows *sql.Rows, v interface{}) error {
 		dest := make([]interface{}, n)
 		holder := placeholder()
 
-		if withColumnReceivers, ok := v.(WithColumnReceivers); ok {
+		if withScanRow, ok := v.(WithScanRow); ok && implementsOwn(tpe, typeWithScanRow) {
+			// nil receivers only tell unmatched columns, fields unfilled are checked by binding of columns
+			if opts := OptionsFromContext(ctx); opts.Strict {
+				if err := structBindingFor(tpe, columns).strictErr(tpe, opts.TableName); err != nil {
+					return err
+				}
+			}
+
+			lowerColumns := make([]string, n)
+			for i := range columns {
+				lowerColumns[i] = strings.ToLower(columns[i])
+			}
+
+			receivers := withScanRow.ScanRow(lowerColumns)
+
+			unmatchedColumns := make([]string, 0)
+
+			for i := range columns {
+				if receivers[i] != nil {
+					dest[i] = nullable.NewNullIgnoreScanner(receivers[i])
+				} else {
+					dest[i] = holder
+					unmatchedColumns = append(unmatchedColumns, columns[i])
+				}
+			}
+
+			if OptionsFromContext(ctx).Strict && len(unmatchedColumns) > 0 {
+				return &StrictScanError{Type: tpe.String(), UnmatchedColumns: unmatchedColumns}
+			}
+
+			return rows.Scan(dest...)
+		}
+
+		if withColumnReceivers, ok := v.(WithColumnReceivers); ok && implementsOwn(tpe, typeWithColumnReceivers) {
 			columnReceivers := withColumnReceivers.ColumnReceivers()
 
+			unmatchedColumns := make([]string, 0)
+
 			for i, columnName := range columns {
 				if cr, ok := columnReceivers[strings.ToLower(columnName)]; ok {
 					dest[i] = nullable.NewNullIgnoreScanner(cr)
 				} else {
 					dest[i] = holder
+					unmatchedColumns = append(unmatchedColumns, columnName)
 				}
 			}
 
+			if OptionsFromContext(ctx).Strict && len(unmatchedColumns) > 0 {
+				return &StrictScanError{Type: tpe.String(), UnmatchedColumns: unmatchedColumns}
+			}
+
 			return rows.Scan(dest...)
 		}
 
-		columnIndexes := map[string]int{}
+		b := structBindingFor(tpe, columns)
 
-		for i, columnName := range columns {
-			columnIndexes[strings.ToLower(columnName)] = i
+		if opts := OptionsFromContext(ctx); opts.Strict {
+			if err := b.strictErr(tpe, opts.TableName); err != nil {
+				return err
+			}
+		}
+
+		for i := range dest {
 			dest[i] = holder
 		}
 
-		builder.ForEachStructFieldValue(ctx, v, func(sf *builder.StructFieldValue) {
-			if sf.TableName != "" {
-				if i, ok := columnIndexes[sf.TableName+"__"+sf.Field.Name]; ok && i > -1 {
-					dest[i] = nullable.NewNullIgnoreScanner(sf.Value.Addr().Interface())
-				}
-			}
+		afterScan := b.bind(indirect(reflect.ValueOf(v)), dest, nil)
 
-			if i, ok := columnIndexes[sf.Field.Name]; ok && i > -1 {
-				dest[i] = nullable.NewNullIgnoreScanner(sf.Value.Addr().Interface())
-			}
-		})
+		if err := rows.Scan(dest...); err != nil {
+			return err
+		}
 
-		return rows.Scan(dest...)
+		for _, fn := range afterScan {
+			fn()
+		}
+
+		return nil
 	default:
 		return rows.Scan(nullable.NewNullIgnoreScanner(v))
 	}
 }
 
+var ownImplements sync.Map
+
+type ownImplementsKey struct {
+	tpe   reflect.Type
+	iface reflect.Type
+}
+
+// implementsOwn returns false when ptr of struct type implements iface only by methods promoted from embedded fields,
+// like ScanRow of embedded model, which could not receive columns of other fields.
+func implementsOwn(tpe reflect.Type, iface reflect.Type) bool {
+	key := ownImplementsKey{tpe: tpe, iface: iface}
+
+	if v, ok := ownImplements.Load(key); ok {
+		return v.(bool)
+	}
+
+	own := reflect.PtrTo(tpe).Implements(iface)
+
+	if own && tpe.Kind() == reflect.Struct {
+		for i := 0; i < tpe.NumField(); i++ {
+			f := tpe.Field(i)
+
+			if f.Anonymous && (f.Type.Implements(iface) || reflect.PtrTo(f.Type).Implements(iface)) {
+				own = false
+				break
+			}
+		}
+	}
+
+	ownImplements.Store(key, own)
+
+	return own
+}
+
 func placeholder() sql.Scanner {
 	p := emptyScanner(0)
 	return &p
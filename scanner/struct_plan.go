@@ -0,0 +1,431 @@
+package scanner
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"go/ast"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/go-courier/sqlx/v2/builder"
+	"github.com/go-courier/sqlx/v2/scanner/nullable"
+	reflectx "github.com/go-courier/x/reflect"
+)
+
+var (
+	typeModel        = reflect.TypeOf((*builder.Model)(nil)).Elem()
+	typeDriverValuer = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+)
+
+var structPlans sync.Map
+
+// structPlanFor returns mapping of columns to fields of struct type.
+//
+// fields of struct type will be matched by column name, or by builder.AutoAliasName when struct is model;
+// nested struct ptr of model, like `Org *Org`, will be matched by builder.AutoAliasName of its table only,
+// and will be kept nil when all its columns are NULL;
+// slice of model, like `Orders []Order`, will be collected from rows grouped by primary key (or all columns) of struct.
+//
+// table name of nested struct could be overwritten by tag `alias`, for joining same table more than once.
+func structPlanFor(tpe reflect.Type) *structPlan {
+	if v, ok := structPlans.Load(tpe); ok {
+		return v.(*structPlan)
+	}
+
+	p := newStructPlan(tpe, tableNameOf(tpe), true)
+
+	if len(p.many()) > 0 {
+		p.keys = p.keyLocs(tpe)
+	}
+
+	structPlans.Store(tpe, p)
+
+	return p
+}
+
+type structPlan struct {
+	fields []*fieldPlan
+	nested []*nestedPlan
+	// locs of fields to group rows for collecting slices of model
+	keys [][]int
+}
+
+type fieldPlan struct {
+	fieldName string
+	tableName string
+	loc       []int
+	names     []string
+}
+
+type nestedPlan struct {
+	loc      []int
+	many     bool
+	elemType reflect.Type
+	plan     *structPlan
+}
+
+func newStructPlan(tpe reflect.Type, tableName string, root bool) *structPlan {
+	p := &structPlan{}
+	p.walk(tpe, tableName, root, nil)
+	return p
+}
+
+func (p *structPlan) walk(tpe reflect.Type, tableName string, root bool, parents []int) {
+	for i := 0; i < tpe.NumField(); i++ {
+		f := tpe.Field(i)
+
+		if !ast.IsExported(f.Name) {
+			continue
+		}
+
+		loc := append(append([]int{}, parents...), i)
+
+		tags := reflectx.ParseStructTags(string(f.Tag))
+
+		tagDB, hasDB := tags["db"]
+		if hasDB && tagDB.Name() == "-" {
+			continue
+		}
+
+		if !hasDB && !f.Type.Implements(typeDriverValuer) {
+			if structType := reflectx.Deref(f.Type); structType.Kind() == reflect.Struct {
+				isPtr := f.Type.Kind() == reflect.Ptr
+
+				if f.Anonymous || (!isPtr && f.Type.Name() == f.Name) {
+					p.walk(structType, tableNameOr(structType, tableName), root, loc)
+					continue
+				}
+
+				if isPtr && (structType.Name() == f.Name || isModel(structType)) {
+					p.nested = append(p.nested, &nestedPlan{
+						loc:      loc,
+						elemType: structType,
+						plan:     newStructPlan(structType, aliasOr(tags, tableNameOf(structType)), false),
+					})
+					continue
+				}
+			}
+
+			if root && f.Type.Kind() == reflect.Slice {
+				if structType := reflectx.Deref(f.Type.Elem()); structType.Kind() == reflect.Struct && isModel(structType) {
+					p.nested = append(p.nested, &nestedPlan{
+						loc:      loc,
+						many:     true,
+						elemType: f.Type.Elem(),
+						plan:     newStructPlan(structType, aliasOr(tags, tableNameOf(structType)), false),
+					})
+					continue
+				}
+			}
+		}
+
+		// alias only for this field
+		fieldTableName := tableName
+		if tableAlias, ok := tags["alias"]; ok {
+			fieldTableName = tableAlias.Name()
+		}
+
+		if !hasDB || tagDB == "" || tagDB.HasFlag("deprecated") {
+			continue
+		}
+
+		name := f.Name
+		if n := tagDB.Name(); n != "" {
+			name = n
+		}
+		name = strings.ToLower(name)
+
+		fp := &fieldPlan{fieldName: f.Name, tableName: fieldTableName, loc: loc}
+
+		if fieldTableName != "" {
+			fp.names = append(fp.names, builder.AutoAliasName(fieldTableName, name))
+		}
+
+		if root {
+			fp.names = append(fp.names, name)
+		}
+
+		p.fields = append(p.fields, fp)
+	}
+}
+
+func (p *structPlan) many() (list []*nestedPlan) {
+	for _, n := range p.nested {
+		if n.many {
+			list = append(list, n)
+		}
+	}
+	return
+}
+
+func (p *structPlan) keyLocs(tpe reflect.Type) (keys [][]int) {
+	if withPrimaryKey, ok := reflect.New(tpe).Interface().(builder.WithPrimaryKey); ok {
+		for _, fieldName := range withPrimaryKey.PrimaryKey() {
+			for _, f := range p.fields {
+				if f.fieldName == fieldName {
+					keys = append(keys, f.loc)
+				}
+			}
+		}
+
+		if len(keys) == len(withPrimaryKey.PrimaryKey()) {
+			return keys
+		}
+	}
+
+	keys = make([][]int, len(p.fields))
+	for i := range p.fields {
+		keys[i] = p.fields[i].loc
+	}
+	return keys
+}
+
+var structBindings sync.Map
+
+type structBindingKey struct {
+	tpe     reflect.Type
+	columns string
+}
+
+// structBindingFor returns binding of columns to fields of struct type, cached by type and columns
+func structBindingFor(tpe reflect.Type, columns []string) *structBinding {
+	key := structBindingKey{tpe: tpe, columns: strings.Join(columns, ",")}
+
+	if v, ok := structBindings.Load(key); ok {
+		return v.(*structBinding)
+	}
+
+	columnIndexes := make(map[string]int, len(columns))
+	for i, columnName := range columns {
+		columnIndexes[strings.ToLower(columnName)] = i
+	}
+
+	b := structPlanFor(tpe).binding(columnIndexes)
+
+	matched := make([]bool, len(columns))
+	b.markMatched(matched)
+
+	for i := range columns {
+		if !matched[i] {
+			b.unmatchedColumns = append(b.unmatchedColumns, columns[i])
+		}
+	}
+
+	structBindings.Store(key, b)
+
+	return b
+}
+
+type structBinding struct {
+	fields []*fieldBinding
+	nested []*nestedBinding
+	// columns not matched to any field
+	unmatchedColumns []string
+	// fields not filled by any column
+	unfilledFields []*fieldPlan
+}
+
+type fieldBinding struct {
+	loc    []int
+	column int
+}
+
+type nestedBinding struct {
+	*nestedPlan
+	binding *structBinding
+}
+
+func (p *structPlan) binding(columnIndexes map[string]int) *structBinding {
+	b := &structBinding{}
+
+	for _, f := range p.fields {
+		filled := false
+
+		for _, name := range f.names {
+			if i, ok := columnIndexes[name]; ok {
+				b.fields = append(b.fields, &fieldBinding{loc: f.loc, column: i})
+				filled = true
+			}
+		}
+
+		if !filled {
+			b.unfilledFields = append(b.unfilledFields, f)
+		}
+	}
+
+	for _, n := range p.nested {
+		nb := n.plan.binding(columnIndexes)
+
+		// nested struct will never be set when no columns matched
+		if len(nb.fields) == 0 && len(nb.nested) == 0 {
+			continue
+		}
+
+		b.nested = append(b.nested, &nestedBinding{nestedPlan: n, binding: nb})
+	}
+
+	return b
+}
+
+func (b *structBinding) markMatched(matched []bool) {
+	for _, f := range b.fields {
+		matched[f.column] = true
+	}
+	for _, n := range b.nested {
+		n.binding.markMatched(matched)
+	}
+}
+
+// strictErr returns error when any column unmatched,
+// or any field of model of table named tableName unfilled.
+func (b *structBinding) strictErr(tpe reflect.Type, tableName string) error {
+	unfilledFields := make([]string, 0)
+
+	if tableName != "" {
+		for _, f := range b.unfilledFields {
+			if f.tableName == tableName {
+				unfilledFields = append(unfilledFields, f.fieldName)
+			}
+		}
+	}
+
+	if len(b.unmatchedColumns) == 0 && len(unfilledFields) == 0 {
+		return nil
+	}
+
+	return &StrictScanError{
+		Type:             tpe.String(),
+		UnmatchedColumns: b.unmatchedColumns,
+		UnfilledFields:   unfilledFields,
+	}
+}
+
+// bind puts receivers of fields of rv into dest,
+// returns funcs to set nested structs after scanned.
+// when notNull is not nil, it will be marked once any value of bound columns is not NULL.
+func (b *structBinding) bind(rv reflect.Value, dest []interface{}, notNull *bool) (afterScan []func()) {
+	for _, f := range b.fields {
+		dest[f.column] = receiverOf(fieldByLoc(rv, f.loc).Addr().Interface(), notNull)
+	}
+
+	for i := range b.nested {
+		n := b.nested[i]
+
+		v := reflect.New(reflectx.Deref(n.elemType))
+		nestedNotNull := false
+
+		nestedAfterScan := n.binding.bind(v.Elem(), dest, &nestedNotNull)
+
+		afterScan = append(afterScan, func() {
+			for _, fn := range nestedAfterScan {
+				fn()
+			}
+
+			if !nestedNotNull {
+				return
+			}
+
+			fv := fieldByLoc(rv, n.loc)
+
+			if n.many {
+				if n.elemType.Kind() == reflect.Ptr {
+					fv.Set(reflect.Append(fv, v))
+				} else {
+					fv.Set(reflect.Append(fv, v.Elem()))
+				}
+				return
+			}
+
+			fv.Set(v)
+		})
+	}
+
+	return
+}
+
+// key of rv for grouping rows
+func (p *structPlan) key(rv reflect.Value) string {
+	values := make([]interface{}, len(p.keys))
+	for i, loc := range p.keys {
+		values[i] = fieldByLoc(rv, loc).Interface()
+	}
+	return fmt.Sprintf("%#v", values)
+}
+
+// merge appends slices of model of src into dest
+func (p *structPlan) merge(dest reflect.Value, src reflect.Value) {
+	for _, n := range p.many() {
+		fv := fieldByLoc(dest, n.loc)
+		fv.Set(reflect.AppendSlice(fv, fieldByLoc(src, n.loc)))
+	}
+}
+
+func receiverOf(v interface{}, notNull *bool) sql.Scanner {
+	s := nullable.NewNullIgnoreScanner(v)
+	if notNull == nil {
+		return s
+	}
+	return &notNullMarkScanner{notNull: notNull, Scanner: s}
+}
+
+type notNullMarkScanner struct {
+	notNull *bool
+	sql.Scanner
+}
+
+func (s *notNullMarkScanner) Scan(src interface{}) error {
+	if src != nil {
+		*s.notNull = true
+	}
+	return s.Scanner.Scan(src)
+}
+
+func fieldByLoc(rv reflect.Value, loc []int) reflect.Value {
+	for i, idx := range loc {
+		rv = rv.Field(idx)
+
+		// last loc should keep ptr value
+		if i < len(loc)-1 {
+			rv = indirect(rv)
+		}
+	}
+	return rv
+}
+
+// indirect like reflectx.Indirect, but nil ptr will be set with new value
+func indirect(rv reflect.Value) reflect.Value {
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			rv.Set(reflect.New(rv.Type().Elem()))
+		}
+		rv = rv.Elem()
+	}
+	return rv
+}
+
+func isModel(tpe reflect.Type) bool {
+	return reflect.PtrTo(tpe).Implements(typeModel)
+}
+
+func tableNameOf(tpe reflect.Type) string {
+	if isModel(tpe) {
+		return reflect.New(tpe).Interface().(builder.Model).TableName()
+	}
+	return ""
+}
+
+func tableNameOr(tpe reflect.Type, tableName string) string {
+	if n := tableNameOf(tpe); n != "" {
+		return n
+	}
+	return tableName
+}
+
+func aliasOr(tags map[string]reflectx.StructTag, tableName string) string {
+	if tableAlias, ok := tags["alias"]; ok && tableAlias.Name() != "" {
+		return tableAlias.Name()
+	}
+	return tableName
+}
@@ -2,6 +2,8 @@ package scanner
 
 import (
 	"context"
+	"database/sql/driver"
+	"fmt"
 	"testing"
 
 	"github.com/go-courier/sqlx/v2/scanner/nullable"
@@ -73,3 +75,146 @@ func BenchmarkScanStruct(b *testing.B) {
 		b.Log(target)
 	})
 }
+
+// Wide model of wide table for benchmarks
+type Wide struct {
+	F0  int64  `db:"f_0"`
+	F1  string `db:"f_1"`
+	F2  int64  `db:"f_2"`
+	F3  string `db:"f_3"`
+	F4  int64  `db:"f_4"`
+	F5  string `db:"f_5"`
+	F6  int64  `db:"f_6"`
+	F7  string `db:"f_7"`
+	F8  int64  `db:"f_8"`
+	F9  string `db:"f_9"`
+	F10 int64  `db:"f_10"`
+	F11 string `db:"f_11"`
+	F12 int64  `db:"f_12"`
+	F13 string `db:"f_13"`
+	F14 int64  `db:"f_14"`
+	F15 string `db:"f_15"`
+	F16 int64  `db:"f_16"`
+	F17 string `db:"f_17"`
+	F18 int64  `db:"f_18"`
+	F19 string `db:"f_19"`
+	F20 int64  `db:"f_20"`
+	F21 string `db:"f_21"`
+	F22 int64  `db:"f_22"`
+	F23 string `db:"f_23"`
+}
+
+// WideWithScanRow like generated ScanRow
+type WideWithScanRow Wide
+
+func (m *WideWithScanRow) ScanRow(columns []string) []interface{} {
+	dest := make([]interface{}, len(columns))
+
+	for i := range columns {
+		switch columns[i] {
+		case "f_0":
+			dest[i] = &m.F0
+		case "f_1":
+			dest[i] = &m.F1
+		case "f_2":
+			dest[i] = &m.F2
+		case "f_3":
+			dest[i] = &m.F3
+		case "f_4":
+			dest[i] = &m.F4
+		case "f_5":
+			dest[i] = &m.F5
+		case "f_6":
+			dest[i] = &m.F6
+		case "f_7":
+			dest[i] = &m.F7
+		case "f_8":
+			dest[i] = &m.F8
+		case "f_9":
+			dest[i] = &m.F9
+		case "f_10":
+			dest[i] = &m.F10
+		case "f_11":
+			dest[i] = &m.F11
+		case "f_12":
+			dest[i] = &m.F12
+		case "f_13":
+			dest[i] = &m.F13
+		case "f_14":
+			dest[i] = &m.F14
+		case "f_15":
+			dest[i] = &m.F15
+		case "f_16":
+			dest[i] = &m.F16
+		case "f_17":
+			dest[i] = &m.F17
+		case "f_18":
+			dest[i] = &m.F18
+		case "f_19":
+			dest[i] = &m.F19
+		case "f_20":
+			dest[i] = &m.F20
+		case "f_21":
+			dest[i] = &m.F21
+		case "f_22":
+			dest[i] = &m.F22
+		case "f_23":
+			dest[i] = &m.F23
+		}
+	}
+
+	return dest
+}
+
+func BenchmarkScanWideTable(b *testing.B) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	columns := make([]string, 0)
+	values := make([]driver.Value, 0)
+
+	for i := 0; i < 24; i++ {
+		columns = append(columns, fmt.Sprintf("f_%d", i))
+		if i%2 == 0 {
+			values = append(values, int64(i))
+		} else {
+			values = append(values, fmt.Sprintf("%d", i))
+		}
+	}
+
+	mockRows := mock.NewRows(columns)
+
+	b.Run("Scan by reflect", func(b *testing.B) {
+		target := &Wide{}
+		_ = mock.ExpectQuery("SELECT .+ from t").WillReturnRows(mockRows)
+
+		rows, _ := db.Query("SELECT * from t")
+
+		for i := 0; i < b.N; i++ {
+			mockRows.AddRow(values...)
+
+			if rows.Next() {
+				_ = scanTo(context.Background(), rows, target)
+			}
+		}
+
+		b.Log(target.F1)
+	})
+
+	b.Run("Scan by ScanRow", func(b *testing.B) {
+		target := &WideWithScanRow{}
+		_ = mock.ExpectQuery("SELECT .+ from t").WillReturnRows(mockRows)
+
+		rows, _ := db.Query("SELECT * from t")
+
+		for i := 0; i < b.N; i++ {
+			mockRows.AddRow(values...)
+
+			if rows.Next() {
+				_ = scanTo(context.Background(), rows, target)
+			}
+		}
+
+		b.Log(target.F1)
+	})
+}
@@ -9,8 +9,16 @@ import (
 
 type ScanIterator = scanner.ScanIterator
 
+// Row dynamic row for scanning, with values converted by column types
+type Row = scanner.Row
+
 func Scan(rows *sql.Rows, v interface{}) error {
-	if err := scanner.Scan(context.Background(), rows, v); err != nil {
+	return ScanContext(context.Background(), rows, v)
+}
+
+// ScanContext scans rows into v, scanning could be strict by scanner.ContextWithOptions
+func ScanContext(ctx context.Context, rows *sql.Rows, v interface{}) error {
+	if err := scanner.Scan(ctx, rows, v); err != nil {
 		if err == scanner.RecordNotFound {
 			return NewSqlError(sqlErrTypeNotFound, "record is not found")
 		}
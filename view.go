@@ -0,0 +1,28 @@
+package sqlx
+
+import (
+	"fmt"
+
+	"github.com/go-courier/sqlx/v2/builder"
+)
+
+// RefreshMaterializedView refreshes materialized view,
+// concurrently refreshing without locking out selects needs an unique index on the view.
+func RefreshMaterializedView(db DBExecutor, view *builder.Table, concurrently bool) error {
+	if !view.IsMaterializedView() {
+		return fmt.Errorf("%s is not materialized view", view.Name)
+	}
+
+	dialect, ok := db.Dialect().(builder.ViewDialect)
+	if !ok {
+		return ErrViewUnsupported
+	}
+
+	expr := dialect.RefreshMaterializedView(view, concurrently)
+	if expr == nil || expr.IsNil() {
+		return nil
+	}
+
+	_, err := db.ExecExpr(expr)
+	return err
+}